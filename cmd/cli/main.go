@@ -13,11 +13,12 @@ import (
 const version = "0.1.0"
 
 func main() {
-	// Detect mode: if stdin is not a TTY, run as MCP server
+	// Detect mode: if stdin is not a TTY and no command is given, run as MCP server.
+	// Commands may still read piped input (e.g. 'briefing --scores -').
 	stat, _ := os.Stdin.Stat()
 	isTTY := (stat.Mode() & os.ModeCharDevice) != 0
 
-	if !isTTY {
+	if !isTTY && len(os.Args) == 1 {
 		// MCP server mode
 		server := mcp.NewWisdomServer()
 		if err := server.Run(context.Background(), os.Stdin, os.Stdout); err != nil {
@@ -2,17 +2,47 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/davidl71/devwisdom-go/internal/mcp"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
 )
 
 func main() {
+	// Exit only after run's deferred cleanup has closed the consultation log
+	if err := run(); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+}
+
+// run serves MCP over the selected transport until it stops or fails.
+func run() error {
+	transport := flag.String("transport", "stdio", "Transport to serve MCP over: stdio or http")
+	addr := flag.String("addr", mcp.DefaultHTTPAddr, "Listen address for the http transport (e.g., :8765)")
+	watchInterval := flag.Duration("watch-interval", wisdom.DefaultWatchInterval, "How often to check sources and config files for changes to reload (0 disables)")
+	flag.Parse()
+
+	// Stop on Ctrl+C or SIGTERM; the http transport shuts down gracefully
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Create MCP server using SDK adapter
-	server := mcp.NewWisdomServerSDK()
+	server := mcp.NewWisdomServerSDK().WithWatchInterval(*watchInterval)
+	defer server.Close()
 
-	// Run server with stdio transport (handled by SDK)
-	if err := server.Run(context.Background()); err != nil {
-		log.Fatalf("Server error: %v", err)
+	switch *transport {
+	case "stdio":
+		// Run server with stdio transport (handled by SDK)
+		return server.Run(ctx)
+	case "http":
+		// Serve streamable HTTP (/mcp) with SSE fallback (/sse); clients share one engine
+		return server.RunHTTP(ctx, *addr)
+	default:
+		return fmt.Errorf("unknown transport %q (expected stdio or http)", *transport)
 	}
 }
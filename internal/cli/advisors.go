@@ -26,6 +26,11 @@ func (a *App) runAdvisors(args []string) error {
 		return fmt.Errorf("failed to initialize wisdom engine: %w", err)
 	}
 
+	// Report advisors.json entries that were skipped (stderr keeps --json output clean)
+	for _, err := range engine.AdvisorConfigErrors() {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
 	// Get advisors registry
 	advisors := engine.GetAdvisors()
 
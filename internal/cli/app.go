@@ -3,6 +3,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 )
@@ -41,6 +42,12 @@ func (a *App) Run(args []string) error {
 		return a.runAdvisors(commandArgs)
 	case "briefing":
 		return a.runBriefing(commandArgs)
+	case "health":
+		return a.runHealth(commandArgs)
+	case "search":
+		return a.runSearch(commandArgs)
+	case "log":
+		return a.runLog(commandArgs)
 	case "version", "-v", "--version":
 		fmt.Printf("devwisdom version %s\n", a.version)
 		return nil
@@ -48,10 +55,23 @@ func (a *App) Run(args []string) error {
 		a.printUsage()
 		return nil
 	default:
-		return fmt.Errorf("unknown command %q: available commands are quote, consult, briefing, sources, advisors - use 'devwisdom help' for usage", command)
+		return fmt.Errorf("unknown command %q: available commands are quote, consult, briefing, health, search, log, sources, advisors - use 'devwisdom help' for usage", command)
 	}
 }
 
+// printDisabled handles output when wisdom is disabled by configuration
+// (EXARP_DISABLE_WISDOM=1 or a .exarp_no_wisdom marker file).
+// Text output is suppressed entirely; JSON output reports the disabled state
+// so scripts can distinguish it from an error.
+func printDisabled(jsonOutput bool) error {
+	if !jsonOutput {
+		return nil
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{"disabled": true})
+}
+
 // printUsage prints the help message
 func (a *App) printUsage() {
 	fmt.Fprintf(os.Stderr, `devwisdom - Wisdom quotes and advisor consultations
@@ -62,9 +82,18 @@ USAGE:
 COMMANDS:
     quote       Get a wisdom quote
     consult     Consult an advisor
-    sources     List available wisdom sources
+    sources     List available wisdom sources (--explain: show defining files)
+                (sources fetch: download Sefaria texts for offline use;
+                 sources validate [--strict] [files...]: check sources.json files)
     advisors    List available advisors
     briefing    Get daily briefing
+    health      Score project health from the local repository
+    search      Search quotes by keyword, prefix (learn*), or "phrase"
+    log         Query the consultation log (filters, pages, --aggregate)
+                (log tail [-f]: show and stream new consultations;
+                 log stats: advisor frequency and score trend;
+                 log export --format csv|jsonl|markdown;
+                 log maintain: prune, compress and compact rotated files)
     version     Show version
     help        Show this help message
 
@@ -73,7 +102,32 @@ EXAMPLES:
     devwisdom quote --source stoic --score 75
     devwisdom consult --metric security --score 40
     devwisdom sources
+    devwisdom sources --explain
+    devwisdom sources fetch
+    devwisdom sources validate --json ~/.wisdom/sources.json
     devwisdom briefing --days 7
+    devwisdom health
+    devwisdom quote --health
+    devwisdom search courage --source stoic
+    devwisdom log --advisor stoic --days 30 --aggregate advisors,daily_scores
+    devwisdom log tail -f --metric security
+    devwisdom log stats --days 90
+    devwisdom log export --format csv -o consultations.csv
+    devwisdom log maintain --max-age-days 90 --compress
+
+CONFIGURATION:
+    EXARP_WISDOM_SOURCE=<id>     Default source for 'quote' (or "random")
+    EXARP_WISDOM_HEBREW=1        Enable Hebrew sources (rebbe, tzaddik, chacham) with translations
+    EXARP_WISDOM_HEBREW_ONLY=1   Use only Hebrew sources, without translations
+    EXARP_DISABLE_WISDOM=1       Disable wisdom output (also: .exarp_no_wisdom file)
+    EXARP_WISDOM_ROTATION=<mode> Quote rotation: daily (default), cycle, or cycle_daily
+    EXARP_WISDOM_TIMEZONE=<tz>   Time zone for the daily quote (e.g., UTC; default: local)
+    EXARP_WISDOM_DAY_START_HOUR=<h> Hour (0-23) at which a new day begins
+    EXARP_WISDOM_LOG_RETENTION_DAYS=<n> Delete rotated consultation logs older than n days
+    EXARP_WISDOM_LOG_MAX_SIZE_MB=<n>    Delete the oldest rotated logs beyond n MB in total
+    EXARP_WISDOM_LOG_COMPRESS=1  Gzip rotated consultation logs
+    EXARP_WISDOM_LOG_COMPACT=1   Merge past months' rotated logs into one file per month
+    XDG_CACHE_HOME=<dir>         Sefaria text cache location (<dir>/devwisdom/sefaria)
 
 For more information, see: https://github.com/davidl71/devwisdom-go
 `)
@@ -76,7 +76,7 @@ func TestApp_RunAdvisors(t *testing.T) {
 func TestApp_RunBriefing(t *testing.T) {
 	app := NewApp("0.1.0")
 
-	err := app.Run([]string{"briefing"})
+	err := app.Run([]string{"briefing", "--log-dir", t.TempDir()})
 	if err != nil {
 		t.Logf("Briefing command returned error (expected if no sources): %v", err)
 	}
@@ -1,26 +1,69 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/davidl71/devwisdom-go/internal/health"
+	"github.com/davidl71/devwisdom-go/internal/logging"
 	"github.com/davidl71/devwisdom-go/internal/wisdom"
 )
 
+// metricScoresFlag collects repeated --metric name=score flags.
+type metricScoresFlag map[string]float64
+
+func (m metricScoresFlag) String() string {
+	pairs := make([]string, 0, len(m))
+	for name, score := range m {
+		pairs = append(pairs, fmt.Sprintf("%s=%g", name, score))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (m metricScoresFlag) Set(value string) error {
+	name, scoreText, found := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !found || name == "" {
+		return fmt.Errorf("invalid metric %q: expected name=score (e.g., security=40)", value)
+	}
+	score, err := strconv.ParseFloat(strings.TrimSpace(scoreText), 64)
+	if err != nil {
+		return fmt.Errorf("invalid score for metric %q: %w", name, err)
+	}
+	if err := wisdom.ValidateScore(score); err != nil {
+		return fmt.Errorf("metric %q: %w", name, err)
+	}
+	m[name] = score
+	return nil
+}
+
 // runBriefing handles the briefing command
 func (a *App) runBriefing(args []string) error {
 	fs := flag.NewFlagSet("briefing", flag.ExitOnError)
-	days := fs.Int("days", 1, "Number of days to include in briefing")
-	score := fs.Float64("score", 50.0, "Overall project score (0-100) for consultation mode")
+	days := fs.Int("days", 7, "Days of consultation history to compare against for trends")
+	score := fs.Float64("score", 50.0, "Overall project score (0-100) for consultation mode (defaults to the scorecard's overall score or the mean of the metric scores)")
+	scoresFile := fs.String("scores", "", "Scorecard JSON file with metric scores ('-' reads stdin)")
+	metricFlags := metricScoresFlag{}
+	fs.Var(metricFlags, "metric", "Metric score as name=score (repeatable, overrides --scores)")
+	limit := fs.Int("limit", wisdom.DefaultBriefingLimit, "Number of weakest metrics to include")
+	logDir := fs.String("log-dir", ".devwisdom", "Consultation log directory used for trends")
 	jsonOutput := fs.Bool("json", false, "Output in JSON format")
 
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
+	scoreSet := false
+	fs.Visit(func(f *flag.Flag) {
+		scoreSet = scoreSet || f.Name == "score"
+	})
 
 	// Initialize wisdom engine
 	engine := wisdom.NewEngine()
@@ -28,67 +71,41 @@ func (a *App) runBriefing(args []string) error {
 		return fmt.Errorf("failed to initialize wisdom engine: %w", err)
 	}
 
-	// Get consultation mode based on provided score
-	mode := wisdom.GetConsultationMode(*score)
-
-	// For now, use sample metric scores (can be parameterized or from consultation log later)
-	// Note: Full consultation log integration comes in Phase 5
-	sampleMetricScores := map[string]float64{
-		"security":      40.0,
-		"testing":       30.0,
-		"documentation": 60.0,
-		"completion":    70.0,
-		"alignment":     50.0,
+	// Wisdom disabled by configuration: produce no output
+	if engine.IsDisabled() {
+		return printDisabled(*jsonOutput)
 	}
 
-	// Find lowest scoring metrics (need most advice)
-	type metricScore struct {
-		metric string
-		score  float64
+	card, scoresFrom, err := briefingScorecard(*scoresFile, metricFlags)
+	if err != nil {
+		return err
 	}
-	metrics := make([]metricScore, 0, len(sampleMetricScores))
-	for m, s := range sampleMetricScores {
-		metrics = append(metrics, metricScore{metric: m, score: s})
+	if !scoreSet {
+		*score = card.OverallScore()
+	}
+	if err := wisdom.ValidateScore(*score); err != nil {
+		return fmt.Errorf("invalid --score: %w", err)
 	}
-	sort.Slice(metrics, func(i, j int) bool {
-		return metrics[i].score < metrics[j].score
-	})
-
-	// Get advisors
-	advisors := engine.GetAdvisors()
-
-	// Build briefing with advisor quotes for lowest 3 metrics
-	briefingQuotes := make([]map[string]interface{}, 0)
-	for i, ms := range metrics {
-		if i >= 3 {
-			break
-		}
 
-		advisorInfo, err := advisors.GetAdvisorForMetric(ms.metric)
-		if err != nil {
-			continue
-		}
+	// Get consultation mode based on the overall score
+	mode := wisdom.GetConsultationMode(*score)
 
-		// Get quote from advisor's source
-		source, exists := engine.GetSource(advisorInfo.Advisor)
-		if !exists {
-			continue
+	// Trends compare against previous consultations; a missing log only means no trends
+	var history []*wisdom.Consultation
+	logger, err := logging.NewConsultationLogger(*logDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: consultation log unavailable, trends omitted: %v\n", err)
+	} else {
+		defer logger.Close()
+		logger.WithClock(engine.Clock(), engine.DayBoundary()).
+			WithRetention(logging.RetentionFromConfig(engine.GetConfig()))
+		if history, err = logger.GetLogs(*days); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read consultation log: %v\n", err)
 		}
-
-		aeonLevel := wisdom.GetAeonLevel(ms.score)
-		quote := source.GetQuote(aeonLevel)
-
-		briefingQuotes = append(briefingQuotes, map[string]interface{}{
-			"metric":        ms.metric,
-			"score":         ms.score,
-			"advisor":       advisorInfo.Advisor,
-			"advisor_icon":  advisorInfo.Icon,
-			"quote":         quote.Quote,
-			"source":        quote.Source,
-			"encouragement": quote.Encouragement,
-		})
 	}
 
+	briefingQuotes := engine.BuildBriefing(card.Metrics, *limit, history)
+
 	// Output
 	if *jsonOutput {
 		result := map[string]interface{}{
@@ -100,8 +117,9 @@ func (a *App) runBriefing(args []string) error {
 				"frequency":   mode.Frequency,
 				"description": mode.Description,
 			},
+			"metric_scores":   card.Metrics,
+			"scores_from":     scoresFrom,
 			"advisory_quotes": briefingQuotes,
-			"note":            "Consultation log integration coming in Phase 5",
 		}
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
@@ -116,32 +134,92 @@ func (a *App) runBriefing(args []string) error {
 	fmt.Println()
 
 	for _, bq := range briefingQuotes {
-		metric := bq["metric"].(string)
-		score := bq["score"].(float64)
-		advisorIcon := bq["advisor_icon"].(string)
-		advisor := bq["advisor"].(string)
-		quote := bq["quote"].(string)
-		encouragement := bq["encouragement"].(string)
-
-		quotePreview := quote
-		if len(quotePreview) > 55 {
-			quotePreview = quotePreview[:55] + "..."
-		}
-		encPreview := encouragement
-		if len(encPreview) > 55 {
-			encPreview = encPreview[:55]
-		}
+		quotePreview := truncateText(bq.Quote, 55, "...")
+		encPreview := truncateText(bq.Encouragement, 55, "")
 
-		fmt.Printf("║  %s %s: %.0f%%\n", advisorIcon, strings.ToUpper(metric), score)
-		fmt.Printf("║     Advisor: %s\n", advisor)
+		fmt.Printf("║  %s %s: %.0f%%%s\n", bq.AdvisorIcon, strings.ToUpper(bq.Metric), bq.Score, formatTrend(bq.Trend))
+		fmt.Printf("║     Advisor: %s\n", bq.Advisor)
 		fmt.Printf("║     \"%s\"\n", quotePreview)
+		if bq.Translation != "" {
+			fmt.Printf("║     (%s)\n", truncateText(bq.Translation, 55, "..."))
+		}
 		fmt.Printf("║     💡 %s\n", encPreview)
 		fmt.Println()
 	}
 
 	fmt.Println("╚══════════════════════════════════════════════════════════════════════╝")
 	fmt.Println()
-	fmt.Println("Note: Consultation log integration coming in Phase 5")
+	fmt.Printf("Scores from: %s\n", scoresFrom)
 
 	return nil
 }
+
+// truncateText shortens text to at most max characters, appending suffix if
+// it was shortened. It counts runes so Hebrew text is not cut mid-character.
+func truncateText(text string, max int, suffix string) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max]) + suffix
+}
+
+// briefingScorecard resolves the briefing's metric scores from --scores and
+// --metric flags, falling back to the health of the current repository.
+// It also returns a description of where the scores came from.
+func briefingScorecard(scoresFile string, metricFlags metricScoresFlag) (*wisdom.Scorecard, string, error) {
+	card := &wisdom.Scorecard{Metrics: make(map[string]float64)}
+	var from []string
+
+	if scoresFile != "" {
+		var data []byte
+		var err error
+		if scoresFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+			scoresFile = "stdin"
+		} else {
+			data, err = os.ReadFile(scoresFile)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read scorecard %q: %w", scoresFile, err)
+		}
+		if card, err = wisdom.ParseScorecard(data); err != nil {
+			return nil, "", fmt.Errorf("invalid scorecard %q: %w", scoresFile, err)
+		}
+		from = append(from, scoresFile)
+	}
+
+	if len(metricFlags) > 0 {
+		for name, score := range metricFlags {
+			card.Metrics[name] = score
+		}
+		from = append(from, "--metric flags")
+	}
+
+	if len(from) == 0 {
+		report, err := health.NewAnalyzer(".").Analyze(context.Background())
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to analyze project health (pass --scores or --metric instead): %w", err)
+		}
+		card.Metrics = report.MetricScores()
+		card.Overall, card.HasOverall = report.Score, true
+		from = append(from, "health of "+report.Root)
+	}
+
+	return card, strings.Join(from, " + "), nil
+}
+
+// formatTrend renders a metric trend for human-readable output.
+func formatTrend(trend *wisdom.MetricTrend) string {
+	if trend == nil {
+		return ""
+	}
+	arrow := "→"
+	switch trend.Direction {
+	case wisdom.TrendImproving:
+		arrow = "↑"
+	case wisdom.TrendDeclining:
+		arrow = "↓"
+	}
+	return fmt.Sprintf(" %s %+.1f since %.0f%%", arrow, trend.Change, trend.PreviousScore)
+}
@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/logging"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
 )
 
 func TestRunBriefing(t *testing.T) {
@@ -110,7 +115,7 @@ func TestRunBriefing(t *testing.T) {
 				done <- true
 			}()
 
-			err := app.runBriefing(tt.args)
+			err := app.runBriefing(append(tt.args, "--log-dir", t.TempDir()))
 
 			w.Close()
 			os.Stdout = oldStdout
@@ -130,3 +135,110 @@ func TestRunBriefing(t *testing.T) {
 		})
 	}
 }
+
+func TestRunBriefing_MetricScores(t *testing.T) {
+	app := NewApp("0.1.0")
+	logDir := t.TempDir()
+
+	scoresFile := filepath.Join(t.TempDir(), "scorecard.json")
+	if err := os.WriteFile(scoresFile, []byte(`{"overall_score": 45, "scores": {"security": 40, "testing": 30, "documentation": 90}}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	// runJSON runs the briefing with --json and decodes its output
+	runJSON := func(t *testing.T, stdin string, args ...string) map[string]interface{} {
+		t.Helper()
+		if stdin != "" {
+			r, w, _ := os.Pipe()
+			oldStdin := os.Stdin
+			os.Stdin = r
+			defer func() { os.Stdin = oldStdin }()
+			go func() {
+				w.WriteString(stdin)
+				w.Close()
+			}()
+		}
+
+		r, w, _ := os.Pipe()
+		oldStdout := os.Stdout
+		os.Stdout = w
+		var buf bytes.Buffer
+		done := make(chan bool)
+		go func() {
+			buf.ReadFrom(r)
+			done <- true
+		}()
+
+		err := app.runBriefing(append(args, "--json", "--log-dir", logDir))
+
+		w.Close()
+		os.Stdout = oldStdout
+		<-done
+
+		if err != nil {
+			t.Fatalf("runBriefing() error = %v", err)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+		}
+		return result
+	}
+
+	weakest := func(result map[string]interface{}) []string {
+		quotes, _ := result["advisory_quotes"].([]interface{})
+		metrics := make([]string, 0, len(quotes))
+		for _, q := range quotes {
+			metrics = append(metrics, q.(map[string]interface{})["metric"].(string))
+		}
+		return metrics
+	}
+
+	t.Run("scores file", func(t *testing.T) {
+		result := runJSON(t, "", "--scores", scoresFile, "--limit", "2")
+		if got := weakest(result); strings.Join(got, ",") != "testing,security" {
+			t.Errorf("advisory metrics = %v, want testing,security", got)
+		}
+		if result["overall_score"] != 45.0 {
+			t.Errorf("overall_score = %v, want scorecard overall 45", result["overall_score"])
+		}
+	})
+
+	t.Run("stdin with metric override and trend", func(t *testing.T) {
+		// Trends compare against advisor consultations; briefings are not logged
+		logger, err := logging.NewConsultationLogger(logDir)
+		if err != nil {
+			t.Fatalf("NewConsultationLogger failed: %v", err)
+		}
+		err = logger.Log(&wisdom.Consultation{
+			Timestamp:        time.Now().Format(time.RFC3339),
+			ConsultationType: "advisor",
+			Metric:           "testing",
+			ScoreAtTime:      30,
+		})
+		logger.Close()
+		if err != nil {
+			t.Fatalf("Log failed: %v", err)
+		}
+
+		result := runJSON(t, `{"security": 40, "testing": 30}`, "--scores", "-", "--metric", "testing=70")
+		if got := weakest(result); strings.Join(got, ",") != "security,testing" {
+			t.Errorf("advisory metrics = %v, want security,testing", got)
+		}
+		// The advisor consultation logged testing at 30
+		quotes := result["advisory_quotes"].([]interface{})
+		trend, ok := quotes[1].(map[string]interface{})["trend"].(map[string]interface{})
+		if !ok || trend["direction"] != "improving" || trend["previous_score"] != 30.0 {
+			t.Errorf("testing trend = %v, want improving from 30", trend)
+		}
+	})
+
+	t.Run("invalid metric flag", func(t *testing.T) {
+		if err := (metricScoresFlag{}).Set("security"); err == nil {
+			t.Error("Set without a score should fail")
+		}
+		if err := (metricScoresFlag{}).Set("security=140"); err == nil {
+			t.Error("Set with an out-of-range score should fail")
+		}
+	})
+}
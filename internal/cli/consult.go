@@ -16,6 +16,7 @@ func (a *App) runConsult(args []string) error {
 	tool := fs.String("tool", "", "Tool name (e.g., project_scorecard)")
 	stage := fs.String("stage", "", "Stage name (e.g., daily_checkin)")
 	score := fs.Float64("score", 50.0, "Project score (0-100)")
+	useHealth := fs.Bool("health", false, "Compute the score from the local repository's health (overrides --score)")
 	jsonOutput := fs.Bool("json", false, "Output in JSON format")
 	quiet := fs.Bool("quiet", false, "Output only the quote text")
 
@@ -28,12 +29,25 @@ func (a *App) runConsult(args []string) error {
 		return fmt.Errorf("must provide at least one of --metric, --tool, or --stage: use 'devwisdom consult --help' for usage examples")
 	}
 
+	if *useHealth {
+		healthScore, err := healthScore(*metric)
+		if err != nil {
+			return err
+		}
+		*score = healthScore
+	}
+
 	// Initialize wisdom engine
 	engine := wisdom.NewEngine()
 	if err := engine.Initialize(); err != nil {
 		return fmt.Errorf("failed to initialize wisdom engine (check sources.json configuration): %w", err)
 	}
 
+	// Wisdom disabled by configuration: produce no output
+	if engine.IsDisabled() {
+		return printDisabled(*jsonOutput)
+	}
+
 	// Determine advisor
 	var advisorInfo *wisdom.AdvisorInfo
 	var err error
@@ -56,12 +70,8 @@ func (a *App) runConsult(args []string) error {
 		}
 	}
 
-	// Get quote from advisor's source
-	aeonLevel := wisdom.GetAeonLevel(*score)
-
 	// Try to get quote from advisor's source
-	source, exists := engine.GetSource(advisorInfo.Advisor)
-	if !exists {
+	if _, exists := engine.GetSource(advisorInfo.Advisor); !exists {
 		// Fallback: try to get quote from any source
 		sources := engine.ListSources()
 		if len(sources) == 0 {
@@ -75,7 +85,7 @@ func (a *App) runConsult(args []string) error {
 
 		// Output
 		if *quiet {
-			fmt.Println(quote.Quote)
+			fmt.Println(quote.Text())
 			return nil
 		}
 
@@ -88,6 +98,9 @@ func (a *App) runConsult(args []string) error {
 				"source":        quote.Source,
 				"encouragement": quote.Encouragement,
 			}
+			if quote.Translation != "" {
+				result["translation"] = quote.Translation
+			}
 			encoder := json.NewEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			return encoder.Encode(result)
@@ -101,19 +114,22 @@ func (a *App) runConsult(args []string) error {
 		if advisorInfo.Rationale != "" {
 			fmt.Printf("Rationale: %s\n", advisorInfo.Rationale)
 		}
-		fmt.Printf("\n\"%s\"\n", quote.Quote)
+		fmt.Printf("\n\"%s\"\n", quote.Text())
 		if quote.Encouragement != "" {
 			fmt.Printf("— %s\n", quote.Encouragement)
 		}
 		return nil
 	}
 
-	// Get quote from advisor's source
-	quote := source.GetQuote(aeonLevel)
+	// Get quote from advisor's source, selected like the server's for the same day
+	quote, err := engine.GetWisdom(*score, advisorInfo.Advisor)
+	if err != nil {
+		return fmt.Errorf("failed to get wisdom quote (source: %q, score: %.1f): %w", advisorInfo.Advisor, *score, err)
+	}
 
 	// Output
 	if *quiet {
-		fmt.Println(quote.Quote)
+		fmt.Println(quote.Text())
 		return nil
 	}
 
@@ -126,6 +142,9 @@ func (a *App) runConsult(args []string) error {
 			"source":        quote.Source,
 			"encouragement": quote.Encouragement,
 		}
+		if quote.Translation != "" {
+			result["translation"] = quote.Translation
+		}
 		if *metric != "" {
 			result["metric"] = *metric
 		}
@@ -152,7 +171,7 @@ func (a *App) runConsult(args []string) error {
 	if advisorInfo.HelpsWith != "" {
 		fmt.Printf("Helps with: %s\n", advisorInfo.HelpsWith)
 	}
-	fmt.Printf("\n\"%s\"\n", quote.Quote)
+	fmt.Printf("\n\"%s\"\n", quote.Text())
 	if quote.Encouragement != "" {
 		fmt.Printf("— %s\n", quote.Encouragement)
 	}
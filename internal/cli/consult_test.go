@@ -6,6 +6,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
 )
 
 func TestRunConsult(t *testing.T) {
@@ -107,6 +109,23 @@ func TestRunConsult(t *testing.T) {
 				return len(output) > 0 && !strings.Contains(output, "Advisor")
 			},
 		},
+		{
+			name:    "consult quote matches the engine's quote for the day",
+			args:    []string{"--metric", "security", "--score", "40", "--quiet"},
+			wantErr: false,
+			check: func(output string) bool {
+				engine := wisdom.NewEngine()
+				if err := engine.Initialize(); err != nil {
+					return false
+				}
+				advisor, err := engine.GetAdvisors().GetAdvisorForMetric("security")
+				if err != nil {
+					return false
+				}
+				quote, err := engine.GetWisdom(40, advisor.Advisor)
+				return err == nil && strings.TrimSpace(output) == quote.Text()
+			},
+		},
 		{
 			name:    "consult without metric/tool/stage",
 			args:    []string{"--score", "50"},
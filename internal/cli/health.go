@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/davidl71/devwisdom-go/internal/health"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// runHealth handles the health command
+func (a *App) runHealth(args []string) error {
+	fs := flag.NewFlagSet("health", flag.ExitOnError)
+	path := fs.String("path", ".", "Repository to analyze (defaults to the current directory)")
+	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := health.NewAnalyzer(*path).Analyze(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to analyze project health: %w", err)
+	}
+
+	// Output
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	// Human-readable output
+	mode := wisdom.GetConsultationMode(report.Score)
+	fmt.Printf("Project Health: %s\n", report.Root)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Overall Score: %.1f%% | Aeon: %s | Mode: %s %s\n\n", report.Score, report.AeonLevel, mode.Icon, strings.ToUpper(mode.Name))
+
+	for _, metric := range report.Metrics {
+		fmt.Printf("  %-15s %5.1f%%\n", metric.Name, metric.Score)
+	}
+	fmt.Println()
+	fmt.Println("Use the score for guidance:")
+	fmt.Println("  devwisdom quote --health")
+	fmt.Println("  devwisdom consult --metric testing --health")
+
+	return nil
+}
+
+// healthScore analyzes the repository containing the current directory and
+// returns the score for metric, or the overall score if metric is empty or
+// not measured.
+func healthScore(metric string) (float64, error) {
+	report, err := health.NewAnalyzer(".").Analyze(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("failed to analyze project health: %w", err)
+	}
+	if m, ok := report.Metric(metric); ok {
+		return m.Score, nil
+	}
+	return report.Score, nil
+}
@@ -0,0 +1,606 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+	"github.com/davidl71/devwisdom-go/internal/logging"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// Export formats of the log export command.
+const (
+	exportCSV      = "csv"
+	exportJSONL    = "jsonl"
+	exportMarkdown = "markdown"
+)
+
+// logFlags holds the consultation log directory and filter flags shared by
+// the log subcommands.
+type logFlags struct {
+	logDir *string
+	days   *int // Time range flags; nil unless added with addRangeFlags
+	since  *string
+	until  *string
+	query  logging.Query
+}
+
+// addLogFlags defines the log directory and consultation filter flags on fs.
+func addLogFlags(fs *flag.FlagSet) *logFlags {
+	f := &logFlags{
+		logDir: fs.String("log-dir", ".devwisdom", "Consultation log directory"),
+	}
+	fs.StringVar(&f.query.Advisor, "advisor", "", "Only consultations of this advisor (e.g., stoic)")
+	fs.StringVar(&f.query.Metric, "metric", "", "Only consultations for this metric (e.g., security)")
+	fs.StringVar(&f.query.Tool, "tool", "", "Only consultations for this tool")
+	fs.StringVar(&f.query.Stage, "stage", "", "Only consultations for this stage")
+	fs.StringVar(&f.query.Mode, "mode", "", "Only consultations in this mode (e.g., chaos, mastery)")
+	fs.StringVar(&f.query.Context, "context", "", "Only consultations whose context contains this text")
+	fs.Func("min-score", "Lowest score at the time of the consultation", func(value string) error {
+		score, err := strconv.ParseFloat(value, 64)
+		f.query.MinScore = &score
+		return err
+	})
+	fs.Func("max-score", "Highest score at the time of the consultation", func(value string) error {
+		score, err := strconv.ParseFloat(value, 64)
+		f.query.MaxScore = &score
+		return err
+	})
+	return f
+}
+
+// addRangeFlags defines the time range flags, including the last defaultDays
+// days unless --since is given.
+func (f *logFlags) addRangeFlags(fs *flag.FlagSet, defaultDays int) {
+	f.days = fs.Int("days", defaultDays, "Days of history to include (ignored with --since; 0 for all)")
+	f.since = fs.String("since", "", "Earliest time, inclusive (RFC 3339 timestamp or YYYY-MM-DD)")
+	f.until = fs.String("until", "", "Latest time, exclusive (RFC 3339 timestamp or YYYY-MM-DD)")
+}
+
+// addOrderFlag defines the sort order flag.
+func (f *logFlags) addOrderFlag(fs *flag.FlagSet, defaultOrder string) {
+	fs.StringVar(&f.query.Order, "order", defaultOrder, "Sort order: newest or oldest")
+}
+
+// addPageFlags defines the page size and cursor flags.
+func (f *logFlags) addPageFlags(fs *flag.FlagSet) {
+	fs.IntVar(&f.query.Limit, "limit", 0, "Maximum entries to show (0 for all); --cursor continues")
+	fs.StringVar(&f.query.Cursor, "cursor", "", "Cursor printed by a previous page")
+}
+
+// build returns the query selected by the flags at time now.
+func (f *logFlags) build(now time.Time) (logging.Query, error) {
+	query := f.query
+	if f.days == nil {
+		return query, nil
+	}
+	for _, t := range []struct {
+		name  string
+		value string
+		field *time.Time
+	}{
+		{"since", *f.since, &query.Since},
+		{"until", *f.until, &query.Until},
+	} {
+		if t.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, t.value)
+		if err != nil {
+			if parsed, err = time.ParseInLocation("2006-01-02", t.value, now.Location()); err != nil {
+				return query, fmt.Errorf("invalid --%s %q: use an RFC 3339 timestamp or a YYYY-MM-DD date", t.name, t.value)
+			}
+		}
+		*t.field = parsed
+	}
+	if query.Since.IsZero() && *f.days > 0 {
+		query.Since = now.AddDate(0, 0, -*f.days)
+	}
+	return query, nil
+}
+
+// dayBoundary returns the configured day boundary, so that days are split
+// like the server's log files: on the configured time zone and day start hour.
+func dayBoundary() (wisdom.DayBoundary, error) {
+	cfg := config.NewConfig()
+	if err := cfg.Load(); err != nil {
+		return wisdom.DayBoundary{}, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	day, err := wisdom.NewDayBoundary(cfg.Timezone, cfg.DayStartHour)
+	if err != nil {
+		return wisdom.DayBoundary{}, fmt.Errorf("invalid day boundary configuration (check EXARP_WISDOM_TIMEZONE and EXARP_WISDOM_DAY_START_HOUR): %w", err)
+	}
+	return day, nil
+}
+
+// run queries the log directory with the query selected by the flags.
+func (f *logFlags) run(query logging.Query) (*logging.QueryResult, error) {
+	day, err := dayBoundary()
+	if err != nil {
+		return nil, err
+	}
+	result, err := logging.NewLogReader(*f.logDir).WithDayBoundary(day).Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query consultation log %q: %w", *f.logDir, err)
+	}
+	return result, nil
+}
+
+// runLog handles the log command
+func (a *App) runLog(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			return a.runLogList(args[1:])
+		case "tail":
+			return a.runLogTail(args[1:])
+		case "stats":
+			return a.runLogStats(args[1:])
+		case "export":
+			return a.runLogExport(args[1:])
+		case "maintain":
+			return a.runLogMaintain(args[1:])
+		}
+	}
+	return a.runLogList(args)
+}
+
+// runLogList handles the log list command (also plain log)
+func (a *App) runLogList(args []string) error {
+	fs := flag.NewFlagSet("log list", flag.ExitOnError)
+	logFlags := addLogFlags(fs)
+	logFlags.addRangeFlags(fs, 7)
+	logFlags.addOrderFlag(fs, logging.OrderNewest)
+	logFlags.addPageFlags(fs)
+	aggregate := fs.String("aggregate", "", "Comma-separated aggregations: "+strings.Join(logging.Aggregates, ", "))
+	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query, err := logFlags.build(time.Now())
+	if err != nil {
+		return err
+	}
+	for _, name := range strings.Split(*aggregate, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			query.Aggregate = append(query.Aggregate, name)
+		}
+	}
+	result, err := logFlags.run(query)
+	if err != nil {
+		return err
+	}
+
+	// Output
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	// Human-readable output
+	if result.Total == 0 {
+		fmt.Printf("No consultations found in %s\n", *logFlags.logDir)
+		return nil
+	}
+	fmt.Printf("%-20s  %-16s  %-10s  %5s  %s\n", "TIME", "ADVISOR", "MODE", "SCORE", "FOR")
+	for _, c := range result.Entries {
+		fmt.Println(formatLogRow(c))
+	}
+	fmt.Printf("\nShowing %d of %d consultations\n", len(result.Entries), result.Total)
+	if result.NextCursor != "" {
+		fmt.Printf("More: devwisdom log list --cursor %s (with the same filters)\n", result.NextCursor)
+	}
+	printAggregations(result.Aggregations)
+	return nil
+}
+
+// runLogTail handles the log tail command
+func (a *App) runLogTail(args []string) error {
+	fs := flag.NewFlagSet("log tail", flag.ExitOnError)
+	logFlags := addLogFlags(fs)
+	lines := fs.Int("lines", 10, "Number of recent consultations to show first")
+	fs.IntVar(lines, "n", 10, "Short for --lines")
+	follow := fs.Bool("follow", false, "Keep streaming consultations as they are logged (Ctrl+C to stop)")
+	fs.BoolVar(follow, "f", false, "Short for --follow")
+	interval := fs.Duration("interval", logging.DefaultFollowInterval, "How often to check for new consultations with --follow")
+	jsonOutput := fs.Bool("json", false, "Output one JSON object per line")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *lines < 0 {
+		return fmt.Errorf("invalid --lines %d: must not be negative", *lines)
+	}
+
+	printEntry := func(c *wisdom.Consultation) error {
+		if *jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(c)
+		}
+		_, err := fmt.Println(formatLogRow(c))
+		return err
+	}
+
+	query, err := logFlags.build(time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Start following before reading the recent consultations, so none
+	// logged in between are missed (one logged at that moment may show twice)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	followed := make(chan *wisdom.Consultation, 64)
+	followErr := make(chan error, 1)
+	if *follow {
+		ready := make(chan struct{})
+		go func() {
+			followErr <- logging.NewFollower(*logFlags.logDir).WithInterval(*interval).WithQuery(query).
+				OnStart(func() { close(ready) }).
+				Follow(ctx, func(c *wisdom.Consultation) error {
+					select {
+					case followed <- c:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				})
+		}()
+		select {
+		case <-ready:
+		case err := <-followErr:
+			return err
+		}
+	}
+
+	if *lines > 0 {
+		query.Order, query.Limit = logging.OrderNewest, *lines
+		result, err := logFlags.run(query)
+		if err != nil {
+			return err
+		}
+		for i := len(result.Entries) - 1; i >= 0; i-- { // Oldest first, like tail
+			if err := printEntry(result.Entries[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if !*follow {
+		return nil
+	}
+
+	for {
+		select {
+		case c := <-followed:
+			if err := printEntry(c); err != nil {
+				return err
+			}
+		case err := <-followErr:
+			if errors.Is(err, context.Canceled) {
+				return nil // Interrupted
+			}
+			return fmt.Errorf("failed to follow consultation log %q: %w", *logFlags.logDir, err)
+		}
+	}
+}
+
+// runLogStats handles the log stats command
+func (a *App) runLogStats(args []string) error {
+	fs := flag.NewFlagSet("log stats", flag.ExitOnError)
+	logFlags := addLogFlags(fs)
+	logFlags.addRangeFlags(fs, 30)
+	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query, err := logFlags.build(time.Now())
+	if err != nil {
+		return err
+	}
+	query.Aggregate = logging.Aggregates
+	result, err := logFlags.run(query)
+	if err != nil {
+		return err
+	}
+
+	stats := result.Aggregations
+	var meanScore float64
+	for _, c := range result.Entries {
+		meanScore += c.ScoreAtTime / float64(result.Total)
+	}
+	dailyMeans := make([]float64, 0, len(stats.DailyScores))
+	for _, day := range stats.DailyScores {
+		dailyMeans = append(dailyMeans, day.MeanScore)
+	}
+	trend := sparkline(dailyMeans, 0, 100)
+
+	// Output
+	if *jsonOutput {
+		output := map[string]interface{}{
+			"total":            result.Total,
+			"mean_score":       meanScore,
+			"advisors":         []logging.AdvisorCount{},
+			"daily_scores":     []logging.DailyScore{},
+			"mode_transitions": []logging.ModeTransition{},
+			"score_trend":      trend,
+		}
+		if result.Total > 0 {
+			output["advisors"] = stats.Advisors
+			output["daily_scores"] = stats.DailyScores
+			output["mode_transitions"] = stats.ModeTransitions
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	// Human-readable output
+	if result.Total == 0 {
+		fmt.Printf("No consultations found in %s\n", *logFlags.logDir)
+		return nil
+	}
+	fmt.Printf("Consultations: %d\n", result.Total)
+	fmt.Printf("Mean score:    %.1f\n", meanScore)
+
+	fmt.Println("\nConsultations per advisor:")
+	top := stats.Advisors[0].Count
+	for _, count := range stats.Advisors {
+		bar := strings.Repeat("█", (count.Count*20+top-1)/top)
+		fmt.Printf("  %-16s %-20s %4d  (%.0f%%)\n", count.Advisor, bar, count.Count, float64(count.Count)*100/float64(result.Total))
+	}
+
+	days := stats.DailyScores
+	fmt.Printf("\nScore trend (mean per day, %s to %s):\n", days[0].Date, days[len(days)-1].Date)
+	fmt.Printf("  %s  %.0f → %.0f\n", trend, days[0].MeanScore, days[len(days)-1].MeanScore)
+
+	if len(stats.ModeTransitions) > 0 {
+		fmt.Println("\nMode transitions:")
+		for _, transition := range stats.ModeTransitions {
+			fmt.Printf("  %s → %s  %d\n", transition.From, transition.To, transition.Count)
+		}
+	}
+	return nil
+}
+
+// runLogExport handles the log export command
+func (a *App) runLogExport(args []string) error {
+	fs := flag.NewFlagSet("log export", flag.ExitOnError)
+	logFlags := addLogFlags(fs)
+	logFlags.addRangeFlags(fs, 0)
+	logFlags.addOrderFlag(fs, logging.OrderOldest)
+	format := fs.String("format", exportCSV, "Export format: csv, jsonl, or markdown")
+	output := fs.String("output", "", "File to write (default: standard output)")
+	fs.StringVar(output, "o", "", "Short for --output")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != exportCSV && *format != exportJSONL && *format != exportMarkdown {
+		return fmt.Errorf("invalid --format %q: use csv, jsonl, or markdown", *format)
+	}
+
+	query, err := logFlags.build(time.Now())
+	if err != nil {
+		return err
+	}
+	result, err := logFlags.run(query)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return exportConsultations(os.Stdout, *format, result.Entries)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create export file %q: %w", *output, err)
+	}
+	if err := exportConsultations(file, *format, result.Entries); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write export file %q: %w", *output, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export file %q: %w", *output, err)
+	}
+	fmt.Printf("Exported %d consultations to %s\n", len(result.Entries), *output)
+	return nil
+}
+
+// runLogMaintain handles the log maintain command
+func (a *App) runLogMaintain(args []string) error {
+	// Defaults come from the log_* settings of the configuration
+	cfg := config.NewConfig()
+	if err := cfg.Load(); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	fs := flag.NewFlagSet("log maintain", flag.ExitOnError)
+	logDir := fs.String("log-dir", ".devwisdom", "Consultation log directory")
+	maxAgeDays := fs.Int("max-age-days", cfg.LogRetentionDays, "Delete rotated files older than this many days (0 keeps all)")
+	maxSizeMB := fs.Int("max-size-mb", cfg.LogMaxSizeMB, "Delete the oldest rotated files while the log is larger (0 for no limit)")
+	compress := fs.Bool("compress", cfg.LogCompress, "Gzip rotated files")
+	compact := fs.Bool("compact", cfg.LogCompactMonthly, "Merge the daily files of past months into one file per month")
+	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *maxAgeDays < 0 || *maxSizeMB < 0 {
+		return fmt.Errorf("invalid retention: --max-age-days and --max-size-mb must not be negative")
+	}
+
+	day, err := dayBoundary()
+	if err != nil {
+		return err
+	}
+	cfg.LogRetentionDays, cfg.LogMaxSizeMB, cfg.LogCompress, cfg.LogCompactMonthly = *maxAgeDays, *maxSizeMB, *compress, *compact
+	report, err := logging.Maintain(*logDir, logging.RetentionFromConfig(cfg), day, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to maintain consultation log %q: %w", *logDir, err)
+	}
+
+	// Output
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	for _, change := range []struct {
+		label string
+		names []string
+	}{
+		{"Compacted", report.Compacted},
+		{"Compressed", report.Compressed},
+		{"Deleted", report.Deleted},
+	} {
+		for _, name := range change.names {
+			fmt.Printf("%-10s  %s\n", change.label, name)
+		}
+	}
+	fmt.Printf("Indexed %d rotated file(s) in %s\n", report.Indexed, *logDir)
+	return nil
+}
+
+// exportConsultations writes consultations to w in an export format.
+func exportConsultations(w io.Writer, format string, consultations []*wisdom.Consultation) error {
+	switch format {
+	case exportJSONL:
+		// The format of the log files themselves
+		encoder := json.NewEncoder(w)
+		for _, c := range consultations {
+			if err := encoder.Encode(c); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case exportMarkdown:
+		if _, err := fmt.Fprintln(w, "| Time | Advisor | Mode | Score | For | Quote |\n|------|---------|------|------:|-----|-------|"); err != nil {
+			return err
+		}
+		for _, c := range consultations {
+			quote := c.Quote
+			if c.QuoteSource != "" {
+				quote += " — " + c.QuoteSource
+			}
+			cells := []string{c.Timestamp, c.Advisor, c.ConsultationMode, strconv.FormatFloat(c.ScoreAtTime, 'f', -1, 64), consultationSubject(c), quote}
+			for i, cell := range cells {
+				cells[i] = markdownCell(cell)
+			}
+			if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		writer := csv.NewWriter(w)
+		_ = writer.Write([]string{"timestamp", "consultation_type", "advisor", "advisor_name", "metric", "tool", "stage",
+			"score_at_time", "consultation_mode", "quote_id", "quote", "quote_source", "encouragement", "context"})
+		for _, c := range consultations {
+			_ = writer.Write([]string{c.Timestamp, c.ConsultationType, c.Advisor, c.AdvisorName, c.Metric, c.Tool, c.Stage,
+				strconv.FormatFloat(c.ScoreAtTime, 'f', -1, 64), c.ConsultationMode, c.QuoteID, c.Quote, c.QuoteSource, c.Encouragement, c.Context})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+}
+
+// markdownCell escapes text for a Markdown table cell.
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "<br>"), "\n", "<br>")
+}
+
+// sparkline draws values between low and high as a line of block characters,
+// one per value.
+func sparkline(values []float64, low, high float64) string {
+	levels := []rune("▁▂▃▄▅▆▇█")
+	var line strings.Builder
+	for _, v := range values {
+		level := int((v - low) / (high - low) * float64(len(levels)))
+		if level < 0 {
+			level = 0
+		}
+		if level >= len(levels) {
+			level = len(levels) - 1
+		}
+		line.WriteRune(levels[level])
+	}
+	return line.String()
+}
+
+// formatLogRow formats a consultation as a row of the log table.
+func formatLogRow(c *wisdom.Consultation) string {
+	return fmt.Sprintf("%-20s  %-16s  %-10s  %5.0f  %s", formatLogTime(c.Timestamp), c.Advisor, c.ConsultationMode, c.ScoreAtTime, consultationSubject(c))
+}
+
+// formatLogTime formats a consultation timestamp in local time.
+func formatLogTime(timestamp string) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// consultationSubject describes what a consultation was about: its metric,
+// tool or stage, and context.
+func consultationSubject(c *wisdom.Consultation) string {
+	var parts []string
+	for _, part := range []struct{ label, value string }{
+		{"metric", c.Metric},
+		{"tool", c.Tool},
+		{"stage", c.Stage},
+	} {
+		if part.value != "" {
+			parts = append(parts, part.label+"="+part.value)
+		}
+	}
+	if c.Context != "" {
+		parts = append(parts, fmt.Sprintf("%q", c.Context))
+	}
+	return strings.Join(parts, " ")
+}
+
+// printAggregations prints the aggregations of a query, if any.
+func printAggregations(aggregations *logging.Aggregations) {
+	if aggregations == nil {
+		return
+	}
+	if len(aggregations.Advisors) > 0 {
+		fmt.Println("\nConsultations per advisor:")
+		for _, count := range aggregations.Advisors {
+			fmt.Printf("  %-16s %d\n", count.Advisor, count.Count)
+		}
+	}
+	if len(aggregations.DailyScores) > 0 {
+		fmt.Println("\nMean score per day:")
+		for _, day := range aggregations.DailyScores {
+			fmt.Printf("  %s  %5.1f  (%d consultations)\n", day.Date, day.MeanScore, day.Count)
+		}
+	}
+	if len(aggregations.ModeTransitions) > 0 {
+		fmt.Println("\nMode transitions:")
+		for _, transition := range aggregations.ModeTransitions {
+			fmt.Printf("  %s → %s  %d\n", transition.From, transition.To, transition.Count)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/logging"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// writeConsultationLog writes consultations logged over the last days to a
+// temp log directory and returns it.
+func writeConsultationLog(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	now := time.Now()
+	var lines []string
+	for i, c := range []wisdom.Consultation{
+		{Advisor: "stoic", Metric: "security", ScoreAtTime: 30, ConsultationMode: "building", Context: "Before the audit"},
+		{Advisor: "tao", Metric: "testing", ScoreAtTime: 50, ConsultationMode: "building"},
+		{Advisor: "stoic", Metric: "security", ScoreAtTime: 85, ConsultationMode: "mastery", Quote: "Waste no more time | argue", QuoteSource: "Marcus Aurelius"},
+	} {
+		c.Timestamp = now.Add(time.Duration(i-3) * time.Hour).Format(time.RFC3339)
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, string(data))
+	}
+	if err := os.WriteFile(filepath.Join(dir, "consultations.jsonl"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// captureLog runs the log command and returns its output.
+func captureLog(t *testing.T, app *App, args ...string) (string, error) {
+	t.Helper()
+	r, w, _ := os.Pipe()
+	oldStdout := os.Stdout
+	os.Stdout = w
+
+	var buf bytes.Buffer
+	done := make(chan bool)
+	go func() {
+		_, _ = buf.ReadFrom(r)
+		done <- true
+	}()
+
+	err := app.runLog(args)
+
+	w.Close()
+	os.Stdout = oldStdout
+	<-done
+	return buf.String(), err
+}
+
+func TestRunLog(t *testing.T) {
+	app := NewApp("0.1.0")
+	dir := writeConsultationLog(t)
+
+	output, err := captureLog(t, app, "--log-dir", dir, "--advisor", "stoic", "--min-score", "50", "--json")
+	if err != nil {
+		t.Fatalf("runLog() error = %v", err)
+	}
+	var result logging.QueryResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, output)
+	}
+	if result.Total != 1 || result.Entries[0].ScoreAtTime != 85 {
+		t.Errorf("result = %+v, want the one stoic consultation above 50", result)
+	}
+
+	output, err = captureLog(t, app, "list", "--log-dir", dir, "--limit", "2", "--aggregate", "advisors,mode_transitions")
+	if err != nil {
+		t.Fatalf("runLog() error = %v", err)
+	}
+	for _, want := range []string{"Showing 2 of 3 consultations", "--cursor", "Consultations per advisor:", "building → mastery"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = captureLog(t, app, "--log-dir", dir, "--context", "AUDIT")
+	if err != nil || !strings.Contains(output, `metric=security "Before the audit"`) || !strings.Contains(output, "Showing 1 of 1") {
+		t.Errorf("runLog(--context) = %v\n%s", err, output)
+	}
+
+	output, err = captureLog(t, app, "--log-dir", filepath.Join(dir, "missing"))
+	if err != nil || !strings.Contains(output, "No consultations found") {
+		t.Errorf("runLog() on a missing directory = %v\n%s", err, output)
+	}
+
+	for _, args := range [][]string{
+		{"--log-dir", dir, "--since", "last week"},
+		{"--log-dir", dir, "--aggregate", "median"},
+		{"--log-dir", dir, "--order", "random"},
+	} {
+		if _, err := captureLog(t, app, args...); err == nil {
+			t.Errorf("runLog(%v) succeeded, want an error", args)
+		}
+	}
+}
+
+func TestRunLogTail(t *testing.T) {
+	app := NewApp("0.1.0")
+	dir := writeConsultationLog(t)
+
+	output, err := captureLog(t, app, "tail", "--log-dir", dir, "-n", "2")
+	if err != nil {
+		t.Fatalf("runLog(tail) error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "tao") || !strings.Contains(lines[1], "mastery") {
+		t.Errorf("tail -n 2 = %q, want the last two consultations, oldest first", lines)
+	}
+
+	output, err = captureLog(t, app, "tail", "--log-dir", dir, "--advisor", "stoic", "--json")
+	if err != nil {
+		t.Fatalf("runLog(tail) error = %v", err)
+	}
+	lines = strings.Split(strings.TrimSpace(output), "\n")
+	var last map[string]interface{}
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[1]), &last) != nil || last["score_at_time"] != 85.0 {
+		t.Errorf("tail --json = %q, want one JSON object per stoic consultation", lines)
+	}
+
+	if _, err := captureLog(t, app, "tail", "--log-dir", dir, "--lines", "-1"); err == nil {
+		t.Error("runLog(tail --lines -1) succeeded, want an error")
+	}
+}
+
+func TestRunLogStats(t *testing.T) {
+	app := NewApp("0.1.0")
+	dir := writeConsultationLog(t)
+
+	output, err := captureLog(t, app, "stats", "--log-dir", dir, "--json")
+	if err != nil {
+		t.Fatalf("runLog(stats) error = %v", err)
+	}
+	var stats struct {
+		Total      int                      `json:"total"`
+		MeanScore  float64                  `json:"mean_score"`
+		Advisors   []map[string]interface{} `json:"advisors"`
+		ScoreTrend string                   `json:"score_trend"`
+	}
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, output)
+	}
+	if stats.Total != 3 || stats.MeanScore < 54.9 || stats.MeanScore > 55.1 || len(stats.Advisors) != 2 || stats.Advisors[0]["advisor"] != "stoic" {
+		t.Errorf("stats = %+v", stats)
+	}
+	if stats.ScoreTrend == "" {
+		t.Error("stats has no score trend")
+	}
+
+	output, err = captureLog(t, app, "stats", "--log-dir", dir)
+	if err != nil {
+		t.Fatalf("runLog(stats) error = %v", err)
+	}
+	for _, want := range []string{"Consultations: 3", "Mean score:    55.0", "(67%)", "Score trend", "building → mastery"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = captureLog(t, app, "stats", "--log-dir", filepath.Join(dir, "missing"), "--json")
+	if err != nil || !strings.Contains(output, `"advisors": []`) {
+		t.Errorf("runLog(stats) on a missing directory = %v\n%s", err, output)
+	}
+}
+
+func TestRunLogExport(t *testing.T) {
+	app := NewApp("0.1.0")
+	dir := writeConsultationLog(t)
+
+	output, err := captureLog(t, app, "export", "--log-dir", dir, "--metric", "security")
+	if err != nil {
+		t.Fatalf("runLog(export) error = %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV output: %v\n%s", err, output)
+	}
+	if len(records) != 3 || records[0][0] != "timestamp" || records[1][7] != "30" || records[2][10] != "Waste no more time | argue" {
+		t.Errorf("CSV export = %q, want a header and the security consultations, oldest first", records)
+	}
+
+	output, err = captureLog(t, app, "export", "--log-dir", dir, "--format", "markdown", "--order", "newest")
+	if err != nil {
+		t.Fatalf("runLog(export) error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], "| Time |") || !strings.Contains(lines[2], `Waste no more time \| argue — Marcus Aurelius`) {
+		t.Errorf("Markdown export = %q", lines)
+	}
+
+	path := filepath.Join(t.TempDir(), "export.jsonl")
+	output, err = captureLog(t, app, "export", "--log-dir", dir, "--format", "jsonl", "-o", path)
+	if err != nil || !strings.Contains(output, "Exported 3 consultations") {
+		t.Fatalf("runLog(export -o) = %v\n%s", err, output)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("export file not written: %v", err)
+	}
+	if !bytes.Equal(data, []byte(readLogDir(t, dir))) {
+		t.Errorf("JSONL export = %s, want the log file", data)
+	}
+
+	if _, err := captureLog(t, app, "export", "--log-dir", dir, "--format", "xml"); err == nil {
+		t.Error("runLog(export --format xml) succeeded, want an error")
+	}
+}
+
+// readLogDir returns the current log file of dir.
+func readLogDir(t *testing.T, dir string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "consultations.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRunLogMaintain(t *testing.T) {
+	app := NewApp("0.1.0")
+	dir := writeConsultationLog(t)
+	old := time.Now().AddDate(0, 0, -40)
+	for _, date := range []time.Time{old, old.AddDate(0, 0, 1), old.AddDate(0, 0, 2)} {
+		data, _ := json.Marshal(wisdom.Consultation{Timestamp: date.Format(time.RFC3339), Advisor: "stoic"})
+		if err := os.WriteFile(filepath.Join(dir, "consultations-"+date.Format("2006-01-02")+".jsonl"), append(data, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := captureLog(t, app, "maintain", "--log-dir", dir, "--compress", "--json")
+	if err != nil {
+		t.Fatalf("runLog(maintain) error = %v", err)
+	}
+	var report logging.MaintenanceReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, output)
+	}
+	if len(report.Compressed) != 2 || report.Indexed != 3 {
+		t.Errorf("report = %+v, want all but the latest rotated file compressed", report)
+	}
+
+	output, err = captureLog(t, app, "maintain", "--log-dir", dir, "--max-age-days", "30")
+	if err != nil || strings.Count(output, "Deleted") != 3 || !strings.Contains(output, "Indexed 0 rotated file(s)") {
+		t.Errorf("runLog(maintain --max-age-days) = %v\n%s", err, output)
+	}
+	if output, _ := captureLog(t, app, "--log-dir", dir, "--days", "0", "--json"); !strings.Contains(output, `"total": 3`) {
+		t.Errorf("current log changed by maintenance:\n%s", output)
+	}
+
+	if _, err := captureLog(t, app, "maintain", "--log-dir", dir, "--max-size-mb", "-1"); err == nil {
+		t.Error("runLog(maintain --max-size-mb -1) succeeded, want an error")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]float64{0, 35, 60, 100, 120}, 0, 100); got != "▁▃▅██" {
+		t.Errorf("sparkline() = %q", got)
+	}
+	if got := sparkline(nil, 0, 100); got != "" {
+		t.Errorf("sparkline(nil) = %q, want empty", got)
+	}
+}
@@ -6,58 +6,77 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/davidl71/devwisdom-go/internal/config"
 	"github.com/davidl71/devwisdom-go/internal/wisdom"
 )
 
 // runQuote handles the quote command
 func (a *App) runQuote(args []string) error {
 	fs := flag.NewFlagSet("quote", flag.ExitOnError)
-	source := fs.String("source", "", "Wisdom source name (e.g., stoic, tao, pistis_sophia)")
+	source := fs.String("source", "", "Wisdom source name (e.g., stoic, tao, pistis_sophia, random); defaults to the configured source")
 	score := fs.Float64("score", 50.0, "Project score (0-100) for aeon level selection")
+	useHealth := fs.Bool("health", false, "Compute the score from the local repository's health (overrides --score)")
 	jsonOutput := fs.Bool("json", false, "Output in JSON format")
 	quiet := fs.Bool("quiet", false, "Output only the quote text")
+	id := fs.String("id", "", "Show the quote with this ID (shown in JSON output), ignoring --source and --score")
+	rotation := fs.String("rotation", "", "Quote rotation: daily (same quote all day), cycle (no repeats until all quotes are seen), or cycle_daily; defaults to the configured rotation")
+	historyDir := fs.String("history-dir", wisdom.DefaultHistoryDir, "Directory for the per-user rotation history")
 
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
+	if *useHealth {
+		healthScore, err := healthScore("")
+		if err != nil {
+			return err
+		}
+		*score = healthScore
+	}
+
 	// Initialize wisdom engine
-	engine := wisdom.NewEngine()
+	engine := wisdom.NewEngine().WithHistoryDir(*historyDir, "")
+	if *rotation != "" {
+		mode, err := wisdom.ParseRotation(*rotation)
+		if err != nil {
+			return err
+		}
+		cfg := config.NewConfig()
+		if err := cfg.Load(); err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		cfg.Rotation = mode
+		engine.WithConfig(cfg)
+	}
 	if err := engine.Initialize(); err != nil {
 		return fmt.Errorf("failed to initialize wisdom engine (check sources.json configuration): %w", err)
 	}
 
-	// Determine source
-	selectedSource := *source
-	if selectedSource == "" {
-		// Use date-seeded random source selector for daily consistency
-		randomSource, err := engine.GetRandomSource(true)
-		if err != nil {
-			return fmt.Errorf("failed to get random source (no sources available): %w", err)
-		}
-		selectedSource = randomSource
+	// Wisdom disabled by configuration: produce no output
+	if engine.IsDisabled() {
+		return printDisabled(*jsonOutput)
 	}
 
-	// Get quote - if source is empty string, GetWisdom will fail, so we handle it above
 	var quote *wisdom.Quote
 	var err error
-	if selectedSource != "" {
-		quote, err = engine.GetWisdom(*score, selectedSource)
+	if *id != "" {
+		quote, err = engine.GetQuoteByID(*id)
+		if err != nil {
+			return fmt.Errorf("failed to get quote by ID: %w", err)
+		}
 	} else {
-		// This shouldn't happen due to check above, but handle it
-		return fmt.Errorf("no source specified and no sources available: ensure sources.json exists and contains valid source definitions. Use 'devwisdom sources' to list available sources")
-	}
-	if err != nil {
-		scoreStr := "default"
-		if score != nil {
-			scoreStr = fmt.Sprintf("%.1f", *score)
+		// Get quote - an empty source uses the configured default source
+		// (EXARP_WISDOM_SOURCE or .exarp_wisdom_config), falling back to a
+		// date-seeded random source for daily consistency
+		quote, err = engine.GetWisdom(*score, *source)
+		if err != nil {
+			return fmt.Errorf("failed to get wisdom quote (source: %q, score: %.1f): %w", *source, *score, err)
 		}
-		return fmt.Errorf("failed to get wisdom quote (source: %q, score: %s): %w", selectedSource, scoreStr, err)
 	}
 
 	// Output based on format
 	if *quiet {
-		fmt.Println(quote.Quote)
+		fmt.Println(quote.Text())
 		return nil
 	}
 
@@ -71,15 +90,15 @@ func (a *App) runQuote(args []string) error {
 	if quote.WisdomIcon != "" {
 		fmt.Printf("%s ", quote.WisdomIcon)
 	}
-	fmt.Printf("\"%s\"\n", quote.Quote)
+	fmt.Printf("\"%s\"\n", quote.Text())
 	if quote.Encouragement != "" {
 		fmt.Printf("— %s\n", quote.Encouragement)
 	}
 	if quote.Source != "" {
 		fmt.Printf("Source: %s\n", quote.Source)
 	}
-	if selectedSource != quote.Source {
-		fmt.Printf("Wisdom Source: %s\n", selectedSource)
+	if quote.WisdomSource != "" && quote.WisdomSource != quote.Source {
+		fmt.Printf("Wisdom Source: %s\n", quote.WisdomSource)
 	}
 
 	return nil
@@ -149,3 +149,51 @@ func TestRunQuote_InvalidScore(t *testing.T) {
 		t.Logf("Quote with negative score returned error (may be expected): %v", err)
 	}
 }
+
+func TestRunQuote_ByID(t *testing.T) {
+	app := NewApp("0.1.0")
+
+	// runJSON runs the quote command with --json and decodes its output
+	runJSON := func(args ...string) map[string]interface{} {
+		t.Helper()
+		r, w, _ := os.Pipe()
+		oldStdout := os.Stdout
+		os.Stdout = w
+		var buf bytes.Buffer
+		done := make(chan bool)
+		go func() {
+			buf.ReadFrom(r)
+			done <- true
+		}()
+
+		err := app.runQuote(append(args, "--json"))
+
+		w.Close()
+		os.Stdout = oldStdout
+		<-done
+
+		if err != nil {
+			t.Fatalf("runQuote(%v) error = %v", args, err)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+		}
+		return result
+	}
+
+	first := runJSON("--source", "stoic", "--score", "75")
+	id, _ := first["id"].(string)
+	if !strings.HasPrefix(id, "stoic-") {
+		t.Fatalf("quote JSON id = %q, want a stoic quote ID", id)
+	}
+
+	byID := runJSON("--id", id)
+	if byID["id"] != id || byID["quote"] != first["quote"] {
+		t.Errorf("quote --id %s = %v, want %v", id, byID, first)
+	}
+
+	if err := app.runQuote([]string{"--id", "stoic-000000000000"}); err == nil {
+		t.Error("runQuote() with unknown ID should return error")
+	}
+}
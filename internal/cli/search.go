@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// runSearch handles the search command
+func (a *App) runSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	source := fs.String("source", "", "Only search this wisdom source (e.g., stoic)")
+	aeon := fs.String("aeon", "", "Only search this aeon level (chaos, lower_aeons, middle_aeons, upper_aeons, treasury)")
+	language := fs.String("language", "", "Only search sources in this language (e.g., english, hebrew)")
+	limit := fs.Int("limit", wisdom.DefaultSearchLimit, "Maximum number of results")
+	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+
+	// Flags may appear before or after the search terms
+	var terms []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		terms = append(terms, args[0])
+		args = args[1:]
+	}
+	query := strings.Join(terms, " ")
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("search terms are required: e.g., 'devwisdom search courage', 'devwisdom search learn*', or 'devwisdom search \"the way\"'")
+	}
+
+	// Initialize wisdom engine
+	engine := wisdom.NewEngine()
+	if err := engine.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize wisdom engine: %w", err)
+	}
+
+	// Wisdom disabled by configuration: produce no output
+	if engine.IsDisabled() {
+		return printDisabled(*jsonOutput)
+	}
+
+	results, err := engine.Search(query, wisdom.SearchOptions{
+		Source:    *source,
+		AeonLevel: *aeon,
+		Language:  *language,
+		Limit:     *limit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to search quotes: %w", err)
+	}
+
+	// Output
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	// Human-readable output
+	if len(results) == 0 {
+		fmt.Printf("No quotes found for %q\n", query)
+		return nil
+	}
+	fmt.Printf("Quotes matching %q:\n", query)
+	fmt.Println(strings.Repeat("=", 80))
+	for _, result := range results {
+		fmt.Printf("\n[%s / %s]\n", result.SourceID, result.AeonLevel)
+		fmt.Printf("  \"%s\"\n", result.Quote.Text())
+		fmt.Printf("  — %s\n", result.Quote.Source)
+		if result.Quote.Encouragement != "" {
+			fmt.Printf("  💡 %s\n", result.Quote.Encouragement)
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunSearch(t *testing.T) {
+	app := NewApp("0.1.0")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+		check   func(output string) bool
+	}{
+		{
+			name:    "search term",
+			args:    []string{"courage"},
+			wantErr: false,
+			check: func(output string) bool {
+				return strings.Contains(output, "Quotes matching") || strings.Contains(output, "No quotes found")
+			},
+		},
+		{
+			name:    "search with flags after terms",
+			args:    []string{"the", "way", "--source", "stoic", "--json"},
+			wantErr: false,
+			check: func(output string) bool {
+				var results []map[string]interface{}
+				if err := json.Unmarshal([]byte(output), &results); err != nil {
+					return false
+				}
+				for _, result := range results {
+					if result["source_id"] != "stoic" {
+						return false
+					}
+				}
+				return true
+			},
+		},
+		{
+			name:    "search without terms",
+			args:    []string{"--json"},
+			wantErr: true,
+		},
+		{
+			name:    "search unknown source",
+			args:    []string{"courage", "--source", "nonexistent"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Capture output using a pipe
+			r, w, _ := os.Pipe()
+			oldStdout := os.Stdout
+			os.Stdout = w
+
+			var buf bytes.Buffer
+			done := make(chan bool)
+			go func() {
+				_, err := buf.ReadFrom(r)
+				if err != nil {
+					t.Errorf("buf.ReadFrom failed: %v", err)
+				}
+				done <- true
+			}()
+
+			err := app.runSearch(tt.args)
+
+			w.Close()
+			os.Stdout = oldStdout
+			<-done
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runSearch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && tt.check != nil {
+				output := buf.String()
+				if !tt.check(output) {
+					t.Errorf("runSearch() output validation failed. Output: %s", output)
+				}
+			}
+		})
+	}
+}
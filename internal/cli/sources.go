@@ -1,19 +1,29 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/davidl71/devwisdom-go/internal/wisdom"
 )
 
 // runSources handles the sources command
 func (a *App) runSources(args []string) error {
+	if len(args) > 0 && args[0] == "fetch" {
+		return a.runSourcesFetch(args[1:])
+	}
+	if len(args) > 0 && args[0] == "validate" {
+		return a.runSourcesValidate(args[1:])
+	}
+
 	fs := flag.NewFlagSet("sources", flag.ExitOnError)
 	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+	explain := fs.Bool("explain", false, "Show which files define each source and what they override")
 
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -44,6 +54,11 @@ func (a *App) runSources(args []string) error {
 		if source.Description != "" {
 			sourceInfo["description"] = source.Description
 		}
+		if *explain {
+			if provenance, ok := engine.SourceProvenance(id); ok {
+				sourceInfo["provenance"] = provenance
+			}
+		}
 		sources = append(sources, sourceInfo)
 	}
 
@@ -74,8 +89,191 @@ func (a *App) runSources(args []string) error {
 		if desc, ok := src["description"].(string); ok && desc != "" {
 			fmt.Printf("  %s\n", desc)
 		}
+		if provenance, ok := src["provenance"].(*wisdom.SourceProvenance); ok {
+			fmt.Println("  Defined in (most recent first):")
+			for _, layer := range provenance.Layers {
+				fmt.Printf("    %s\n", formatLayer(layer))
+			}
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// runSourcesFetch handles "sources fetch": it downloads the texts of all
+// Sefaria sources into the persistent cache so they work offline.
+func (a *App) runSourcesFetch(args []string) error {
+	fs := flag.NewFlagSet("sources fetch", flag.ExitOnError)
+	cacheDir := fs.String("cache-dir", "", "Sefaria cache directory (default: $XDG_CACHE_HOME/devwisdom/sefaria)")
+	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Initialize wisdom engine with the requested cache directory
+	engine := wisdom.NewEngine()
+	loader := engine.GetLoader()
+	if *cacheDir != "" {
+		loader.WithSefariaCacheDir(*cacheDir)
+	}
+	if loader.SefariaCacheDir() == "" {
+		return fmt.Errorf("no cache directory available: set XDG_CACHE_HOME or use --cache-dir")
+	}
+	if err := engine.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize wisdom engine: %w", err)
+	}
+
+	results := loader.FetchSefariaSources(context.Background())
+
+	failed := 0
+	sources := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		sourceInfo := map[string]interface{}{
+			"id":     result.SourceID,
+			"refs":   result.Refs,
+			"verses": result.Verses,
+		}
+		if result.Err != nil {
+			failed++
+			sourceInfo["error"] = result.Err.Error()
+		}
+		if result.Distribution != nil {
+			sourceInfo["distribution"] = result.Distribution
+		}
+		sources = append(sources, sourceInfo)
+	}
+
+	// Output
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(map[string]interface{}{
+			"cache_dir": loader.SefariaCacheDir(),
+			"sources":   sources,
+			"fetched":   len(results) - failed,
+			"failed":    failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		if len(results) == 0 {
+			fmt.Println("No Sefaria sources configured.")
+			return nil
+		}
+		fmt.Printf("Fetching Sefaria texts into %s:\n\n", loader.SefariaCacheDir())
+		for _, result := range results {
+			if result.Err != nil {
+				fmt.Printf("  ✗ %s (%s): %v\n", result.SourceID, strings.Join(result.Refs, ", "), result.Err)
+			} else {
+				fmt.Printf("  ✓ %s (%s): %d verses\n", result.SourceID, strings.Join(result.Refs, ", "), result.Verses)
+				if result.Distribution != nil {
+					fmt.Printf("      %s\n", formatDistribution(result.Distribution))
+				}
+			}
+		}
+		for _, err := range loader.AeonOverrideErrors() {
+			fmt.Printf("\n  ⚠️  Ignored %v\n", err)
+		}
 		fmt.Println()
 	}
 
+	if failed > 0 {
+		return fmt.Errorf("failed to fetch %d of %d Sefaria sources (cached copies, if any, are kept)", failed, len(results))
+	}
+	return nil
+}
+
+// runSourcesValidate handles "sources validate": it checks sources.json files
+// (by default, those the loader reads) and reports problems with their
+// positions. It fails if there are errors, or warnings with --strict.
+func (a *App) runSourcesValidate(args []string) error {
+	fs := flag.NewFlagSet("sources validate", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+	strict := fs.Bool("strict", false, "Fail on warnings too")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = wisdom.NewEngine().GetLoader().ExistingSourceFiles()
+	}
+	diagnostics := wisdom.ValidateSourceFiles(files...)
+
+	counts := make(map[wisdom.Severity]int)
+	for _, d := range diagnostics {
+		counts[d.Severity]++
+	}
+
+	// Output
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(map[string]interface{}{
+			"files":       files,
+			"diagnostics": diagnostics,
+			"errors":      counts[wisdom.SeverityError],
+			"warnings":    counts[wisdom.SeverityWarning],
+			"infos":       counts[wisdom.SeverityInfo],
+		}); err != nil {
+			return err
+		}
+	} else {
+		if len(files) == 0 {
+			fmt.Println("No sources.json files found.")
+			return nil
+		}
+		for _, d := range diagnostics {
+			fmt.Println(d)
+		}
+		if len(diagnostics) > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Checked %d file(s): %d error(s), %d warning(s), %d info(s)\n",
+			len(files), counts[wisdom.SeverityError], counts[wisdom.SeverityWarning], counts[wisdom.SeverityInfo])
+	}
+
+	if counts[wisdom.SeverityError] > 0 {
+		return fmt.Errorf("sources validation found %d error(s)", counts[wisdom.SeverityError])
+	}
+	if *strict && counts[wisdom.SeverityWarning] > 0 {
+		return fmt.Errorf("sources validation found %d warning(s) (--strict)", counts[wisdom.SeverityWarning])
+	}
 	return nil
 }
+
+// formatLayer describes a definition of a source, e.g.
+// "✓ .wisdom/sources.json (append, 3 quotes)" or "✗ sources.json (12 quotes, shadowed)".
+func formatLayer(layer wisdom.SourceLayer) string {
+	mark, details := "✓", fmt.Sprintf("%d quotes", layer.Quotes)
+	if layer.Merge == wisdom.MergeAppend {
+		details = "append, " + details
+	}
+	if layer.Shadowed {
+		mark, details = "✗", details+", shadowed"
+	}
+	return fmt.Sprintf("%s %s (%s)", mark, layer.Path, details)
+}
+
+// formatDistribution summarizes how a source's quotes are placed on aeon
+// levels, e.g. "chaos 3, lower_aeons 2, ... — 9 classified (mean confidence 0.61), 1 overridden".
+func formatDistribution(distribution *wisdom.AeonDistribution) string {
+	levels := make([]string, 0, len(distribution.Levels))
+	for _, level := range []wisdom.AeonLevel{wisdom.AeonChaos, wisdom.AeonLower, wisdom.AeonMiddle, wisdom.AeonUpper, wisdom.AeonTreasury} {
+		levels = append(levels, fmt.Sprintf("%s %d", level, distribution.Levels[string(level)]))
+	}
+	placement := fmt.Sprintf("%d classified", distribution.Classified)
+	if distribution.Classified > 0 {
+		placement += fmt.Sprintf(" (mean confidence %.2f)", distribution.MeanConfidence)
+	}
+	if distribution.Overridden > 0 {
+		placement += fmt.Sprintf(", %d overridden", distribution.Overridden)
+	}
+	if distribution.Unclassified > 0 {
+		placement += fmt.Sprintf(", %d unclassified", distribution.Unclassified)
+	}
+	return strings.Join(levels, ", ") + " — " + placement
+}
@@ -4,9 +4,12 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 )
 
 // Config holds wisdom configuration including source selection and Hebrew text options.
@@ -15,14 +18,25 @@ type Config struct {
 	HebrewEnabled bool   `json:"hebrew_enabled"`
 	HebrewOnly    bool   `json:"hebrew_only"`
 	Disabled      bool   `json:"disabled"`
-	configPath    string
+	Rotation      string `json:"rotation,omitempty"`       // Quote rotation: "daily" (default), "cycle", or "cycle_daily"
+	Timezone      string `json:"timezone,omitempty"`       // IANA time zone for daily selection; empty uses the local zone
+	DayStartHour  int    `json:"day_start_hour,omitempty"` // Hour (0-23) at which a new day begins
+
+	// Maintenance of rotated consultation log files
+	LogRetentionDays  int  `json:"log_retention_days,omitempty"`  // Delete files older than this many days; 0 keeps all
+	LogMaxSizeMB      int  `json:"log_max_size_mb,omitempty"`     // Delete the oldest files while the log is larger; 0 for no limit
+	LogCompress       bool `json:"log_compress,omitempty"`        // Gzip rotated files
+	LogCompactMonthly bool `json:"log_compact_monthly,omitempty"` // Merge the daily files of past months into one file per month
+
+	configPath string
 }
 
 // NewConfig creates a new config with default values.
-// Default source is "pistis_sophia" and Hebrew features are disabled.
+// No default source is set, so a date-seeded random source is used, and
+// Hebrew features are disabled.
 func NewConfig() *Config {
 	return &Config{
-		Source:        "pistis_sophia", // Default source
+		Source:        "", // Random daily source unless configured
 		HebrewEnabled: false,
 		HebrewOnly:    false,
 		Disabled:      false,
@@ -34,21 +48,58 @@ func NewConfig() *Config {
 // Environment variables take precedence over file configuration.
 // Returns nil if config file doesn't exist (it's optional).
 func (c *Config) Load() error {
-	// First check environment variables
+	// Load config file first so environment variables can override it.
+	// An unreadable or malformed file is ignored (the file is optional).
+	if data, err := os.ReadFile(c.configPath); err == nil {
+		fileConfig := *c
+		if err := json.Unmarshal(data, &fileConfig); err == nil {
+			*c = fileConfig
+		}
+	}
+
+	// Environment variables
 	if source := os.Getenv("EXARP_WISDOM_SOURCE"); source != "" {
 		c.Source = source
 	}
 
-	if os.Getenv("EXARP_WISDOM_HEBREW") == "1" {
-		c.HebrewEnabled = true
+	if enabled, ok := envBool("EXARP_WISDOM_HEBREW"); ok {
+		c.HebrewEnabled = enabled
 	}
 
-	if os.Getenv("EXARP_WISDOM_HEBREW_ONLY") == "1" {
-		c.HebrewOnly = true
+	if hebrewOnly, ok := envBool("EXARP_WISDOM_HEBREW_ONLY"); ok {
+		c.HebrewOnly = hebrewOnly
 	}
 
-	if os.Getenv("EXARP_DISABLE_WISDOM") == "1" {
-		c.Disabled = true
+	if rotation := os.Getenv("EXARP_WISDOM_ROTATION"); rotation != "" {
+		c.Rotation = rotation
+	}
+
+	if timezone := os.Getenv("EXARP_WISDOM_TIMEZONE"); timezone != "" {
+		c.Timezone = timezone
+	}
+
+	if hour, err := strconv.Atoi(os.Getenv("EXARP_WISDOM_DAY_START_HOUR")); err == nil {
+		c.DayStartHour = hour
+	}
+
+	if days, err := strconv.Atoi(os.Getenv("EXARP_WISDOM_LOG_RETENTION_DAYS")); err == nil {
+		c.LogRetentionDays = days
+	}
+
+	if size, err := strconv.Atoi(os.Getenv("EXARP_WISDOM_LOG_MAX_SIZE_MB")); err == nil {
+		c.LogMaxSizeMB = size
+	}
+
+	if compress, ok := envBool("EXARP_WISDOM_LOG_COMPRESS"); ok {
+		c.LogCompress = compress
+	}
+
+	if compact, ok := envBool("EXARP_WISDOM_LOG_COMPACT"); ok {
+		c.LogCompactMonthly = compact
+	}
+
+	if disabled, ok := envBool("EXARP_DISABLE_WISDOM"); ok {
+		c.Disabled = disabled
 	}
 
 	// Check for .exarp_no_wisdom marker file
@@ -56,18 +107,76 @@ func (c *Config) Load() error {
 		c.Disabled = true
 	}
 
-	// Try to load from config file
-	if _, err := os.Stat(c.configPath); err == nil {
-		data, err := os.ReadFile(c.configPath)
-		if err == nil {
-			if err := json.Unmarshal(data, c); err == nil {
-				// Config loaded successfully
-				return nil
-			}
+	return nil // Config file is optional
+}
+
+// Path returns the path of the config file read by Load and written by Save.
+func (c *Config) Path() string {
+	return c.configPath
+}
+
+// Reloaded returns a new config read from c's file and the environment, as
+// NewConfig and Load would. Unlike Load, it fails if the file exists but
+// cannot be read or is not valid JSON, so callers can keep c in that case.
+func (c *Config) Reloaded() (*Config, error) {
+	data, err := os.ReadFile(c.configPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read config file %q: %w", c.configPath, err)
+	}
+	if err == nil {
+		var fileConfig Config
+		if err := json.Unmarshal(data, &fileConfig); err != nil {
+			return nil, fmt.Errorf("invalid config file %q: %w", c.configPath, err)
 		}
 	}
 
-	return nil // Config file is optional
+	reloaded := NewConfig()
+	reloaded.configPath = c.configPath
+	if err := reloaded.Load(); err != nil {
+		return nil, err
+	}
+	return reloaded, nil
+}
+
+// AllowsLanguage reports whether sources in the given language may be used.
+// HebrewOnly restricts selection to Hebrew sources; otherwise Hebrew sources
+// are only used when HebrewEnabled is set. Other languages are always allowed
+// unless HebrewOnly is set.
+func (c *Config) AllowsLanguage(language string) bool {
+	isHebrew := language == "hebrew"
+	if c.HebrewOnly {
+		return isHebrew
+	}
+	if isHebrew {
+		return c.HebrewEnabled
+	}
+	return true
+}
+
+// ShowsOriginal reports whether the original text of a translated quote is
+// shown, which requires HebrewEnabled or HebrewOnly.
+func (c *Config) ShowsOriginal() bool {
+	return c.HebrewEnabled || c.HebrewOnly
+}
+
+// ShowsTranslation reports whether the translation of a translated quote is
+// shown, which is always the case unless HebrewOnly is set. With both
+// HebrewEnabled and translations shown, quotes appear side by side.
+func (c *Config) ShowsTranslation() bool {
+	return !c.HebrewOnly
+}
+
+// envBool parses a boolean environment variable ("1"/"true" or "0"/"false").
+// The second return value is false if the variable is unset or unrecognized.
+func envBool(name string) (bool, bool) {
+	switch os.Getenv(name) {
+	case "1", "true", "TRUE", "True":
+		return true, true
+	case "0", "false", "FALSE", "False":
+		return false, true
+	default:
+		return false, false
+	}
 }
 
 // Save saves configuration to file in JSON format.
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestConfig returns a default config that reads its file from a temp
+// directory, with the working directory switched to that directory so the
+// .exarp_no_wisdom marker check is isolated.
+func newTestConfig(t *testing.T) (*Config, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir failed: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	for _, name := range []string{"EXARP_WISDOM_SOURCE", "EXARP_WISDOM_HEBREW", "EXARP_WISDOM_HEBREW_ONLY", "EXARP_DISABLE_WISDOM", "EXARP_WISDOM_ROTATION", "EXARP_WISDOM_TIMEZONE", "EXARP_WISDOM_DAY_START_HOUR",
+		"EXARP_WISDOM_LOG_RETENTION_DAYS", "EXARP_WISDOM_LOG_MAX_SIZE_MB", "EXARP_WISDOM_LOG_COMPRESS", "EXARP_WISDOM_LOG_COMPACT"} {
+		t.Setenv(name, "")
+	}
+
+	cfg := NewConfig()
+	cfg.configPath = filepath.Join(dir, ".exarp_wisdom_config")
+	return cfg, dir
+}
+
+func TestConfig_Load_Defaults(t *testing.T) {
+	cfg, _ := newTestConfig(t)
+	if err := cfg.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Source != "" {
+		t.Errorf("Source = %q, want none (random daily source)", cfg.Source)
+	}
+	if cfg.HebrewEnabled || cfg.HebrewOnly || cfg.Disabled {
+		t.Errorf("unexpected flags set: %+v", cfg)
+	}
+}
+
+func TestConfig_Load_Environment(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   string
+		value string
+		check func(*Config) bool
+	}{
+		{"source", "EXARP_WISDOM_SOURCE", "stoic", func(c *Config) bool { return c.Source == "stoic" }},
+		{"hebrew", "EXARP_WISDOM_HEBREW", "1", func(c *Config) bool { return c.HebrewEnabled }},
+		{"hebrew only", "EXARP_WISDOM_HEBREW_ONLY", "true", func(c *Config) bool { return c.HebrewOnly }},
+		{"disabled", "EXARP_DISABLE_WISDOM", "1", func(c *Config) bool { return c.Disabled }},
+		{"rotation", "EXARP_WISDOM_ROTATION", "cycle", func(c *Config) bool { return c.Rotation == "cycle" }},
+		{"timezone", "EXARP_WISDOM_TIMEZONE", "UTC", func(c *Config) bool { return c.Timezone == "UTC" }},
+		{"day start hour", "EXARP_WISDOM_DAY_START_HOUR", "6", func(c *Config) bool { return c.DayStartHour == 6 }},
+		{"log retention", "EXARP_WISDOM_LOG_RETENTION_DAYS", "90", func(c *Config) bool { return c.LogRetentionDays == 90 }},
+		{"log max size", "EXARP_WISDOM_LOG_MAX_SIZE_MB", "50", func(c *Config) bool { return c.LogMaxSizeMB == 50 }},
+		{"log compress", "EXARP_WISDOM_LOG_COMPRESS", "1", func(c *Config) bool { return c.LogCompress }},
+		{"log compact", "EXARP_WISDOM_LOG_COMPACT", "true", func(c *Config) bool { return c.LogCompactMonthly }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _ := newTestConfig(t)
+			t.Setenv(tt.env, tt.value)
+
+			if err := cfg.Load(); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if !tt.check(cfg) {
+				t.Errorf("%s=%s not applied: %+v", tt.env, tt.value, cfg)
+			}
+		})
+	}
+}
+
+func TestConfig_Load_EnvironmentOverridesFile(t *testing.T) {
+	cfg, dir := newTestConfig(t)
+	data := []byte(`{"source": "bofh", "hebrew_enabled": true, "disabled": true}`)
+	if err := os.WriteFile(filepath.Join(dir, ".exarp_wisdom_config"), data, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	t.Setenv("EXARP_WISDOM_SOURCE", "stoic")
+	t.Setenv("EXARP_DISABLE_WISDOM", "0")
+
+	if err := cfg.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Source != "stoic" {
+		t.Errorf("Source = %q, want stoic from environment", cfg.Source)
+	}
+	if cfg.Disabled {
+		t.Error("Disabled = true, want false from environment")
+	}
+	if !cfg.HebrewEnabled {
+		t.Error("HebrewEnabled = false, want true from file")
+	}
+}
+
+func TestConfig_Load_MarkerFile(t *testing.T) {
+	cfg, dir := newTestConfig(t)
+	if err := os.WriteFile(filepath.Join(dir, ".exarp_no_wisdom"), nil, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if err := cfg.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.Disabled {
+		t.Error("Disabled = false, want true with .exarp_no_wisdom marker")
+	}
+}
+
+func TestConfig_AllowsLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		language string
+		want     bool
+	}{
+		{"english by default", Config{}, "", true},
+		{"hebrew by default", Config{}, "hebrew", false},
+		{"hebrew enabled", Config{HebrewEnabled: true}, "hebrew", true},
+		{"hebrew only allows hebrew", Config{HebrewOnly: true}, "hebrew", true},
+		{"hebrew only excludes english", Config{HebrewOnly: true}, "english", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.AllowsLanguage(tt.language); got != tt.want {
+				t.Errorf("AllowsLanguage(%q) = %v, want %v", tt.language, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_ShowsOriginalAndTranslation(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             Config
+		wantOriginal    bool
+		wantTranslation bool
+	}{
+		{"default", Config{}, false, true},
+		{"hebrew enabled", Config{HebrewEnabled: true}, true, true},
+		{"hebrew only", Config{HebrewOnly: true}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.ShowsOriginal(); got != tt.wantOriginal {
+				t.Errorf("ShowsOriginal() = %v, want %v", got, tt.wantOriginal)
+			}
+			if got := tt.cfg.ShowsTranslation(); got != tt.wantTranslation {
+				t.Errorf("ShowsTranslation() = %v, want %v", got, tt.wantTranslation)
+			}
+		})
+	}
+}
+
+func TestConfig_Reloaded(t *testing.T) {
+	cfg, dir := newTestConfig(t)
+	path := filepath.Join(dir, ".exarp_wisdom_config")
+	if err := os.WriteFile(path, []byte(`{"source": "stoic", "hebrew_enabled": true}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := cfg.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// Settings removed from the file revert to their defaults
+	if err := os.WriteFile(path, []byte(`{"source": "tao"}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	reloaded, err := cfg.Reloaded()
+	if err != nil {
+		t.Fatalf("Reloaded failed: %v", err)
+	}
+	if reloaded.Source != "tao" || reloaded.HebrewEnabled || reloaded.Path() != path {
+		t.Errorf("reloaded config = %+v, want source tao from %s", reloaded, path)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"source": `), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := cfg.Reloaded(); err == nil {
+		t.Error("Reloaded accepted a malformed config file")
+	}
+	if cfg.Source != "stoic" {
+		t.Errorf("Source = %q after a failed reload, want the original stoic", cfg.Source)
+	}
+}
@@ -0,0 +1,163 @@
+// Package health computes project health scores from a local repository.
+// It analyzes the working tree (tests, coverage profiles, documentation,
+// TODO/FIXME markers, CI configuration) and git history to produce per-metric
+// scores on the same 0-100 scale used for aeon levels and consultation modes.
+package health
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// Metric names. They match the metric advisor mappings, so each score can be
+// used directly for a consultation.
+const (
+	MetricTesting       = "testing"
+	MetricDocumentation = "documentation"
+	MetricCompletion    = "completion"
+	MetricCICD          = "ci_cd"
+	MetricCodebase      = "codebase"
+)
+
+// defaultChurnWindow is the git history window used for churn.
+const defaultChurnWindow = 30 * 24 * time.Hour
+
+// MetricScore is the score for a single metric with the measurements behind it.
+type MetricScore struct {
+	Name    string                 `json:"name"`
+	Score   float64                `json:"score"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report is the result of analyzing a repository.
+type Report struct {
+	Root             string        `json:"root"`
+	GeneratedAt      time.Time     `json:"generated_at"`
+	Score            float64       `json:"score"` // Mean of the metric scores
+	AeonLevel        string        `json:"aeon_level"`
+	ConsultationMode string        `json:"consultation_mode"`
+	Metrics          []MetricScore `json:"metrics"` // Sorted by name
+}
+
+// MetricScores returns the metric scores keyed by metric name.
+func (r *Report) MetricScores() map[string]float64 {
+	scores := make(map[string]float64, len(r.Metrics))
+	for _, m := range r.Metrics {
+		scores[m.Name] = m.Score
+	}
+	return scores
+}
+
+// Metric returns the score for the named metric.
+func (r *Report) Metric(name string) (MetricScore, bool) {
+	for _, m := range r.Metrics {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return MetricScore{}, false
+}
+
+// Analyzer computes health reports for a repository working tree.
+type Analyzer struct {
+	root        string
+	churnWindow time.Duration
+	now         func() time.Time
+}
+
+// NewAnalyzer creates an analyzer for the repository at root.
+// If root is inside a git working tree, the tree's top level is analyzed.
+func NewAnalyzer(root string) *Analyzer {
+	return &Analyzer{
+		root:        root,
+		churnWindow: defaultChurnWindow,
+		now:         time.Now,
+	}
+}
+
+// WithChurnWindow sets how far back git history is read for churn (default: 30 days).
+func (a *Analyzer) WithChurnWindow(window time.Duration) *Analyzer {
+	if window > 0 {
+		a.churnWindow = window
+	}
+	return a
+}
+
+// Analyze scans the repository and returns its health report.
+func (a *Analyzer) Analyze(ctx context.Context) (*Report, error) {
+	root, err := filepath.Abs(a.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository path %q: %w", a.root, err)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository path %q: %w", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("repository path %q is not a directory", root)
+	}
+	if top, ok := gitTopLevel(ctx, root); ok {
+		root = top
+	}
+
+	tree, err := scanTree(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	now := a.now()
+	churn, churnErr := gitChurn(ctx, root, now.Add(-a.churnWindow))
+
+	metrics := []MetricScore{
+		scoreTesting(root, tree),
+		scoreDocumentation(root, tree),
+		scoreCompletion(tree),
+		scoreCICD(root),
+		scoreCodebase(tree, churn, churnErr, a.churnWindow),
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+
+	total := 0.0
+	for _, m := range metrics {
+		total += m.Score
+	}
+	overall := round1(total / float64(len(metrics)))
+
+	return &Report{
+		Root:             root,
+		GeneratedAt:      now,
+		Score:            overall,
+		AeonLevel:        wisdom.GetAeonLevel(overall),
+		ConsultationMode: wisdom.GetConsultationMode(overall).Name,
+		Metrics:          metrics,
+	}, nil
+}
+
+// gitTopLevel returns the top level of the git working tree containing dir.
+func gitTopLevel(ctx context.Context, dir string) (string, bool) {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", false
+	}
+	top := strings.TrimSpace(string(out))
+	return top, top != ""
+}
+
+// clamp limits a score to the 0-100 range.
+func clamp(score float64) float64 {
+	return math.Min(math.Max(score, 0), 100)
+}
+
+// round1 rounds to one decimal place for stable output.
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,173 @@
+package health
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// writeTree writes files (path relative to root → content) into root.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"README.md":                strings.Repeat("line\n", readmeFullLines),
+		"alpha/alpha.go":           "// Package alpha does things.\npackage alpha\n\nfunc A() {}\n",
+		"alpha/alpha_test.go":      "package alpha\n",
+		"beta/beta.go":             "package beta\n\n// TODO: finish\nfunc B() {}\n",
+		"node_modules/x/x.js":      "// TODO: ignored\n",
+		".hidden/hidden.go":        "package hidden\n",
+		"coverage.out":             "mode: set\nalpha/alpha.go:3.1,3.12 3 1\nbeta/beta.go:4.1,4.12 1 0\n",
+		".github/workflows/ci.yml": "jobs:\n  build:\n    steps:\n      - run: go vet ./...\n      - run: go test ./...\n",
+	})
+
+	report, err := NewAnalyzer(root).Analyze(context.Background())
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if len(report.Metrics) != 5 {
+		t.Fatalf("got %d metrics, want 5", len(report.Metrics))
+	}
+
+	tm, _ := report.Metric(MetricTesting)
+	if tm.Details["test_files"] != 1 || tm.Details["source_files"] != 2 {
+		t.Errorf("testing details = %v, want 1 test file and 2 source files", tm.Details)
+	}
+	// 75% coverage, test ratio at the 0.5 target: 0.6*75 + 0.4*100
+	if tm.Score != 85 {
+		t.Errorf("testing score = %v, want 85", tm.Score)
+	}
+
+	// Full README, one of two packages documented
+	if doc, _ := report.Metric(MetricDocumentation); doc.Score != 75 {
+		t.Errorf("documentation score = %v, want 75", doc.Score)
+	}
+
+	// One marker in 8 lines is far above the density that scores zero
+	if completion, _ := report.Metric(MetricCompletion); completion.Score != 0 || completion.Details["markers"] != 1 {
+		t.Errorf("completion = %+v, want score 0 with 1 marker", completion)
+	}
+
+	if ci, _ := report.Metric(MetricCICD); ci.Score != 100 {
+		t.Errorf("ci_cd score = %v, want 100 (workflow that tests and vets)", ci.Score)
+	}
+
+	if report.AeonLevel != wisdom.GetAeonLevel(report.Score) {
+		t.Errorf("AeonLevel = %q, want level for score %v", report.AeonLevel, report.Score)
+	}
+	if report.ConsultationMode != wisdom.GetConsultationMode(report.Score).Name {
+		t.Errorf("ConsultationMode = %q, want mode for score %v", report.ConsultationMode, report.Score)
+	}
+}
+
+func TestAnalyzer_Analyze_EmptyTree(t *testing.T) {
+	report, err := NewAnalyzer(t.TempDir()).Analyze(context.Background())
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if tm, _ := report.Metric(MetricTesting); tm.Score != 0 {
+		t.Errorf("testing score = %v, want 0 without sources", tm.Score)
+	}
+	if ci, _ := report.Metric(MetricCICD); ci.Score != 0 {
+		t.Errorf("ci_cd score = %v, want 0 without workflows", ci.Score)
+	}
+}
+
+func TestAnalyzer_Analyze_InvalidPath(t *testing.T) {
+	if _, err := NewAnalyzer(filepath.Join(t.TempDir(), "missing")).Analyze(context.Background()); err == nil {
+		t.Error("Analyze should fail for a missing path")
+	}
+}
+
+func TestAnalyzer_Analyze_GitChurn(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", root, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	writeTree(t, root, map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+	git("add", "-A")
+	git("commit", "-q", "-m", "initial")
+	writeTree(t, root, map[string]string{"main.go": strings.Repeat("// line\n", 20) + "package main\n"})
+	git("commit", "-q", "-am", "rewrite")
+
+	report, err := NewAnalyzer(root).Analyze(context.Background())
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	codebase, _ := report.Metric(MetricCodebase)
+	if codebase.Details["git_history"] != true || codebase.Details["commits"] != 2 {
+		t.Fatalf("codebase details = %v, want git history with 2 commits", codebase.Details)
+	}
+	// The root commit is not churn; the rewrite is
+	if changed := codebase.Details["lines_changed"].(int); changed == 0 || changed > 30 {
+		t.Errorf("lines_changed = %d, want only the second commit's lines", changed)
+	}
+	if codebase.Score >= 100 {
+		t.Errorf("codebase score = %v, want a churn penalty", codebase.Score)
+	}
+}
+
+func TestParseCoverProfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "coverage.out")
+	// Merged profiles repeat blocks; a block covered in either counts once
+	writeTree(t, dir, map[string]string{"coverage.out": "mode: count\n" +
+		"a.go:1.1,2.2 2 0\n" +
+		"a.go:1.1,2.2 2 5\n" +
+		"a.go:3.1,4.2 2 0\n"})
+
+	coverage, ok := parseCoverProfile(path)
+	if !ok || coverage != 50 {
+		t.Errorf("parseCoverProfile = %v, %v; want 50, true", coverage, ok)
+	}
+
+	writeTree(t, dir, map[string]string{"bad.out": "not a profile\n"})
+	if _, ok := parseCoverProfile(filepath.Join(dir, "bad.out")); ok {
+		t.Error("parseCoverProfile should reject files without a mode line")
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	tests := map[string]bool{
+		"pkg/foo_test.go":       true,
+		"src/foo.test.ts":       true,
+		"src/foo.spec.js":       true,
+		"test_foo.py":           true,
+		"tests/helpers.py":      true,
+		"src/__tests__/a.js":    true,
+		"pkg/foo.go":            false,
+		"src/contest/winner.py": false,
+	}
+	for path, want := range tests {
+		if got := isTestFile(path, filepath.Base(path)); got != want {
+			t.Errorf("isTestFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
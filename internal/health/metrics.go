@@ -0,0 +1,288 @@
+package health
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scoring targets.
+const (
+	// targetTestRatio is the test-file-per-source-file ratio that scores 100.
+	targetTestRatio = 0.5
+	// todoPenaltyPerKLOC is the completion penalty per TODO marker per 1000 lines.
+	todoPenaltyPerKLOC = 10.0
+	// readmeFullLines is the README length that earns full documentation credit.
+	readmeFullLines = 100
+)
+
+// coverageProfileNames are the coverage profile files looked for in the repository root.
+var coverageProfileNames = []string{"coverage.out", "cover.out", "coverage.txt", "c.out", "profile.cov"}
+
+// scoreTesting scores test file ratio and, when a coverage profile is present, coverage.
+func scoreTesting(root string, tree *treeStats) MetricScore {
+	details := map[string]interface{}{
+		"test_files":   tree.testFiles,
+		"source_files": tree.codeFiles,
+	}
+
+	ratioScore := 0.0
+	if tree.codeFiles > 0 {
+		ratio := float64(tree.testFiles) / float64(tree.codeFiles)
+		details["test_ratio"] = round1(ratio*100) / 100
+		ratioScore = clamp(ratio / targetTestRatio * 100)
+	}
+
+	score := ratioScore
+	if profile, coverage, ok := findCoverage(root); ok {
+		details["coverage_profile"] = profile
+		details["coverage_percent"] = round1(coverage)
+		// Coverage is the stronger signal when it is available
+		score = 0.6*coverage + 0.4*ratioScore
+	}
+
+	return MetricScore{Name: MetricTesting, Score: round1(clamp(score)), Details: details}
+}
+
+// findCoverage returns the first parseable coverage profile in root and its
+// statement coverage percentage.
+func findCoverage(root string) (string, float64, bool) {
+	candidates := make([]string, 0, len(coverageProfileNames))
+	for _, name := range coverageProfileNames {
+		candidates = append(candidates, filepath.Join(root, name))
+	}
+	if matches, err := filepath.Glob(filepath.Join(root, "*.coverprofile")); err == nil {
+		candidates = append(candidates, matches...)
+	}
+
+	for _, path := range candidates {
+		if coverage, ok := parseCoverProfile(path); ok {
+			return filepath.Base(path), coverage, true
+		}
+	}
+	return "", 0, false
+}
+
+// parseCoverProfile computes statement coverage from a Go coverage profile
+// ("mode: set" followed by "file:start,end statements count" lines).
+// Blocks repeated across merged profiles are counted once.
+func parseCoverProfile(path string) (float64, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), "mode:") {
+		return 0, false
+	}
+
+	type block struct {
+		statements int
+		covered    bool
+	}
+	blocks := make(map[string]*block)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		statements, errS := strconv.Atoi(fields[1])
+		count, errC := strconv.Atoi(fields[2])
+		if errS != nil || errC != nil {
+			continue
+		}
+		b, exists := blocks[fields[0]]
+		if !exists {
+			b = &block{statements: statements}
+			blocks[fields[0]] = b
+		}
+		b.covered = b.covered || count > 0
+	}
+
+	total, covered := 0, 0
+	for _, b := range blocks {
+		total += b.statements
+		if b.covered {
+			covered += b.statements
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(covered) / float64(total) * 100, true
+}
+
+// scoreDocumentation scores the README and, for Go projects, package doc comments.
+func scoreDocumentation(root string, tree *treeStats) MetricScore {
+	details := map[string]interface{}{}
+
+	readmeScore := 0.0
+	if name, lines, ok := findReadme(root); ok {
+		details["readme"] = name
+		details["readme_lines"] = lines
+		// Presence earns 40; length up to readmeFullLines earns the rest
+		readmeScore = 40 + 60*min(float64(lines)/readmeFullLines, 1)
+	}
+
+	score := readmeScore
+	if packages := len(tree.goPackages); packages > 0 {
+		documented := 0
+		for _, hasDoc := range tree.goPackages {
+			if hasDoc {
+				documented++
+			}
+		}
+		details["go_packages"] = packages
+		details["documented_packages"] = documented
+		score = 0.5*readmeScore + 0.5*float64(documented)/float64(packages)*100
+	}
+
+	return MetricScore{Name: MetricDocumentation, Score: round1(clamp(score)), Details: details}
+}
+
+// findReadme returns the README file in root and its line count.
+func findReadme(root string) (string, int, bool) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", 0, false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(strings.ToUpper(entry.Name()), "README") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, entry.Name()))
+		if err != nil {
+			continue
+		}
+		return entry.Name(), strings.Count(string(data), "\n") + 1, true
+	}
+	return "", 0, false
+}
+
+// scoreCompletion scores TODO/FIXME/XXX/HACK density per 1000 lines of code.
+func scoreCompletion(tree *treeStats) MetricScore {
+	details := map[string]interface{}{
+		"markers":    tree.todos,
+		"code_lines": tree.codeLines,
+	}
+
+	score := 100.0
+	if tree.codeLines > 0 {
+		perKLOC := float64(tree.todos) / float64(tree.codeLines) * 1000
+		details["markers_per_kloc"] = round1(perKLOC)
+		score = 100 - perKLOC*todoPenaltyPerKLOC
+	}
+
+	return MetricScore{Name: MetricCompletion, Score: round1(clamp(score)), Details: details}
+}
+
+// ciConfigs maps CI systems to their configuration files (globs relative to the root).
+var ciConfigs = []struct {
+	system string
+	glob   string
+}{
+	{"github_actions", ".github/workflows/*.yml"},
+	{"github_actions", ".github/workflows/*.yaml"},
+	{"gitlab", ".gitlab-ci.yml"},
+	{"circleci", ".circleci/config.yml"},
+	{"travis", ".travis.yml"},
+	{"jenkins", "Jenkinsfile"},
+	{"azure_pipelines", "azure-pipelines.yml"},
+	{"bitbucket", "bitbucket-pipelines.yml"},
+}
+
+var (
+	ciTestPattern = regexp.MustCompile(`(?i)\btest`)
+	ciLintPattern = regexp.MustCompile(`(?i)\b(lint|vet|staticcheck|golangci)`)
+)
+
+// scoreCICD scores the presence of CI workflows and whether they test and lint.
+func scoreCICD(root string) MetricScore {
+	systems := []string{}
+	seen := map[string]bool{}
+	files := 0
+	runsTests, runsLint := false, false
+
+	for _, ci := range ciConfigs {
+		matches, err := filepath.Glob(filepath.Join(root, ci.glob))
+		if err != nil {
+			continue
+		}
+		for _, path := range matches {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			files++
+			if !seen[ci.system] {
+				seen[ci.system] = true
+				systems = append(systems, ci.system)
+			}
+			runsTests = runsTests || ciTestPattern.Match(data)
+			runsLint = runsLint || ciLintPattern.Match(data)
+		}
+	}
+
+	score := 0.0
+	if files > 0 {
+		score = 60
+		if runsTests {
+			score += 20
+		}
+		if runsLint {
+			score += 20
+		}
+	}
+
+	return MetricScore{
+		Name:  MetricCICD,
+		Score: score,
+		Details: map[string]interface{}{
+			"systems":        systems,
+			"workflow_files": files,
+			"runs_tests":     runsTests,
+			"runs_lint":      runsLint,
+		},
+	}
+}
+
+// scoreCodebase scores size (share of very large files) and churn (lines
+// changed in the churn window relative to the codebase size).
+func scoreCodebase(tree *treeStats, churn *churnStats, churnErr error, window time.Duration) MetricScore {
+	details := map[string]interface{}{
+		"source_files": tree.codeFiles,
+		"code_lines":   tree.codeLines,
+		"large_files":  tree.largeFiles,
+	}
+
+	score := 100.0
+	if tree.codeFiles > 0 {
+		// Up to 40 points off when 20% or more of the files are very large
+		largeShare := float64(tree.largeFiles) / float64(tree.codeFiles)
+		score -= min(largeShare*200, 40)
+	}
+
+	if churnErr != nil {
+		details["git_history"] = false
+	} else {
+		details["git_history"] = true
+		details["churn_days"] = int(window.Hours() / 24)
+		details["commits"] = churn.commits
+		details["lines_changed"] = churn.linesChanged
+		if tree.codeLines > 0 {
+			// Rewriting more than half the codebase per window signals instability;
+			// up to 60 points off at twice the codebase size
+			ratio := float64(churn.linesChanged) / float64(tree.codeLines)
+			details["churn_ratio"] = round1(ratio*100) / 100
+			score -= min(max((ratio-0.5)*40, 0), 60)
+		}
+	}
+
+	return MetricScore{Name: MetricCodebase, Score: round1(clamp(score)), Details: details}
+}
@@ -0,0 +1,188 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScanFileSize skips very large files (generated code, data) when scanning.
+const maxScanFileSize = 1 << 20
+
+// codeExtensions lists the file extensions counted as source code.
+var codeExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".rs": true, ".java": true, ".kt": true, ".scala": true, ".swift": true,
+	".c": true, ".h": true, ".cc": true, ".cpp": true, ".hpp": true, ".cs": true,
+	".rb": true, ".php": true, ".sh": true,
+}
+
+// skippedDirs are never scanned (dependencies and build output).
+var skippedDirs = map[string]bool{
+	"vendor": true, "node_modules": true, "dist": true, "build": true, "target": true,
+}
+
+// todoPattern matches TODO-style markers.
+var todoPattern = regexp.MustCompile(`\b(TODO|FIXME|XXX|HACK)\b`)
+
+// treeStats summarizes the source files of a working tree.
+type treeStats struct {
+	codeFiles  int // Non-test source files
+	testFiles  int
+	codeLines  int             // Lines in non-test source files
+	todos      int             // Lines with TODO/FIXME/XXX/HACK markers
+	largeFiles int             // Source files over largeFileLines lines
+	goPackages map[string]bool // Go package directory → has a package doc comment
+}
+
+// largeFileLines is the size above which a source file counts as large.
+const largeFileLines = 1000
+
+// scanTree walks the working tree under root and collects source statistics.
+// Hidden directories, dependencies, and build output are skipped.
+func scanTree(ctx context.Context, root string) (*treeStats, error) {
+	stats := &treeStats{goPackages: make(map[string]bool)}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Unreadable entries are skipped
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := filepath.Ext(name)
+		if !codeExtensions[ext] {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > maxScanFileSize {
+			return nil
+		}
+
+		if isTestFile(path, name) {
+			stats.testFiles++
+			return nil
+		}
+
+		lines, todos, hasPackageDoc, err := scanSourceFile(path)
+		if err != nil {
+			return nil
+		}
+		stats.codeFiles++
+		stats.codeLines += lines
+		stats.todos += todos
+		if lines > largeFileLines {
+			stats.largeFiles++
+		}
+		if ext == ".go" {
+			dir := filepath.Dir(path)
+			stats.goPackages[dir] = stats.goPackages[dir] || hasPackageDoc
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan repository %q: %w", root, err)
+	}
+	return stats, nil
+}
+
+// isTestFile reports whether a source file is a test by common naming conventions.
+func isTestFile(path, name string) bool {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	switch {
+	case strings.HasSuffix(base, "_test"), strings.HasSuffix(base, ".test"), strings.HasSuffix(base, ".spec"):
+		return true
+	case strings.HasPrefix(base, "test_"):
+		return true
+	}
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+		if dir == "test" || dir == "tests" || dir == "__tests__" {
+			return true
+		}
+	}
+	return false
+}
+
+// scanSourceFile counts lines and TODO markers in a source file and reports
+// whether a Go file has a package doc comment ("// Package name ...").
+func scanSourceFile(path string) (lines, todos int, hasPackageDoc bool, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer f.Close()
+
+	inHeader := true // Before the Go package clause
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxScanFileSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		lines++
+		if todoPattern.MatchString(line) {
+			todos++
+		}
+		if inHeader {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "// Package ") || strings.HasPrefix(trimmed, "Package ") {
+				hasPackageDoc = true
+			}
+			if strings.HasPrefix(trimmed, "package ") {
+				inHeader = false
+			}
+		}
+	}
+	return lines, todos, hasPackageDoc, scanner.Err()
+}
+
+// churnStats summarizes recent git history.
+type churnStats struct {
+	commits      int
+	linesChanged int // Added plus deleted lines
+}
+
+// gitChurn reads lines changed and commits since the given time from git log.
+// The root commit's lines are not counted: importing a project is not churn.
+func gitChurn(ctx context.Context, root string, since time.Time) (*churnStats, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", root, "log",
+		"--since="+since.Format(time.RFC3339), "--numstat", "--format=commit %H %P")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git history in %q: %w", root, err)
+	}
+
+	stats := &churnStats{}
+	rootCommit := false
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "commit ") {
+			stats.commits++
+			rootCommit = len(strings.Fields(line)) == 2 // No parent hashes
+			continue
+		}
+		fields := strings.Fields(line)
+		if rootCommit || len(fields) < 3 {
+			continue
+		}
+		// Binary files report "-" for both counts
+		added, errA := strconv.Atoi(fields[0])
+		deleted, errD := strconv.Atoi(fields[1])
+		if errA == nil && errD == nil {
+			stats.linesChanged += added + deleted
+		}
+	}
+	return stats, nil
+}
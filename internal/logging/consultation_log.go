@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
@@ -24,6 +23,12 @@ type ConsultationLogger struct {
 	file        *os.File
 	encoder     *json.Encoder
 	currentDate string // Track current date for rotation (YYYY-MM-DD format)
+	clock       wisdom.Clock
+	day         wisdom.DayBoundary         // When a new log day begins
+	onLog       func(*wisdom.Consultation) // Called after each logged consultation
+	retention   Retention                  // Applied to rotated files after each rotation
+	appLogger   *Logger                    // Reports maintenance failures after a rotation
+	maintainMu  sync.Mutex                 // Serializes maintenance passes, which run without mu
 }
 
 // NewConsultationLogger creates a new consultation logger.
@@ -38,15 +43,16 @@ func NewConsultationLogger(logDir string) (*ConsultationLogger, error) {
 	// Log file path
 	filePath := filepath.Join(logDir, "consultations.jsonl")
 
-	// Get current date for rotation tracking
-	currentDate := time.Now().Format("2006-01-02")
+	// Get current date for rotation tracking (local midnight until WithClock is called)
+	day := wisdom.DayBoundary{}
+	currentDate := logDate(day, time.Now())
 
 	// Check if file exists and get its modification date
 	var file *os.File
 	var err error
 	if info, err := os.Stat(filePath); err == nil {
 		// File exists - check if it needs rotation
-		fileDate := info.ModTime().Format("2006-01-02")
+		fileDate := logDate(day, info.ModTime())
 		if fileDate != currentDate {
 			// File is from a different date - rotate it
 			rotatedPath := filepath.Join(logDir, fmt.Sprintf("consultations-%s.jsonl", fileDate))
@@ -68,15 +74,43 @@ func NewConsultationLogger(logDir string) (*ConsultationLogger, error) {
 		file:        file,
 		encoder:     json.NewEncoder(file),
 		currentDate: currentDate,
+		clock:       wisdom.SystemClock,
+		day:         day,
+		appLogger:   NewLogger(),
 	}
 
 	return logger, nil
 }
 
+// WithClock sets the clock used for timestamps and the day boundary at which
+// log files rotate, so the log splits at the same moment for a whole team.
+// Pass the engine's Clock() and DayBoundary() to match daily quote selection.
+func (l *ConsultationLogger) WithClock(clock wisdom.Clock, day wisdom.DayBoundary) *ConsultationLogger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if clock != nil {
+		l.clock = clock
+	}
+	l.day = day
+
+	// Re-date the current file under the new boundary; the next Log rotates it if needed
+	l.currentDate = logDate(day, l.clock.Now())
+	if info, err := os.Stat(l.filePath); err == nil && info.Size() > 0 {
+		l.currentDate = logDate(day, info.ModTime())
+	}
+	return l
+}
+
+// logDate returns the log day containing t (YYYY-MM-DD format).
+func logDate(day wisdom.DayBoundary, t time.Time) string {
+	return day.Date(t).Format("2006-01-02")
+}
+
 // rotateIfNeeded checks if log rotation is needed based on date change
 // Must be called with mutex held
 func (l *ConsultationLogger) rotateIfNeeded() error {
-	currentDate := time.Now().Format("2006-01-02")
+	currentDate := logDate(l.day, l.clock.Now())
 
 	// If date hasn't changed, no rotation needed
 	if l.currentDate == currentDate {
@@ -117,34 +151,105 @@ func (l *ConsultationLogger) rotateIfNeeded() error {
 	return nil
 }
 
+// WithRetention sets how rotated log files are deleted, compressed and
+// compacted. It is applied by Maintain, which Log calls after each rotation.
+func (l *ConsultationLogger) WithRetention(retention Retention) *ConsultationLogger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.retention = retention
+	return l
+}
+
+// WithLogger sets the logger that reports log maintenance failures after a
+// rotation (default: a new Logger writing to stderr).
+func (l *ConsultationLogger) WithLogger(appLogger *Logger) *ConsultationLogger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if appLogger != nil {
+		l.appLogger = appLogger
+	}
+	return l
+}
+
+// Maintain applies the logger's retention to the rotated log files and
+// updates the index that lets queries skip files (see the package function
+// Maintain). Call it at startup to apply a changed retention right away.
+// Logging continues during maintenance, which never changes the current file.
+func (l *ConsultationLogger) Maintain() (*MaintenanceReport, error) {
+	l.mu.Lock()
+	dir, retention, day, now := l.logDir, l.retention, l.day, l.clock.Now()
+	l.mu.Unlock()
+
+	l.maintainMu.Lock()
+	defer l.maintainMu.Unlock()
+	return Maintain(dir, retention, day, now)
+}
+
+// OnLog sets a function called after each consultation is written by Log,
+// for example to notify readers of the log. It is called without the
+// logger's lock held, so it may read the log.
+func (l *ConsultationLogger) OnLog(fn func(*wisdom.Consultation)) *ConsultationLogger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onLog = fn
+	return l
+}
+
 // Log writes a consultation to the JSONL log file
 // Thread-safe: uses mutex to protect concurrent writes
 // Automatically rotates log file if date has changed
+// Maintenance after a rotation does not fail Log: the consultation is already
+// written, so a failure is reported to the logger set by WithLogger.
 func (l *ConsultationLogger) Log(consultation *wisdom.Consultation) error {
+	onLog, rotated, err := l.write(consultation)
+	if err != nil {
+		return err
+	}
+	if onLog != nil {
+		onLog(consultation)
+	}
+	if rotated {
+		if _, err := l.Maintain(); err != nil {
+			l.mu.Lock()
+			appLogger := l.appLogger
+			l.mu.Unlock()
+			appLogger.Warn("", "Consultation logged, but log maintenance failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// write appends a consultation to the log file and returns the OnLog
+// function, and whether the log was rotated first.
+func (l *ConsultationLogger) write(consultation *wisdom.Consultation) (func(*wisdom.Consultation), bool, error) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
 	// Check if rotation is needed (date-based)
+	previousDate := l.currentDate
 	if err := l.rotateIfNeeded(); err != nil {
-		return fmt.Errorf("log rotation failed: %w", err)
+		return nil, false, fmt.Errorf("log rotation failed: %w", err)
 	}
+	rotated := l.currentDate != previousDate
 
 	// Encode consultation as JSON and write as single line
 	if err := l.encoder.Encode(consultation); err != nil {
-		return fmt.Errorf("failed to encode consultation: %w", err)
+		return nil, rotated, fmt.Errorf("failed to encode consultation: %w", err)
 	}
 
 	// Flush to ensure data is written
 	if err := l.file.Sync(); err != nil {
-		return fmt.Errorf("failed to sync log file: %w", err)
+		return nil, rotated, fmt.Errorf("failed to sync log file: %w", err)
 	}
 
-	return nil
+	return l.onLog, rotated, nil
 }
 
-// readLogFile reads consultations from a single log file
-func (l *ConsultationLogger) readLogFile(filePath string, cutoffTime time.Time) ([]*wisdom.Consultation, error) {
-	file, err := os.Open(filePath)
+// readLogFile reads consultations from a single log file, decompressing
+// gzipped (.gz) files. A missing file has no consultations; malformed lines
+// are skipped.
+func readLogFile(filePath string) ([]*wisdom.Consultation, error) {
+	file, err := openLogFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// File doesn't exist, return empty array
@@ -170,18 +275,7 @@ func (l *ConsultationLogger) readLogFile(filePath string, cutoffTime time.Time)
 			// Skip malformed JSON lines (log error but continue)
 			continue
 		}
-
-		// Parse timestamp and filter by date
-		timestamp, err := time.Parse(time.RFC3339, consultation.Timestamp)
-		if err != nil {
-			// Skip entries with invalid timestamps
-			continue
-		}
-
-		// Include consultations from cutoff time onwards
-		if timestamp.After(cutoffTime) || timestamp.Equal(cutoffTime) {
-			consultations = append(consultations, &consultation)
-		}
+		consultations = append(consultations, &consultation)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -192,64 +286,28 @@ func (l *ConsultationLogger) readLogFile(filePath string, cutoffTime time.Time)
 }
 
 // GetLogs retrieves consultations from log files filtered by days
-// Returns consultations from the last N days, reading from both current and rotated files
+// Returns consultations from the last N days, oldest first, reading from both current and rotated files
 func (l *ConsultationLogger) GetLogs(days int) ([]*wisdom.Consultation, error) {
 	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	// Calculate cutoff time
-	cutoffTime := time.Now().AddDate(0, 0, -days)
+	since := l.clock.Now().AddDate(0, 0, -days)
+	l.mu.Unlock()
 
-	var allConsultations []*wisdom.Consultation
-
-	// Read from current log file
-	consultations, err := l.readLogFile(l.filePath, cutoffTime)
+	result, err := l.Query(Query{Since: since, Order: OrderOldest})
 	if err != nil {
 		return nil, err
 	}
-	allConsultations = append(allConsultations, consultations...)
-
-	// Read from rotated log files (consultations-YYYY-MM-DD.jsonl)
-	entries, err := os.ReadDir(l.logDir)
-	if err != nil {
-		// If we can't read directory, just return what we got from current file
-		return allConsultations, nil
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		name := entry.Name()
-		// Check if it's a rotated log file (starts with "consultations-" and ends with ".jsonl")
-		if strings.HasPrefix(name, "consultations-") && strings.HasSuffix(name, ".jsonl") && name != "consultations.jsonl" {
-			// Extract date from filename (consultations-YYYY-MM-DD.jsonl)
-			dateStr := strings.TrimPrefix(name, "consultations-")
-			dateStr = strings.TrimSuffix(dateStr, ".jsonl")
-
-			// Parse date to check if it's within range
-			fileDate, err := time.Parse("2006-01-02", dateStr)
-			if err != nil {
-				// Skip files with invalid date format
-				continue
-			}
+	return result.Entries, nil
+}
 
-			// Only read files that might contain logs within our date range
-			// (file date should be >= cutoff date)
-			if fileDate.After(cutoffTime) || fileDate.Equal(cutoffTime) {
-				rotatedPath := filepath.Join(l.logDir, name)
-				consultations, err := l.readLogFile(rotatedPath, cutoffTime)
-				if err != nil {
-					// Log error but continue with other files
-					continue
-				}
-				allConsultations = append(allConsultations, consultations...)
-			}
-		}
-	}
+// Query returns the consultations in the log matching q.
+// Days are split on the logger's day boundary.
+// Logging continues while the log is read.
+func (l *ConsultationLogger) Query(q Query) (*QueryResult, error) {
+	l.mu.Lock()
+	dir, day := l.logDir, l.day
+	l.mu.Unlock()
 
-	return allConsultations, nil
+	return NewLogReader(dir).WithDayBoundary(day).Query(q)
 }
 
 // Close closes the log file
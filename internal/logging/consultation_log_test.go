@@ -73,6 +73,34 @@ func TestConsultationLogger_Log(t *testing.T) {
 	}
 }
 
+func TestConsultationLogger_OnLog(t *testing.T) {
+	logger, err := NewConsultationLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer logger.Close()
+
+	var logged []*wisdom.Consultation
+	logger.OnLog(func(consultation *wisdom.Consultation) {
+		// The log is readable from the callback
+		logs, err := logger.GetLogs(1)
+		if err != nil || len(logs) != len(logged)+1 {
+			t.Errorf("GetLogs() in OnLog = %d entries, %v; want %d", len(logs), err, len(logged)+1)
+		}
+		logged = append(logged, consultation)
+	})
+
+	consultation := &wisdom.Consultation{Timestamp: time.Now().Format(time.RFC3339), Advisor: "stoic", Quote: "Q"}
+	for i := 0; i < 2; i++ {
+		if err := logger.Log(consultation); err != nil {
+			t.Fatalf("Log failed: %v", err)
+		}
+	}
+	if len(logged) != 2 || logged[0] != consultation {
+		t.Errorf("OnLog called with %v, want the consultation twice", logged)
+	}
+}
+
 func TestConsultationLogger_GetLogs(t *testing.T) {
 	tmpDir := t.TempDir()
 	logger, err := NewConsultationLogger(tmpDir)
@@ -201,6 +229,49 @@ func TestConsultationLogger_ThreadSafety(t *testing.T) {
 	}
 }
 
+func TestConsultationLogger_LogDuringQueryAndMaintain(t *testing.T) {
+	logger, err := NewConsultationLogger(newRotatedLog(t, "2026-04-08", "2026-04-09"))
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer logger.Close()
+	logger.WithRetention(Retention{Compress: true})
+
+	// Queries and maintenance don't hold the logger's lock while reading files
+	done := make(chan bool, 30)
+	for i := 0; i < 10; i++ {
+		go func(id int) {
+			if err := logger.Log(&wisdom.Consultation{Timestamp: time.Now().Format(time.RFC3339), Advisor: "stoic", ScoreAtTime: float64(id)}); err != nil {
+				t.Errorf("Log failed: %v", err)
+			}
+			done <- true
+		}(i)
+		go func() {
+			if _, err := logger.Query(Query{}); err != nil {
+				t.Errorf("Query failed: %v", err)
+			}
+			done <- true
+		}()
+		go func() {
+			if _, err := logger.Maintain(); err != nil {
+				t.Errorf("Maintain failed: %v", err)
+			}
+			done <- true
+		}()
+	}
+	for i := 0; i < 30; i++ {
+		<-done
+	}
+
+	logs, err := logger.GetLogs(1)
+	if err != nil {
+		t.Fatalf("GetLogs failed: %v", err)
+	}
+	if len(logs) != 10 {
+		t.Errorf("GetLogs() = %d entries, want the 10 logged", len(logs))
+	}
+}
+
 func TestConsultationLogger_LogRotation(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -423,3 +494,51 @@ func TestConsultationLogger_RotationIdempotent(t *testing.T) {
 		t.Errorf("Expected at least 2 logs, got %d", len(logs))
 	}
 }
+
+func TestConsultationLogger_WithClock(t *testing.T) {
+	tmpDir := t.TempDir()
+	now := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	clock := wisdom.ClockFunc(func() time.Time { return now })
+	// Days start at 06:00 UTC, so 23:00 on March 1 and 05:00 on March 2 share a log day
+	day := wisdom.DayBoundary{Location: time.UTC, StartHour: 6}
+
+	logger, err := NewConsultationLogger(tmpDir)
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer logger.Close()
+	logger.WithClock(clock, day)
+
+	log := func(quote string) {
+		t.Helper()
+		if err := logger.Log(&wisdom.Consultation{Timestamp: now.Format(time.RFC3339), Quote: quote}); err != nil {
+			t.Fatalf("Log failed: %v", err)
+		}
+	}
+
+	log("evening")
+	now = time.Date(2026, 3, 2, 5, 0, 0, 0, time.UTC)
+	log("early morning")
+	if _, err := os.Stat(filepath.Join(tmpDir, "consultations-2026-03-01.jsonl")); err == nil {
+		t.Fatal("log rotated before the configured day boundary")
+	}
+
+	now = time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)
+	log("after boundary")
+	if _, err := os.Stat(filepath.Join(tmpDir, "consultations-2026-03-01.jsonl")); err != nil {
+		t.Fatalf("log not rotated at the day boundary: %v", err)
+	}
+
+	// GetLogs measures days from the injected clock
+	logs, err := logger.GetLogs(1)
+	if err != nil {
+		t.Fatalf("GetLogs failed: %v", err)
+	}
+	if len(logs) != 3 {
+		t.Errorf("GetLogs(1) returned %d consultations, want 3", len(logs))
+	}
+	now = now.AddDate(0, 0, 3)
+	if logs, _ := logger.GetLogs(1); len(logs) != 0 {
+		t.Errorf("GetLogs(1) three days later returned %d consultations, want 0", len(logs))
+	}
+}
@@ -0,0 +1,200 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// DefaultFollowInterval is how often a Follower checks the log for new consultations.
+const DefaultFollowInterval = time.Second
+
+// Follower streams the consultations appended to the log in a directory,
+// like tail -f. It polls the current consultations.jsonl and follows it
+// across date-based rotation: when the file is renamed to
+// consultations-YYYY-MM-DD.jsonl, the rest of the renamed file is read
+// before the new consultations.jsonl. Files are not kept open between polls,
+// so the writer can rotate them on every platform.
+type Follower struct {
+	dir      string
+	interval time.Duration
+	query    Query
+	onStart  func()
+}
+
+// followPosition is how far a Follower has read a log file.
+type followPosition struct {
+	info   os.FileInfo // The file read, to recognize it once renamed; nil before it exists
+	offset int64       // End of the last complete line read
+}
+
+// NewFollower creates a follower for the log files in logDir.
+func NewFollower(logDir string) *Follower {
+	return &Follower{dir: logDir, interval: DefaultFollowInterval}
+}
+
+// WithInterval sets how often the log is checked for new consultations.
+func (f *Follower) WithInterval(interval time.Duration) *Follower {
+	if interval > 0 {
+		f.interval = interval
+	}
+	return f
+}
+
+// WithQuery sets the filters new consultations must pass. The order, page
+// and aggregation fields of q are ignored.
+func (f *Follower) WithQuery(q Query) *Follower {
+	f.query = q
+	return f
+}
+
+// OnStart sets a function called once Follow has found the end of the log,
+// for example to read the consultations logged before it without missing any
+// logged in between.
+func (f *Follower) OnStart(fn func()) *Follower {
+	f.onStart = fn
+	return f
+}
+
+// Follow calls fn with each matching consultation logged after Follow is
+// called, in the order they were logged, until ctx is done or fn returns an
+// error. It returns ctx.Err() or the error of fn.
+func (f *Follower) Follow(ctx context.Context, fn func(*wisdom.Consultation) error) error {
+	if err := f.query.validate(); err != nil {
+		return err
+	}
+
+	current := filepath.Join(f.dir, "consultations.jsonl")
+	pos := f.start(current)
+	if f.onStart != nil {
+		f.onStart()
+	}
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := f.poll(current, &pos, fn); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// start returns the end of the current file, where following begins.
+func (f *Follower) start(current string) followPosition {
+	if info, err := os.Stat(current); err == nil {
+		return followPosition{info: info, offset: info.Size()}
+	}
+	return followPosition{}
+}
+
+// poll reads the consultations appended to the current file since pos,
+// finishing the previous file first if it was rotated.
+func (f *Follower) poll(current string, pos *followPosition, fn func(*wisdom.Consultation) error) error {
+	info, err := os.Stat(current)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to check log file %q: %w", current, err)
+	}
+
+	if pos.info != nil && (info == nil || !os.SameFile(pos.info, info)) {
+		// The file was rotated (or removed): read what was appended to it
+		// before the rename, then start the new file from the beginning
+		if rotated := f.findRotated(pos.info); rotated != "" {
+			if _, err := f.readFrom(rotated, pos.offset, fn); err != nil {
+				return err
+			}
+		}
+		*pos = followPosition{}
+	}
+	if info == nil {
+		return nil // Not created yet
+	}
+
+	if info.Size() < pos.offset {
+		pos.offset = 0 // Truncated, or replaced by a file not recognized as new
+	}
+	offset, err := f.readFrom(current, pos.offset, fn)
+	if err != nil {
+		return err
+	}
+	pos.info, pos.offset = info, offset
+	return nil
+}
+
+// findRotated returns the path of the rotated log file that is the file
+// described by info, or "" if there is none.
+func (f *Follower) findRotated(info os.FileInfo) string {
+	dirEntries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return ""
+	}
+	var names []string
+	for _, entry := range dirEntries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, "consultations-") && strings.HasSuffix(name, ".jsonl") {
+			names = append(names, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names))) // The most recent rotation is the likeliest
+	for _, name := range names {
+		path := filepath.Join(f.dir, name)
+		if rotated, err := os.Stat(path); err == nil && os.SameFile(info, rotated) {
+			return path
+		}
+	}
+	return ""
+}
+
+// readFrom calls fn with the matching consultations on the complete lines of
+// path after offset, and returns the offset after the last complete line. A
+// line still being written is read by a later call.
+func (f *Follower) readFrom(path string, offset int64, fn func(*wisdom.Consultation) error) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return offset, nil // Renamed since it was checked; found as rotated next time
+		}
+		return offset, fmt.Errorf("failed to open log file %q: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, fmt.Errorf("failed to seek in log file %q: %w", path, err)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return offset, fmt.Errorf("failed to read log file %q: %w", path, err)
+	}
+
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		return offset, nil
+	}
+	for _, line := range bytes.Split(data[:end], []byte{'\n'}) {
+		var consultation wisdom.Consultation
+		if len(line) == 0 || json.Unmarshal(line, &consultation) != nil {
+			continue // Skip empty and malformed lines
+		}
+		t, err := time.Parse(time.RFC3339, consultation.Timestamp)
+		if err != nil || !f.query.matches(&consultation, t) {
+			continue
+		}
+		if err := fn(&consultation); err != nil {
+			return offset, err
+		}
+	}
+	return offset + int64(end) + 1, nil
+}
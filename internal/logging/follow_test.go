@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+func TestFollower_FollowsRotation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	logger, err := NewConsultationLogger(dir)
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer logger.Close()
+	logger.WithClock(wisdom.ClockFunc(func() time.Time { return now }), wisdom.DayBoundary{Location: time.UTC})
+
+	log := func(advisor string) {
+		t.Helper()
+		if err := logger.Log(logAt(now, advisor, "building", 50)); err != nil {
+			t.Fatalf("Log failed: %v", err)
+		}
+	}
+
+	log("before") // Logged before following starts
+
+	follower := NewFollower(dir).WithQuery(Query{Advisor: "stoic"})
+	current := filepath.Join(dir, "consultations.jsonl")
+	pos := follower.start(current)
+	var got []*wisdom.Consultation
+	poll := func() {
+		t.Helper()
+		if err := follower.poll(current, &pos, func(c *wisdom.Consultation) error {
+			got = append(got, c)
+			return nil
+		}); err != nil {
+			t.Fatalf("poll failed: %v", err)
+		}
+	}
+
+	// Consultations logged on both sides of a rotation between two polls
+	log("stoic")
+	log("tao")
+	now = now.Add(2 * time.Hour)
+	log("stoic")
+	if _, err := os.Stat(filepath.Join(dir, "consultations-2026-03-01.jsonl")); err != nil {
+		t.Fatalf("log not rotated: %v", err)
+	}
+	poll()
+	if quotes(got) != "stoic at 2026-03-01T23:00:00Z, stoic at 2026-03-02T01:00:00Z" {
+		t.Errorf("followed %s, want the stoic consultations on both sides of the rotation", quotes(got))
+	}
+
+	// A line still being written is read once complete
+	got = nil
+	file, err := os.OpenFile(current, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(`{"timestamp":"2026-03-02T02:00:00Z",`); err != nil {
+		t.Fatal(err)
+	}
+	poll()
+	if len(got) != 0 {
+		t.Fatalf("followed a partial line: %s", quotes(got))
+	}
+	if _, err := file.WriteString(`"advisor":"stoic","quote":"completed"}` + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	poll()
+	if quotes(got) != "completed" {
+		t.Errorf("followed %q, want the completed line", quotes(got))
+	}
+
+	// Nothing new
+	got = nil
+	poll()
+	if len(got) != 0 {
+		t.Errorf("followed %s again", quotes(got))
+	}
+}
+
+func TestFollower_Follow(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewConsultationLogger(dir)
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer logger.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	followed := make(chan *wisdom.Consultation, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- NewFollower(dir).WithInterval(10*time.Millisecond).Follow(ctx, func(c *wisdom.Consultation) error {
+			followed <- c
+			return errors.New("stop")
+		})
+	}()
+	time.Sleep(50 * time.Millisecond) // Let Follow find the end of the file
+
+	if err := logger.Log(logAt(time.Now(), "stoic", "building", 50)); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	select {
+	case c := <-followed:
+		if c.Advisor != "stoic" {
+			t.Errorf("followed %+v", c)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the consultation")
+	}
+	if err := <-done; err == nil || err.Error() != "stop" {
+		t.Errorf("Follow() = %v, want the callback's error", err)
+	}
+
+	if err := NewFollower(dir).WithQuery(Query{Order: "random"}).Follow(ctx, nil); err == nil {
+		t.Error("Follow() accepted an invalid query")
+	}
+}
@@ -0,0 +1,251 @@
+package logging
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// Names of the files in a log directory besides the rotated files.
+const (
+	currentLogName = "consultations.jsonl"      // Written by ConsultationLogger
+	indexName      = "consultations.index.json" // Sidecar index written by Maintain
+)
+
+// logFile is a rotated log file: consultations-YYYY-MM-DD.jsonl written by
+// date-based rotation, or consultations-YYYY-MM.jsonl written by monthly
+// compaction, either optionally gzipped (.jsonl.gz).
+type logFile struct {
+	name       string
+	path       string
+	start, end time.Time // Log days covered by the file name, by the day boundary
+	monthly    bool
+	compressed bool
+	size       int64
+	modTime    time.Time
+}
+
+// period returns the file's date ("2006-01-02") or month ("2006-01").
+func (f logFile) period() string {
+	if f.monthly {
+		return f.start.Format("2006-01")
+	}
+	return f.start.Format("2006-01-02")
+}
+
+// rotatedName returns the name of the rotated file for a date ("2006-01-02")
+// or month ("2006-01").
+func rotatedName(period string, compressed bool) string {
+	if compressed {
+		return "consultations-" + period + ".jsonl.gz"
+	}
+	return "consultations-" + period + ".jsonl"
+}
+
+// listLogFiles returns the rotated log files in dir, oldest first. If a file
+// exists both plain and gzipped, the plain file is used until compression
+// merges the two.
+func listLogFiles(dir string, day wisdom.DayBoundary) ([]logFile, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read consultation log directory %q: %w", dir, err)
+	}
+
+	loc := dayLocation(day)
+	byPeriod := make(map[string]logFile)
+	for _, entry := range dirEntries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "consultations-") {
+			continue
+		}
+		stem := strings.TrimPrefix(name, "consultations-")
+		file := logFile{name: name, path: filepath.Join(dir, name)}
+		switch {
+		case strings.HasSuffix(stem, ".jsonl.gz"):
+			stem, file.compressed = strings.TrimSuffix(stem, ".jsonl.gz"), true
+		case strings.HasSuffix(stem, ".jsonl"):
+			stem = strings.TrimSuffix(stem, ".jsonl")
+		default:
+			continue
+		}
+		if date, err := time.ParseInLocation("2006-01-02", stem, loc); err == nil {
+			file.start, file.end = day.StartOf(date), day.StartOf(date.AddDate(0, 0, 1))
+		} else if month, err := time.ParseInLocation("2006-01", stem, loc); err == nil {
+			file.start, file.end, file.monthly = day.StartOf(month), day.StartOf(month.AddDate(0, 1, 0)), true
+		} else {
+			continue // Skip files with invalid date format
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed since the directory was read
+		}
+		file.size, file.modTime = info.Size(), info.ModTime()
+
+		key := stem
+		if existing, ok := byPeriod[key]; ok && !existing.compressed {
+			continue
+		}
+		byPeriod[key] = file
+	}
+
+	files := make([]logFile, 0, len(byPeriod))
+	for _, file := range byPeriod {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].start.Equal(files[j].start) {
+			return files[i].start.Before(files[j].start)
+		}
+		return files[i].name < files[j].name
+	})
+	return files, nil
+}
+
+// dayLocation returns the time zone in which a day boundary dates files.
+func dayLocation(day wisdom.DayBoundary) *time.Location {
+	if day.Location != nil {
+		return day.Location
+	}
+	return time.Local
+}
+
+// openLogFile opens a log file for reading, decompressing .gz files.
+func openLogFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to decompress log file %s: %w", path, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{reader, file}, nil
+}
+
+// logIndex is the sidecar index of a log directory: the time range of the
+// entries in each rotated file, so that queries can skip files without
+// reading them. An entry is only used while the file's size and modification
+// time are those indexed.
+type logIndex struct {
+	Files map[string]indexEntry `json:"files"`
+}
+
+// indexEntry describes the entries of an indexed log file.
+type indexEntry struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Count   int       `json:"count"` // Entries with a valid timestamp
+	First   time.Time `json:"first"` // Earliest timestamp; zero without entries
+	Last    time.Time `json:"last"`  // Latest timestamp
+}
+
+// readIndex returns the index of dir. A missing or invalid index is empty.
+func readIndex(dir string) logIndex {
+	index := logIndex{Files: make(map[string]indexEntry)}
+	data, err := os.ReadFile(filepath.Join(dir, indexName))
+	if err != nil {
+		return index
+	}
+	if err := json.Unmarshal(data, &index); err != nil || index.Files == nil {
+		return logIndex{Files: make(map[string]indexEntry)}
+	}
+	return index
+}
+
+// lookup returns the index entry of f, if it is up to date.
+func (idx logIndex) lookup(f logFile) (indexEntry, bool) {
+	entry, ok := idx.Files[f.name]
+	if !ok || entry.Size != f.size || !entry.ModTime.Equal(f.modTime) {
+		return indexEntry{}, false
+	}
+	return entry, true
+}
+
+// write replaces the index of dir.
+func (idx logIndex) write(dir string) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode consultation log index: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(dir, indexName), func(w io.Writer) error {
+		_, err := w.Write(append(data, '\n'))
+		return err
+	})
+}
+
+// indexFile reads f and returns its index entry.
+func indexFile(f logFile) (indexEntry, error) {
+	entry := indexEntry{Size: f.size, ModTime: f.modTime}
+	consultations, err := readLogFile(f.path)
+	if err != nil {
+		return entry, err
+	}
+	for _, c := range consultations {
+		t, err := time.Parse(time.RFC3339, c.Timestamp)
+		if err != nil {
+			continue
+		}
+		if entry.Count == 0 || t.Before(entry.First) {
+			entry.First = t
+		}
+		if entry.Count == 0 || t.After(entry.Last) {
+			entry.Last = t
+		}
+		entry.Count++
+	}
+	return entry, nil
+}
+
+// writeFileAtomic writes a file with write, through a temporary file renamed
+// into place, so readers never see it partially written.
+func writeFileAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %q: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	buffered := bufio.NewWriter(tmp)
+	if err := write(buffered); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	if err := buffered.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync %q: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set permissions of %q: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %q: %w", path, err)
+	}
+	return nil
+}
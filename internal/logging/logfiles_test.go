@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// gzipLogFile compresses name in dir to name.gz and removes name.
+func gzipLogFile(t *testing.T, dir, name string) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(mustRead(t, filepath.Join(dir, name))); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".gz"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, name)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	day1 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	for _, name := range []string{
+		"consultations-2026-03-02.jsonl",
+		"consultations-2026-02.jsonl.gz",
+		"consultations-2026-03-01.jsonl",
+		"consultations-2026-03-01.jsonl.gz", // Interrupted compression
+		"consultations.jsonl",
+		"consultations.index.json",
+		"consultations-latest.jsonl",
+		"consultations-2026-03-03.txt",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := listLogFiles(dir, wisdom.DayBoundary{Location: time.UTC, StartHour: 6})
+	if err != nil {
+		t.Fatalf("listLogFiles failed: %v", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.name)
+	}
+	want := "consultations-2026-02.jsonl.gz consultations-2026-03-01.jsonl consultations-2026-03-02.jsonl"
+	if strings.Join(names, " ") != want {
+		t.Errorf("listLogFiles() = %v, want %s", names, want)
+	}
+
+	month := files[0]
+	if !month.monthly || !month.compressed || month.period() != "2026-02" ||
+		!month.start.Equal(time.Date(2026, 2, 1, 6, 0, 0, 0, time.UTC)) || !month.end.Equal(time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("monthly file = %+v", month)
+	}
+	if files[1].monthly || files[1].compressed || !files[1].start.Equal(day1.Add(-3*time.Hour)) {
+		t.Errorf("daily file = %+v", files[1])
+	}
+}
+
+func TestLogReader_ReadsCompressedFiles(t *testing.T) {
+	reader, _ := newQueryLog(t)
+	gzipLogFile(t, reader.dir, "consultations-2026-03-01.jsonl")
+
+	result, err := reader.Query(Query{Order: OrderOldest})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if result.Total != 5 || !strings.HasPrefix(result.Entries[0].Quote, "stoic at 2026-03-01") {
+		t.Errorf("Query() = %d entries (%s), want 5 including the compressed file", result.Total, quotes(result.Entries))
+	}
+
+	// Corrupt compressed files are reported
+	if err := os.WriteFile(filepath.Join(reader.dir, "consultations-2026-02-28.jsonl.gz"), []byte("not gzip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.Query(Query{}); err == nil {
+		t.Error("Query() succeeded with a corrupt compressed file")
+	}
+}
+
+func TestLogReader_UsesIndex(t *testing.T) {
+	reader, day1 := newQueryLog(t)
+	// A misplaced entry: the name says March 1, but it is from March 3
+	writeLogFile(t, reader.dir, "consultations-2026-03-01.jsonl", logAt(day1.AddDate(0, 0, 2), "misplaced", "MASTERY", 50))
+
+	since := day1.AddDate(0, 0, 2)
+	if result, _ := reader.Query(Query{Since: since}); strings.Contains(quotes(result.Entries), "misplaced") {
+		t.Fatalf("Query() read a file out of range by name: %s", quotes(result.Entries))
+	}
+
+	if _, err := Maintain(reader.dir, Retention{}, reader.day, day1.AddDate(0, 0, 3)); err != nil {
+		t.Fatalf("Maintain failed: %v", err)
+	}
+	index := readIndex(reader.dir)
+	entry, ok := index.Files["consultations-2026-03-02.jsonl"]
+	if len(index.Files) != 2 || !ok || entry.Count != 1 || !entry.First.Equal(day1.AddDate(0, 0, 1)) {
+		t.Errorf("index = %+v", index)
+	}
+
+	// The index has the actual time range of the file
+	result, err := reader.Query(Query{Since: since})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if !strings.Contains(quotes(result.Entries), "misplaced") {
+		t.Errorf("Query() = %s, want the misplaced entry found by the index", quotes(result.Entries))
+	}
+
+	// An outdated entry is not used
+	writeLogFile(t, reader.dir, "consultations-2026-03-02.jsonl", logAt(day1, "rewritten", "CHAOS", 10), logAt(day1.AddDate(0, 0, 2), "rewritten", "CHAOS", 10))
+	result, err = reader.Query(Query{Since: since})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if !strings.Contains(quotes(result.Entries), "rewritten") {
+		t.Errorf("Query() = %s, want the rewritten file read despite its outdated index entry", quotes(result.Entries))
+	}
+}
@@ -0,0 +1,418 @@
+package logging
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// Sort orders of query results.
+const (
+	OrderNewest = "newest" // Most recent first (default)
+	OrderOldest = "oldest" // Oldest first
+)
+
+// Aggregations a query can compute over all matching entries.
+const (
+	AggregateAdvisors        = "advisors"         // Consultations per advisor
+	AggregateDailyScores     = "daily_scores"     // Mean score per day
+	AggregateModeTransitions = "mode_transitions" // Changes of consultation mode between consecutive entries
+)
+
+// Aggregates lists the aggregation names accepted by Query.Aggregate.
+var Aggregates = []string{AggregateAdvisors, AggregateDailyScores, AggregateModeTransitions}
+
+// Query selects consultation log entries. Empty fields match all entries.
+type Query struct {
+	Advisor  string    // Advisor ID
+	Metric   string    // Metric name
+	Tool     string    // Tool name
+	Stage    string    // Stage name
+	Mode     string    // Consultation mode (e.g., "chaos" or "mastery")
+	MinScore *float64  // Lowest score at the time of the consultation, inclusive
+	MaxScore *float64  // Highest score at the time of the consultation, inclusive
+	Since    time.Time // Earliest timestamp, inclusive
+	Until    time.Time // Latest timestamp, exclusive
+	Context  string    // Case-insensitive substring of the consultation context
+
+	Order     string   // OrderNewest (default) or OrderOldest
+	Limit     int      // Entries per page; 0 returns all
+	Cursor    string   // NextCursor of the previous page
+	Aggregate []string // Aggregations to compute (see Aggregates)
+}
+
+// QueryResult is a page of matching entries, with aggregations over all of them.
+type QueryResult struct {
+	Entries      []*wisdom.Consultation `json:"entries"`
+	Total        int                    `json:"total"`                 // Entries matching the filters, across all pages
+	NextCursor   string                 `json:"next_cursor,omitempty"` // Set if more entries follow
+	Aggregations *Aggregations          `json:"aggregations,omitempty"`
+}
+
+// Aggregations summarize the entries matching a query.
+type Aggregations struct {
+	Advisors        []AdvisorCount   `json:"advisors,omitempty"`         // Most consulted first
+	DailyScores     []DailyScore     `json:"daily_scores,omitempty"`     // By date
+	ModeTransitions []ModeTransition `json:"mode_transitions,omitempty"` // Most frequent first
+}
+
+// AdvisorCount is the number of consultations of an advisor.
+type AdvisorCount struct {
+	Advisor     string `json:"advisor"`
+	AdvisorName string `json:"advisor_name,omitempty"`
+	Count       int    `json:"count"`
+}
+
+// DailyScore is the mean score of the consultations of a day.
+type DailyScore struct {
+	Date      string  `json:"date"` // YYYY-MM-DD
+	Count     int     `json:"count"`
+	MeanScore float64 `json:"mean_score"`
+}
+
+// ModeTransition counts consecutive consultations (in time order) whose mode
+// changed from one mode to another.
+type ModeTransition struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Count int    `json:"count"`
+}
+
+// LogReader reads the consultation log files in a directory: the current
+// consultations.jsonl, the rotated consultations-YYYY-MM-DD.jsonl files and
+// the monthly consultations-YYYY-MM.jsonl files of compaction, gzipped or not.
+// It does not write or rotate files, so it can read the log of a running
+// server.
+type LogReader struct {
+	dir string
+	day wisdom.DayBoundary
+}
+
+// NewLogReader creates a reader for the log files in logDir.
+func NewLogReader(logDir string) *LogReader {
+	return &LogReader{dir: logDir}
+}
+
+// WithDayBoundary sets the day boundary used for daily aggregations and to
+// date rotated files (default: local midnight).
+func (r *LogReader) WithDayBoundary(day wisdom.DayBoundary) *LogReader {
+	r.day = day
+	return r
+}
+
+// logEntry is a consultation with its parsed timestamp.
+type logEntry struct {
+	consultation *wisdom.Consultation
+	time         time.Time
+}
+
+// Query returns the entries matching q. Malformed lines and entries without
+// a valid RFC 3339 timestamp are skipped.
+func (r *LogReader) Query(q Query) (*QueryResult, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+	cursor, err := decodeCursor(q.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := r.read(q)
+	if err != nil {
+		return nil, err
+	}
+
+	// Oldest first; entries with the same timestamp keep the order they were logged in
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].time.Before(entries[j].time) })
+
+	result := &QueryResult{Total: len(entries), Entries: []*wisdom.Consultation{}}
+	if len(q.Aggregate) > 0 {
+		result.Aggregations = r.aggregate(entries, q.Aggregate)
+	}
+
+	if q.Order != OrderOldest {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+	page := cursor.apply(entries, q.Order == OrderOldest)
+	if q.Limit > 0 && len(page) > q.Limit {
+		page = page[:q.Limit]
+		result.NextCursor = nextCursor(entries, page[len(page)-1]).encode()
+	}
+	for _, entry := range page {
+		result.Entries = append(result.Entries, entry.consultation)
+	}
+	return result, nil
+}
+
+// validate checks the sort order, page size and aggregation names.
+func (q Query) validate() error {
+	if q.Order != "" && q.Order != OrderNewest && q.Order != OrderOldest {
+		return fmt.Errorf("invalid order %q: must be %q or %q", q.Order, OrderNewest, OrderOldest)
+	}
+	if q.Limit < 0 {
+		return fmt.Errorf("invalid limit %d: must not be negative", q.Limit)
+	}
+	if q.MinScore != nil && q.MaxScore != nil && *q.MinScore > *q.MaxScore {
+		return fmt.Errorf("invalid score range: min score %g is above max score %g", *q.MinScore, *q.MaxScore)
+	}
+	for _, name := range q.Aggregate {
+		if !containsString(Aggregates, name) {
+			return fmt.Errorf("unknown aggregation %q (expected one of: %s)", name, strings.Join(Aggregates, ", "))
+		}
+	}
+	return nil
+}
+
+// matches reports whether a consultation logged at t passes q's filters.
+func (q Query) matches(c *wisdom.Consultation, t time.Time) bool {
+	switch {
+	case q.Advisor != "" && c.Advisor != q.Advisor,
+		q.Metric != "" && c.Metric != q.Metric,
+		q.Tool != "" && c.Tool != q.Tool,
+		q.Stage != "" && c.Stage != q.Stage,
+		q.Mode != "" && !strings.EqualFold(c.ConsultationMode, q.Mode),
+		q.MinScore != nil && c.ScoreAtTime < *q.MinScore,
+		q.MaxScore != nil && c.ScoreAtTime > *q.MaxScore,
+		!q.Since.IsZero() && t.Before(q.Since),
+		!q.Until.IsZero() && !t.Before(q.Until),
+		q.Context != "" && !strings.Contains(strings.ToLower(c.Context), strings.ToLower(q.Context)):
+		return false
+	}
+	return true
+}
+
+// read returns the entries of all log files that match q, in file order:
+// rotated files by date, then the current file.
+func (r *LogReader) read(q Query) ([]logEntry, error) {
+	paths, err := r.files(q.Since, q.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []logEntry
+	for _, path := range paths {
+		consultations, err := readLogFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range consultations {
+			t, err := time.Parse(time.RFC3339, c.Timestamp)
+			if err != nil {
+				continue // Skip entries with invalid timestamps
+			}
+			if q.matches(c, t) {
+				entries = append(entries, logEntry{consultation: c, time: t})
+			}
+		}
+	}
+	return entries, nil
+}
+
+// files returns the log files that may hold entries between since and until
+// (either may be zero), rotated files by date first. Files are skipped by
+// the time range in the index or, for files not indexed, by their names.
+func (r *LogReader) files(since, until time.Time) ([]string, error) {
+	files, err := listLogFiles(r.dir, r.day)
+	if err != nil {
+		return nil, err
+	}
+
+	index := readIndex(r.dir)
+	var paths []string
+	for _, file := range files {
+		if entry, ok := index.lookup(file); ok {
+			if entry.Count == 0 || (!since.IsZero() && entry.Last.Before(since)) || (!until.IsZero() && !entry.First.Before(until)) {
+				continue
+			}
+		} else {
+			// The file holds the entries of its log days. The writer's day
+			// boundary may differ from the reader's, so allow a day on either side.
+			start, end := file.start.AddDate(0, 0, -1), file.end.AddDate(0, 0, 1)
+			if (!since.IsZero() && !end.After(since)) || (!until.IsZero() && !start.Before(until)) {
+				continue
+			}
+		}
+		paths = append(paths, file.path)
+	}
+
+	current := filepath.Join(r.dir, currentLogName)
+	if _, err := os.Stat(current); err == nil {
+		paths = append(paths, current)
+	}
+	return paths, nil
+}
+
+// location returns the time zone of the reader's day boundary.
+func (r *LogReader) location() *time.Location {
+	return dayLocation(r.day)
+}
+
+// aggregate computes the named aggregations over entries, oldest first.
+func (r *LogReader) aggregate(entries []logEntry, names []string) *Aggregations {
+	aggregations := &Aggregations{}
+
+	if containsString(names, AggregateAdvisors) {
+		counts := make(map[string]*AdvisorCount)
+		for _, entry := range entries {
+			c := entry.consultation
+			count, exists := counts[c.Advisor]
+			if !exists {
+				count = &AdvisorCount{Advisor: c.Advisor}
+				counts[c.Advisor] = count
+			}
+			count.Count++
+			if c.AdvisorName != "" {
+				count.AdvisorName = c.AdvisorName
+			}
+		}
+		for _, count := range counts {
+			aggregations.Advisors = append(aggregations.Advisors, *count)
+		}
+		sort.Slice(aggregations.Advisors, func(i, j int) bool {
+			a, b := aggregations.Advisors[i], aggregations.Advisors[j]
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			return a.Advisor < b.Advisor
+		})
+	}
+
+	if containsString(names, AggregateDailyScores) {
+		var totals []float64
+		for _, entry := range entries { // Oldest first, so days are in order
+			date := r.day.Date(entry.time.In(r.location())).Format("2006-01-02")
+			last := len(aggregations.DailyScores) - 1
+			if last < 0 || aggregations.DailyScores[last].Date != date {
+				aggregations.DailyScores = append(aggregations.DailyScores, DailyScore{Date: date})
+				totals = append(totals, 0)
+				last++
+			}
+			aggregations.DailyScores[last].Count++
+			totals[last] += entry.consultation.ScoreAtTime
+		}
+		for i := range aggregations.DailyScores {
+			aggregations.DailyScores[i].MeanScore = totals[i] / float64(aggregations.DailyScores[i].Count)
+		}
+	}
+
+	if containsString(names, AggregateModeTransitions) {
+		counts := make(map[[2]string]int)
+		for i := 1; i < len(entries); i++ {
+			from, to := entries[i-1].consultation.ConsultationMode, entries[i].consultation.ConsultationMode
+			if from != to {
+				counts[[2]string{from, to}]++
+			}
+		}
+		for modes, count := range counts {
+			aggregations.ModeTransitions = append(aggregations.ModeTransitions, ModeTransition{From: modes[0], To: modes[1], Count: count})
+		}
+		sort.Slice(aggregations.ModeTransitions, func(i, j int) bool {
+			a, b := aggregations.ModeTransitions[i], aggregations.ModeTransitions[j]
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			if a.From != b.From {
+				return a.From < b.From
+			}
+			return a.To < b.To
+		})
+	}
+
+	return aggregations
+}
+
+// queryCursor marks the last entry of a page: its timestamp, and how many
+// entries with that timestamp the pages so far returned. Unlike an offset,
+// it stays valid while new consultations are logged.
+type queryCursor struct {
+	time time.Time
+	seen int
+}
+
+// nextCursor returns the cursor following last, an entry of entries (in
+// page order).
+func nextCursor(entries []logEntry, last logEntry) queryCursor {
+	cursor := queryCursor{time: last.time}
+	for _, entry := range entries {
+		if entry.time.Equal(last.time) {
+			cursor.seen++
+		}
+		if entry.consultation == last.consultation {
+			break
+		}
+	}
+	return cursor
+}
+
+// apply returns the entries (in page order) after the cursor.
+func (c queryCursor) apply(entries []logEntry, oldestFirst bool) []logEntry {
+	if c.time.IsZero() {
+		return entries
+	}
+	for i, entry := range entries {
+		after := entry.time.Before(c.time)
+		if oldestFirst {
+			after = entry.time.After(c.time)
+		}
+		if after {
+			return entries[i:]
+		}
+		if entry.time.Equal(c.time) {
+			if c.seen == 0 {
+				return entries[i:]
+			}
+			c.seen--
+		}
+	}
+	return nil
+}
+
+// encode returns the cursor as an opaque string.
+func (c queryCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", c.time.UnixNano(), c.seen)))
+}
+
+// decodeCursor parses a cursor returned in QueryResult.NextCursor; an empty
+// string is the start of the results.
+func decodeCursor(s string) (queryCursor, error) {
+	if s == "" {
+		return queryCursor{}, nil
+	}
+	invalid := fmt.Errorf("invalid cursor %q: pass the next_cursor of a previous page", s)
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return queryCursor{}, invalid
+	}
+	nanos, seen, ok := strings.Cut(string(data), ":")
+	if !ok {
+		return queryCursor{}, invalid
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return queryCursor{}, invalid
+	}
+	count, err := strconv.Atoi(seen)
+	if err != nil || count < 1 {
+		return queryCursor{}, invalid
+	}
+	return queryCursor{time: time.Unix(0, n), seen: count}, nil
+}
+
+// containsString reports whether values contains s.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// writeLogFile writes consultations to name in dir as JSONL.
+func writeLogFile(t *testing.T, dir, name string, consultations ...*wisdom.Consultation) {
+	t.Helper()
+	var lines []string
+	for _, c := range consultations {
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		lines = append(lines, string(data))
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+// logAt returns a consultation logged at t.
+func logAt(t time.Time, advisor, mode string, score float64) *wisdom.Consultation {
+	return &wisdom.Consultation{
+		Timestamp:        t.Format(time.RFC3339),
+		Advisor:          advisor,
+		AdvisorName:      strings.ToUpper(advisor),
+		ScoreAtTime:      score,
+		ConsultationMode: mode,
+		Quote:            advisor + " at " + t.Format(time.RFC3339),
+	}
+}
+
+// quotes returns the quotes of consultations, in order.
+func quotes(consultations []*wisdom.Consultation) string {
+	var result []string
+	for _, c := range consultations {
+		result = append(result, c.Quote)
+	}
+	return strings.Join(result, ", ")
+}
+
+// newQueryLog writes a log over three days in UTC and returns a reader for it.
+func newQueryLog(t *testing.T) (*LogReader, time.Time) {
+	t.Helper()
+	dir := t.TempDir()
+	day1 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	first := logAt(day1, "stoic", "CHAOS", 30)
+	first.Metric, first.Context = "security", "Before the Audit"
+	second := logAt(day1.Add(time.Hour), "tao", "CHAOS", 40)
+	second.Metric, second.Tool = "testing", "project_scorecard"
+	writeLogFile(t, dir, "consultations-2026-03-01.jsonl", first, second)
+
+	third := logAt(day1.AddDate(0, 0, 1), "stoic", "BUILDING", 60)
+	third.Metric, third.Stage = "security", "daily_checkin"
+	writeLogFile(t, dir, "consultations-2026-03-02.jsonl", third)
+
+	day3 := day1.AddDate(0, 0, 2)
+	writeLogFile(t, dir, "consultations.jsonl",
+		logAt(day3, "stoic", "MASTERY", 90),
+		logAt(day3, "tao", "MASTERY", 80), // Same timestamp as the previous entry
+		&wisdom.Consultation{Timestamp: "yesterday", Advisor: "stoic"},
+	)
+	if err := os.WriteFile(filepath.Join(dir, "consultations.jsonl"), append(mustRead(t, filepath.Join(dir, "consultations.jsonl")), "not json\n"...), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	return NewLogReader(dir).WithDayBoundary(wisdom.DayBoundary{Location: time.UTC}), day1
+}
+
+func mustRead(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	return data
+}
+
+func TestLogReader_Query_Filters(t *testing.T) {
+	reader, day1 := newQueryLog(t)
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name  string
+		query Query
+		want  int
+	}{
+		{"all", Query{}, 5},
+		{"advisor", Query{Advisor: "stoic"}, 3},
+		{"metric", Query{Metric: "security"}, 2},
+		{"tool", Query{Tool: "project_scorecard"}, 1},
+		{"stage", Query{Stage: "daily_checkin"}, 1},
+		{"mode ignores case", Query{Mode: "mastery"}, 2},
+		{"min score", Query{MinScore: score(60)}, 3},
+		{"score range", Query{MinScore: score(35), MaxScore: score(80)}, 3},
+		{"since", Query{Since: day1.AddDate(0, 0, 1)}, 3},
+		{"until is exclusive", Query{Until: day1.Add(time.Hour)}, 1},
+		{"context substring", Query{Context: "audit"}, 1},
+		{"combined", Query{Advisor: "stoic", Metric: "security", Since: day1.Add(time.Minute)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := reader.Query(tt.query)
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			if result.Total != tt.want || len(result.Entries) != tt.want {
+				t.Errorf("Query() = %d of %d entries (%s), want %d", len(result.Entries), result.Total, quotes(result.Entries), tt.want)
+			}
+		})
+	}
+}
+
+func TestLogReader_Query_Pagination(t *testing.T) {
+	for _, order := range []string{OrderNewest, OrderOldest} {
+		t.Run(order, func(t *testing.T) {
+			reader, _ := newQueryLog(t)
+			all, err := reader.Query(Query{Order: order})
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+
+			var paged []*wisdom.Consultation
+			query := Query{Order: order, Limit: 2}
+			for page := 0; ; page++ {
+				result, err := reader.Query(query)
+				if err != nil {
+					t.Fatalf("Query failed: %v", err)
+				}
+				if result.Total != 5 || len(result.Entries) > 2 {
+					t.Fatalf("page %d: %d of %d entries", page, len(result.Entries), result.Total)
+				}
+				paged = append(paged, result.Entries...)
+				if result.NextCursor == "" {
+					break
+				}
+				query.Cursor = result.NextCursor
+			}
+			if quotes(paged) != quotes(all.Entries) {
+				t.Errorf("pages = %s, want %s", quotes(paged), quotes(all.Entries))
+			}
+		})
+	}
+
+	reader, _ := newQueryLog(t)
+	newest, _ := reader.Query(Query{})
+	if !strings.HasPrefix(newest.Entries[0].Quote, "tao") || !strings.HasPrefix(newest.Entries[4].Quote, "stoic") {
+		t.Errorf("newest first = %s", quotes(newest.Entries))
+	}
+}
+
+func TestLogReader_Query_CursorSurvivesNewEntries(t *testing.T) {
+	reader, day1 := newQueryLog(t)
+	first, err := reader.Query(Query{Limit: 2})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	// A consultation logged between pages does not shift the next page
+	current := filepath.Join(reader.dir, "consultations.jsonl")
+	data, _ := json.Marshal(logAt(day1.AddDate(0, 0, 3), "stoic", "MASTERY", 95))
+	if err := os.WriteFile(current, append(mustRead(t, current), append(data, '\n')...), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	second, err := reader.Query(Query{Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	want, _ := reader.Query(Query{})
+	if quotes(second.Entries) != quotes(want.Entries[3:5]) {
+		t.Errorf("second page = %s, want %s", quotes(second.Entries), quotes(want.Entries[3:5]))
+	}
+}
+
+func TestLogReader_Query_Aggregations(t *testing.T) {
+	reader, _ := newQueryLog(t)
+	result, err := reader.Query(Query{Limit: 1, Aggregate: Aggregates})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	a := result.Aggregations
+	if a == nil {
+		t.Fatal("no aggregations")
+	}
+
+	if len(a.Advisors) != 2 || a.Advisors[0] != (AdvisorCount{Advisor: "stoic", AdvisorName: "STOIC", Count: 3}) {
+		t.Errorf("advisors = %+v, want stoic (3) first", a.Advisors)
+	}
+
+	wantDays := []DailyScore{{"2026-03-01", 2, 35}, {"2026-03-02", 1, 60}, {"2026-03-03", 2, 85}}
+	if len(a.DailyScores) != len(wantDays) {
+		t.Fatalf("daily scores = %+v, want %+v", a.DailyScores, wantDays)
+	}
+	for i, want := range wantDays {
+		if a.DailyScores[i] != want {
+			t.Errorf("daily score %d = %+v, want %+v", i, a.DailyScores[i], want)
+		}
+	}
+
+	wantTransitions := []ModeTransition{{"BUILDING", "MASTERY", 1}, {"CHAOS", "BUILDING", 1}}
+	if len(a.ModeTransitions) != 2 || a.ModeTransitions[0] != wantTransitions[0] || a.ModeTransitions[1] != wantTransitions[1] {
+		t.Errorf("mode transitions = %+v, want %+v", a.ModeTransitions, wantTransitions)
+	}
+}
+
+func TestLogReader_Query_Invalid(t *testing.T) {
+	reader, _ := newQueryLog(t)
+	low, high := 80.0, 20.0
+	for _, q := range []Query{
+		{Order: "random"},
+		{Limit: -1},
+		{Cursor: "not-a-cursor"},
+		{Aggregate: []string{"median"}},
+		{MinScore: &low, MaxScore: &high},
+	} {
+		if _, err := reader.Query(q); err == nil {
+			t.Errorf("Query(%+v) succeeded, want an error", q)
+		}
+	}
+}
+
+func TestLogReader_Query_SkipsFilesOutOfRange(t *testing.T) {
+	reader, day1 := newQueryLog(t)
+	// A rotated file dated long before the range is not read, even if it
+	// holds (misplaced) entries within it
+	writeLogFile(t, reader.dir, "consultations-2025-01-01.jsonl", logAt(day1.AddDate(0, 0, 2), "misplaced", "MASTERY", 50))
+
+	result, err := reader.Query(Query{Since: day1.AddDate(0, 0, 1)})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if strings.Contains(quotes(result.Entries), "misplaced") {
+		t.Errorf("Query() read a file out of range: %s", quotes(result.Entries))
+	}
+	if all, _ := reader.Query(Query{}); all.Total != 6 {
+		t.Errorf("Query() without a time range = %d entries, want 6", all.Total)
+	}
+}
+
+func TestLogReader_Query_MissingDir(t *testing.T) {
+	result, err := NewLogReader(filepath.Join(t.TempDir(), "missing")).Query(Query{})
+	if err != nil || result.Total != 0 || result.Entries == nil {
+		t.Errorf("Query() = %+v, %v; want an empty result", result, err)
+	}
+}
@@ -0,0 +1,298 @@
+package logging
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// Retention configures the maintenance of rotated consultation log files.
+// The zero value keeps all files as they are. The current consultations.jsonl
+// is never changed, and the most recently rotated file is not compressed or
+// compacted (only deleted by the limits), so readers following the log can
+// finish it.
+type Retention struct {
+	MaxAge         time.Duration // Delete files whose log days all ended longer ago; 0 keeps all
+	MaxTotalSize   int64         // Delete the oldest files while all log files together are larger (bytes); 0 for no limit
+	Compress       bool          // Gzip rotated files (consultations-*.jsonl.gz)
+	CompactMonthly bool          // Merge the daily files of past months into one consultations-YYYY-MM.jsonl file per month
+}
+
+// RetentionFromConfig returns the retention set by the log_* settings of cfg.
+func RetentionFromConfig(cfg *config.Config) Retention {
+	return Retention{
+		MaxAge:         time.Duration(cfg.LogRetentionDays) * 24 * time.Hour,
+		MaxTotalSize:   int64(cfg.LogMaxSizeMB) << 20,
+		Compress:       cfg.LogCompress,
+		CompactMonthly: cfg.LogCompactMonthly,
+	}
+}
+
+// MaintenanceReport lists the files changed by Maintain.
+type MaintenanceReport struct {
+	Compacted  []string `json:"compacted,omitempty"`  // Monthly files written
+	Compressed []string `json:"compressed,omitempty"` // Files gzipped
+	Deleted    []string `json:"deleted,omitempty"`    // Files removed by retention
+	Indexed    int      `json:"indexed"`              // Files in the index
+}
+
+// Maintain applies a retention to the rotated log files in dir at time now,
+// then updates the directory's index of the time range of each file, which
+// lets queries skip files. Compaction runs first, then compression, then
+// deletion. Days and months are those of the day boundary.
+func Maintain(dir string, retention Retention, day wisdom.DayBoundary, now time.Time) (*MaintenanceReport, error) {
+	report := &MaintenanceReport{}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return report, nil // Nothing logged yet
+	}
+	files, err := listLogFiles(dir, day)
+	if err != nil {
+		return nil, err
+	}
+
+	// The most recently rotated file may still be read by a follower
+	var latest time.Time
+	for _, file := range files {
+		if !file.monthly && file.start.After(latest) {
+			latest = file.start
+		}
+	}
+	isLatest := func(file logFile) bool { return !file.monthly && file.start.Equal(latest) }
+
+	if retention.CompactMonthly {
+		currentMonth := day.Date(now).Format("2006-01")
+		months := make(map[string][]logFile)
+		for _, file := range files {
+			if month := file.start.Format("2006-01"); month < currentMonth && !isLatest(file) {
+				months[month] = append(months[month], file)
+			}
+		}
+		for month, monthFiles := range months {
+			if len(monthFiles) == 1 && monthFiles[0].monthly {
+				continue // Already compacted
+			}
+			name, err := compactMonth(dir, month, monthFiles, retention.Compress)
+			if err != nil {
+				return report, err
+			}
+			report.Compacted = append(report.Compacted, name)
+		}
+		sort.Strings(report.Compacted)
+		if len(report.Compacted) > 0 {
+			if files, err = listLogFiles(dir, day); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	if retention.Compress {
+		for _, file := range files {
+			if file.compressed || isLatest(file) {
+				continue
+			}
+			name, err := compressFile(dir, file)
+			if err != nil {
+				return report, err
+			}
+			report.Compressed = append(report.Compressed, name)
+		}
+		if len(report.Compressed) > 0 {
+			if files, err = listLogFiles(dir, day); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	var kept []logFile
+	total := int64(0)
+	if info, err := os.Stat(filepath.Join(dir, currentLogName)); err == nil {
+		total = info.Size()
+	}
+	for _, file := range files {
+		total += file.size
+	}
+	cutoff := now.Add(-retention.MaxAge)
+	for _, file := range files { // Oldest first
+		expired := retention.MaxAge > 0 && !file.end.After(cutoff)
+		oversized := retention.MaxTotalSize > 0 && total > retention.MaxTotalSize
+		if !expired && !oversized {
+			kept = append(kept, file)
+			continue
+		}
+		if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+			return report, fmt.Errorf("failed to delete log file %q: %w", file.path, err)
+		}
+		report.Deleted = append(report.Deleted, file.name)
+		total -= file.size
+	}
+
+	// Re-index the files that changed; unchanged entries are kept
+	previous := readIndex(dir)
+	index := logIndex{Files: make(map[string]indexEntry, len(kept))}
+	for _, file := range kept {
+		entry, ok := previous.lookup(file)
+		if !ok {
+			if entry, err = indexFile(file); err != nil {
+				return report, err
+			}
+		}
+		index.Files[file.name] = entry
+	}
+	if err := index.write(dir); err != nil {
+		return report, err
+	}
+	report.Indexed = len(index.Files)
+	return report, nil
+}
+
+// compactMonth merges the files of a month, which may include an earlier
+// monthly file, into one monthly file sorted by timestamp, and returns its
+// name. The merged files are deleted once the monthly file is written.
+func compactMonth(dir, month string, files []logFile, compress bool) (string, error) {
+	type line struct {
+		data []byte
+		time time.Time
+	}
+	var lines []line
+	for _, file := range files {
+		reader, err := openLogFile(file.path)
+		if err != nil {
+			return "", fmt.Errorf("failed to open log file %q: %w", file.path, err)
+		}
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			data := append([]byte(nil), scanner.Bytes()...)
+			if len(data) == 0 {
+				continue
+			}
+			// Lines without a valid timestamp are kept, first
+			var entry struct {
+				Timestamp string `json:"timestamp"`
+			}
+			var t time.Time
+			if json.Unmarshal(data, &entry) == nil {
+				t, _ = time.Parse(time.RFC3339, entry.Timestamp)
+			}
+			lines = append(lines, line{data: data, time: t})
+		}
+		err = scanner.Err()
+		reader.Close()
+		if err != nil {
+			return "", fmt.Errorf("error reading log file %s: %w", file.path, err)
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].time.Before(lines[j].time) })
+
+	name := rotatedName(month, compress)
+	err := writeFileAtomic(filepath.Join(dir, name), func(w io.Writer) error {
+		return writeLogData(w, compress, func(w io.Writer) error {
+			for _, l := range lines {
+				if _, err := w.Write(append(l.data, '\n')); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range files {
+		if file.name == name {
+			continue // Replaced
+		}
+		if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to delete compacted log file %q: %w", file.path, err)
+		}
+	}
+	return name, nil
+}
+
+// compressFile gzips a plain log file, deletes it, and returns the name of
+// the compressed file. If the period already has a compressed file, the plain
+// file's lines are merged into it; lines it already holds (from an interrupted
+// compression) are not repeated.
+func compressFile(dir string, file logFile) (string, error) {
+	name := rotatedName(file.period(), true)
+	path := filepath.Join(dir, name)
+
+	archived, err := readLogLines(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read compressed log file %q: %w", path, err)
+	}
+	plain, err := readLogLines(file.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log file %q: %w", file.path, err)
+	}
+
+	seen := make(map[string]bool, len(archived))
+	lines := archived
+	for _, line := range archived {
+		seen[string(line)] = true
+	}
+	for _, line := range plain {
+		if !seen[string(line)] {
+			lines = append(lines, line)
+		}
+	}
+
+	err = writeFileAtomic(path, func(w io.Writer) error {
+		return writeLogData(w, true, func(w io.Writer) error {
+			for _, line := range lines {
+				if _, err := w.Write(append(line, '\n')); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to delete compressed log file %q: %w", file.path, err)
+	}
+	return name, nil
+}
+
+// readLogLines returns the non-empty lines of a plain or gzipped log file.
+func readLogLines(path string) ([][]byte, error) {
+	reader, err := openLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// writeLogData writes log data to w with write, gzipped if compress is set.
+func writeLogData(w io.Writer, compress bool, write func(io.Writer) error) error {
+	if !compress {
+		return write(w)
+	}
+	gz := gzip.NewWriter(w)
+	if err := write(gz); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,259 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// dirNames returns the names of the files in dir, sorted.
+func dirNames(t *testing.T, dir string) string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+// newRotatedLog writes a daily file for each date in dir, plus the current file.
+func newRotatedLog(t *testing.T, dates ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, date := range dates {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeLogFile(t, dir, rotatedName(date, false), logAt(day.Add(9*time.Hour), "stoic", "BUILDING", 50), logAt(day.Add(8*time.Hour), "tao", "CHAOS", 30))
+	}
+	writeLogFile(t, dir, currentLogName, logAt(time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC), "stoic", "MASTERY", 90))
+	return dir
+}
+
+var utcDays = wisdom.DayBoundary{Location: time.UTC}
+
+func TestMaintain_CompactAndCompress(t *testing.T) {
+	dir := newRotatedLog(t, "2026-02-27", "2026-02-28", "2026-03-01", "2026-03-31", "2026-04-08", "2026-04-09")
+	now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	before, err := NewLogReader(dir).WithDayBoundary(utcDays).Query(Query{Order: OrderOldest})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	report, err := Maintain(dir, Retention{Compress: true, CompactMonthly: true}, utcDays, now)
+	if err != nil {
+		t.Fatalf("Maintain failed: %v", err)
+	}
+	want := "consultations-2026-02.jsonl.gz consultations-2026-03.jsonl.gz consultations-2026-04-08.jsonl.gz consultations-2026-04-09.jsonl consultations.index.json consultations.jsonl"
+	if got := dirNames(t, dir); got != want {
+		t.Errorf("files = %s\nwant %s", got, want)
+	}
+	if strings.Join(report.Compacted, " ") != "consultations-2026-02.jsonl.gz consultations-2026-03.jsonl.gz" ||
+		strings.Join(report.Compressed, " ") != "consultations-2026-04-08.jsonl.gz" || report.Indexed != 4 {
+		t.Errorf("report = %+v", report)
+	}
+
+	// Compaction sorts a month's entries by time and loses none
+	after, err := NewLogReader(dir).WithDayBoundary(utcDays).Query(Query{Order: OrderOldest})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if quotes(after.Entries) != quotes(before.Entries) {
+		t.Errorf("entries after maintenance = %s\nwant %s", quotes(after.Entries), quotes(before.Entries))
+	}
+	february, err := readLogFile(filepath.Join(dir, "consultations-2026-02.jsonl.gz"))
+	if err != nil || len(february) != 4 || !strings.HasPrefix(february[0].Quote, "tao at 2026-02-27") {
+		t.Errorf("compacted February = %s, %v", quotes(february), err)
+	}
+
+	// A file rotated later into an already compacted month is merged
+	writeLogFile(t, dir, "consultations-2026-03-15.jsonl", logAt(time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC), "late", "BUILDING", 40))
+	report, err = Maintain(dir, Retention{Compress: true, CompactMonthly: true}, utcDays, now)
+	if err != nil {
+		t.Fatalf("Maintain failed: %v", err)
+	}
+	march, err := readLogFile(filepath.Join(dir, "consultations-2026-03.jsonl.gz"))
+	if err != nil || len(march) != 5 || march[2].Advisor != "late" || strings.Join(report.Compacted, " ") != "consultations-2026-03.jsonl.gz" {
+		t.Errorf("compacted March = %s, %v; report %+v", quotes(march), err, report)
+	}
+
+	// Nothing left to do
+	if report, err = Maintain(dir, Retention{Compress: true, CompactMonthly: true}, utcDays, now); err != nil || len(report.Compacted)+len(report.Compressed)+len(report.Deleted) != 0 {
+		t.Errorf("second Maintain = %+v, %v; want no changes", report, err)
+	}
+}
+
+func TestMaintain_CompressMergesArchive(t *testing.T) {
+	dir := newRotatedLog(t, "2026-04-08", "2026-04-09")
+	now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 4, 8, 0, 0, 0, 0, time.UTC)
+
+	// The archive holds an entry of its own and one also in the plain file
+	var archive bytes.Buffer
+	err := writeLogData(&archive, true, func(w io.Writer) error {
+		for _, c := range []*wisdom.Consultation{logAt(day.Add(7*time.Hour), "archived", "BUILDING", 60), logAt(day.Add(8*time.Hour), "tao", "CHAOS", 30)} {
+			data, err := json.Marshal(c)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(append(data, '\n')); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "consultations-2026-04-08.jsonl.gz"), archive.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Maintain(dir, Retention{Compress: true}, utcDays, now)
+	if err != nil {
+		t.Fatalf("Maintain failed: %v", err)
+	}
+	if strings.Join(report.Compressed, " ") != "consultations-2026-04-08.jsonl.gz" {
+		t.Errorf("compressed = %v", report.Compressed)
+	}
+	merged, err := readLogFile(filepath.Join(dir, "consultations-2026-04-08.jsonl.gz"))
+	want := "archived at 2026-04-08T07:00:00Z, tao at 2026-04-08T08:00:00Z, stoic at 2026-04-08T09:00:00Z"
+	if err != nil || quotes(merged) != want {
+		t.Errorf("merged archive = %s, %v\nwant %s", quotes(merged), err, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "consultations-2026-04-08.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("plain file still exists: %v", err)
+	}
+}
+
+func TestMaintain_Retention(t *testing.T) {
+	now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+
+	dir := newRotatedLog(t, "2026-03-01", "2026-04-01", "2026-04-08", "2026-04-09")
+	report, err := Maintain(dir, Retention{MaxAge: 8 * 24 * time.Hour}, utcDays, now)
+	if err != nil {
+		t.Fatalf("Maintain failed: %v", err)
+	}
+	if strings.Join(report.Deleted, " ") != "consultations-2026-03-01.jsonl consultations-2026-04-01.jsonl" {
+		t.Errorf("deleted by age = %v", report.Deleted)
+	}
+
+	dir = newRotatedLog(t, "2026-04-07", "2026-04-08", "2026-04-09")
+	info, err := os.Stat(filepath.Join(dir, "consultations-2026-04-08.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Room for the current file and the two newest rotated files
+	current, _ := os.Stat(filepath.Join(dir, currentLogName))
+	report, err = Maintain(dir, Retention{MaxTotalSize: current.Size() + 2*info.Size()}, utcDays, now)
+	if err != nil {
+		t.Fatalf("Maintain failed: %v", err)
+	}
+	if strings.Join(report.Deleted, " ") != "consultations-2026-04-07.jsonl" || report.Indexed != 2 {
+		t.Errorf("deleted by size = %v (report %+v)", report.Deleted, report)
+	}
+	if _, ok := readIndex(dir).Files["consultations-2026-04-07.jsonl"]; ok {
+		t.Error("deleted file still in the index")
+	}
+
+	// The current file is never deleted
+	report, err = Maintain(dir, Retention{MaxTotalSize: 1, MaxAge: time.Hour}, utcDays, now)
+	if err != nil {
+		t.Fatalf("Maintain failed: %v", err)
+	}
+	if got := dirNames(t, dir); got != "consultations.index.json consultations.jsonl" {
+		t.Errorf("files = %s, want only the current file and the index", got)
+	}
+}
+
+func TestConsultationLogger_MaintainsAfterRotation(t *testing.T) {
+	dir := newRotatedLog(t, "2026-02-27")
+	if err := os.Remove(filepath.Join(dir, currentLogName)); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)
+	logger, err := NewConsultationLogger(dir)
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer logger.Close()
+	logger.WithClock(wisdom.ClockFunc(func() time.Time { return now }), utcDays).
+		WithRetention(Retention{Compress: true})
+
+	if err := logger.Log(logAt(now, "stoic", "BUILDING", 50)); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if got := dirNames(t, dir); strings.Contains(got, ".gz") {
+		t.Fatalf("maintained before a rotation: %s", got)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if err := logger.Log(logAt(now, "stoic", "BUILDING", 50)); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	// The file just rotated stays plain for readers following the log
+	want := "consultations-2026-02-27.jsonl.gz consultations-2026-03-31.jsonl consultations.index.json consultations.jsonl"
+	if got := dirNames(t, dir); got != want {
+		t.Errorf("files after rotation = %s\nwant %s", got, want)
+	}
+	if logs, err := logger.GetLogs(60); err != nil || len(logs) != 4 {
+		t.Errorf("GetLogs() = %d entries, %v; want 4", len(logs), err)
+	}
+}
+
+func TestConsultationLogger_MaintenanceFailureAfterRotation(t *testing.T) {
+	dir := t.TempDir()
+	// The index cannot be replaced by a file, so maintenance fails
+	if err := os.MkdirAll(filepath.Join(dir, indexName, "blocked"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)
+	logger, err := NewConsultationLogger(dir)
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer logger.Close()
+	var warnings bytes.Buffer
+	appLogger := NewLogger()
+	appLogger.output = &warnings
+	logger.WithClock(wisdom.ClockFunc(func() time.Time { return now }), utcDays).WithLogger(appLogger)
+
+	if err := logger.Log(logAt(now, "stoic", "BUILDING", 50)); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	now = now.Add(2 * time.Hour)
+	// The consultation is written, so Log succeeds and only warns
+	if err := logger.Log(logAt(now, "tao", "BUILDING", 50)); err != nil {
+		t.Errorf("Log after a rotation = %v, want nil despite the maintenance failure", err)
+	}
+	if !strings.Contains(warnings.String(), "[WARN]") || !strings.Contains(warnings.String(), "maintenance failed") {
+		t.Errorf("warnings = %q, want the maintenance failure", warnings.String())
+	}
+	if logs, err := logger.GetLogs(60); err != nil || len(logs) != 2 {
+		t.Errorf("GetLogs() = %d entries, %v; want 2", len(logs), err)
+	}
+}
+
+func TestRetentionFromConfig(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.LogRetentionDays, cfg.LogMaxSizeMB, cfg.LogCompress, cfg.LogCompactMonthly = 90, 5, true, true
+	want := Retention{MaxAge: 90 * 24 * time.Hour, MaxTotalSize: 5 << 20, Compress: true, CompactMonthly: true}
+	if got := RetentionFromConfig(cfg); got != want {
+		t.Errorf("RetentionFromConfig() = %+v, want %+v", got, want)
+	}
+}
@@ -3,10 +3,15 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/davidl71/devwisdom-go/internal/health"
 	"github.com/davidl71/devwisdom-go/internal/logging"
 	"github.com/davidl71/devwisdom-go/internal/wisdom"
 )
@@ -39,14 +44,26 @@ func (h *WisdomHandlers) HandleToolCall(name string, params map[string]interface
 		return h.handleGetDailyBriefing(params)
 	case "get_consultation_log":
 		return h.handleGetConsultationLog(params)
+	case "search_quotes":
+		return h.handleSearchQuotes(params)
 	default:
-		availableTools := []string{"consult_advisor", "get_wisdom", "get_daily_briefing", "get_consultation_log"}
+		availableTools := []string{"consult_advisor", "get_wisdom", "get_daily_briefing", "get_consultation_log", "search_quotes"}
 		return nil, fmt.Errorf("unknown tool %q (available tools: %v). Check tool name spelling", name, availableTools)
 	}
 }
 
+// disabledResult is returned by wisdom tools when wisdom output is disabled by configuration
+// (EXARP_DISABLE_WISDOM=1 or a .exarp_no_wisdom marker file).
+func disabledResult() map[string]interface{} {
+	return map[string]interface{}{"disabled": true}
+}
+
 // handleConsultAdvisor implements consult_advisor tool
 func (h *WisdomHandlers) handleConsultAdvisor(params map[string]interface{}) (interface{}, error) {
+	if h.wisdom.IsDisabled() {
+		return disabledResult(), nil
+	}
+
 	// Extract parameters
 	var metric, tool, stage, context string
 	var score float64
@@ -68,6 +85,12 @@ func (h *WisdomHandlers) handleConsultAdvisor(params map[string]interface{}) (in
 	} else if sc, ok := params["score"].(int); ok {
 		score = float64(sc)
 	}
+	if useHealth, _ := params["health"].(bool); useHealth {
+		var err error
+		if score, err = healthScore(metric); err != nil {
+			return nil, err
+		}
+	}
 	// Validate and clamp score to 0-100 range
 	if score < 0 {
 		score = 0
@@ -103,8 +126,12 @@ func (h *WisdomHandlers) handleConsultAdvisor(params map[string]interface{}) (in
 		}
 	}
 
-	// Get wisdom quote
+	// Get wisdom quote, falling back to the configured default source if the
+	// advisor's source is unavailable (e.g. excluded by Hebrew settings)
 	quote, err := h.wisdom.GetWisdom(score, advisorInfo.Advisor)
+	if err != nil {
+		quote, err = h.wisdom.GetWisdom(score, "")
+	}
 	if err != nil {
 		// Fallback quote
 		quote = &wisdom.Quote{
@@ -119,18 +146,23 @@ func (h *WisdomHandlers) handleConsultAdvisor(params map[string]interface{}) (in
 
 	// Create consultation
 	consultation := wisdom.Consultation{
-		Timestamp:        time.Now().Format(time.RFC3339),
+		Timestamp:        h.wisdom.Now().Format(time.RFC3339),
 		ConsultationType: "advisor",
 		Advisor:          advisorInfo.Advisor,
 		AdvisorIcon:      advisorInfo.Icon,
 		AdvisorName:      advisorInfo.Advisor,
 		Rationale:        advisorInfo.Rationale,
+		Metric:           metric,
+		Tool:             tool,
+		Stage:            stage,
 		ScoreAtTime:      score,
 		ConsultationMode: modeConfig.Name,
 		ModeIcon:         modeConfig.Icon,
 		ModeFrequency:    modeConfig.Frequency,
 		ModeGuidance:     modeConfig.Description,
+		QuoteID:          quote.ID,
 		Quote:            quote.Quote,
+		QuoteTranslation: quote.Translation,
 		QuoteSource:      quote.Source,
 		Encouragement:    quote.Encouragement,
 		Context:          context,
@@ -149,16 +181,25 @@ func (h *WisdomHandlers) handleConsultAdvisor(params map[string]interface{}) (in
 
 // handleGetWisdom implements get_wisdom tool
 func (h *WisdomHandlers) handleGetWisdom(params map[string]interface{}) (interface{}, error) {
+	if h.wisdom.IsDisabled() {
+		return disabledResult(), nil
+	}
+
 	var score float64
 	var source string
 
-	// Score is required
-	if sc, ok := params["score"].(float64); ok {
+	// Score is required unless computed from the project's health
+	if useHealth, _ := params["health"].(bool); useHealth {
+		var err error
+		if score, err = healthScore(""); err != nil {
+			return nil, err
+		}
+	} else if sc, ok := params["score"].(float64); ok {
 		score = sc
 	} else if sc, ok := params["score"].(int); ok {
 		score = float64(sc)
 	} else {
-		return nil, fmt.Errorf("score parameter is required and must be a number between 0-100")
+		return nil, fmt.Errorf("score parameter is required and must be a number between 0-100 (or set health)")
 	}
 
 	// Validate and clamp score
@@ -168,7 +209,7 @@ func (h *WisdomHandlers) handleGetWisdom(params map[string]interface{}) (interfa
 		score = 100
 	}
 
-	// Source is optional
+	// Source is optional (empty uses the configured default source)
 	if s, ok := params["source"].(string); ok {
 		source = s
 	}
@@ -182,10 +223,22 @@ func (h *WisdomHandlers) handleGetWisdom(params map[string]interface{}) (interfa
 	return quote, nil
 }
 
-// handleGetDailyBriefing implements get_daily_briefing tool
+// handleGetDailyBriefing implements get_daily_briefing tool.
+// Metric scores come from "scores" (an object of metric scores or a scorecard);
+// without it, the health of the server's working directory is analyzed, which
+// runs git there. Briefings are not logged, so trends compare against earlier
+// advisor consultations.
 func (h *WisdomHandlers) handleGetDailyBriefing(params map[string]interface{}) (interface{}, error) {
-	var score float64
+	if h.wisdom.IsDisabled() {
+		return disabledResult(), nil
+	}
+
+	card, scoresFrom, err := briefingScorecard(params)
+	if err != nil {
+		return nil, err
+	}
 
+	score := card.OverallScore()
 	if sc, ok := params["score"].(float64); ok {
 		score = sc
 	} else if sc, ok := params["score"].(int); ok {
@@ -199,13 +252,47 @@ func (h *WisdomHandlers) handleGetDailyBriefing(params map[string]interface{}) (
 		score = 100
 	}
 
-	// Get multiple quotes from different sources
+	limit := wisdom.DefaultBriefingLimit
+	if l, ok := params["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+	} else if l, ok := params["limit"].(int); ok && l > 0 {
+		limit = l
+	}
+	days := 7
+	if d, ok := params["days"].(float64); ok && d > 0 {
+		days = int(d)
+	} else if d, ok := params["days"].(int); ok && d > 0 {
+		days = d
+	}
+
+	// Trends compare against previous consultations
+	var history []*wisdom.Consultation
+	if h.logger != nil {
+		if history, err = h.logger.GetLogs(days); err != nil {
+			h.appLogger.Warn("", "Failed to read consultation log: %v", err)
+		}
+	}
+
+	advisoryQuotes := h.wisdom.BuildBriefing(card.Metrics, limit, history)
+	now := h.wisdom.Now()
+
+	mode := wisdom.GetConsultationMode(score)
 	sources := h.wisdom.ListSources()
 	briefing := map[string]interface{}{
-		"date":    time.Now().Format("2006-01-02"),
-		"score":   score,
-		"quotes":  []interface{}{},
-		"sources": sources,
+		"date":  h.wisdom.DayBoundary().Date(now).Format("2006-01-02"),
+		"score": score,
+		"days":  days,
+		"consultation_mode": map[string]interface{}{
+			"name":        mode.Name,
+			"icon":        mode.Icon,
+			"frequency":   mode.Frequency,
+			"description": mode.Description,
+		},
+		"metric_scores":   card.Metrics,
+		"scores_from":     scoresFrom,
+		"advisory_quotes": advisoryQuotes,
+		"quotes":          []interface{}{},
+		"sources":         sources,
 	}
 
 	// Get quotes from a few sources
@@ -229,31 +316,356 @@ func (h *WisdomHandlers) handleGetDailyBriefing(params map[string]interface{}) (
 	return briefing, nil
 }
 
-// handleGetConsultationLog implements get_consultation_log tool
+// briefingScorecard resolves the briefing's metric scores from the "scores"
+// parameter, falling back to the health of the working directory.
+// It also returns a description of where the scores came from.
+// Scorecard files are not read: MCP clients must not pick server-side paths.
+func briefingScorecard(params map[string]interface{}) (*wisdom.Scorecard, string, error) {
+	if _, ok := params["scores_file"]; ok {
+		return nil, "", fmt.Errorf("scores_file is not supported; pass the scorecard contents as scores")
+	}
+
+	if scores, ok := params["scores"]; ok && scores != nil {
+		data, err := json.Marshal(scores)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid scores parameter: %w", err)
+		}
+		card, err := wisdom.ParseScorecard(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid scores parameter: %w", err)
+		}
+		return card, "scores parameter", nil
+	}
+
+	report, err := health.NewAnalyzer(".").Analyze(context.Background())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to analyze project health (pass scores instead): %w", err)
+	}
+	card := &wisdom.Scorecard{
+		Metrics:    report.MetricScores(),
+		Overall:    report.Score,
+		HasOverall: true,
+	}
+	return card, "health of " + report.Root, nil
+}
+
+// healthScore analyzes the health of the server's working directory, which
+// runs git there, and returns the score for metric, or the overall score if
+// metric is empty or not measured.
+func healthScore(metric string) (float64, error) {
+	report, err := health.NewAnalyzer(".").Analyze(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("failed to analyze project health: %w", err)
+	}
+	if m, ok := report.Metric(metric); ok {
+		return m.Score, nil
+	}
+	return report.Score, nil
+}
+
+// dailyBriefingInputSchema returns the input schema of the get_daily_briefing tool.
+func dailyBriefingInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"score": map[string]interface{}{
+				"type":        "number",
+				"description": "Overall project score (0-100) for the consultation mode; defaults to the scorecard's overall score or the mean of the metric scores",
+			},
+			"scores": map[string]interface{}{
+				"type":        "object",
+				"description": "Metric scores (0-100) keyed by metric name (e.g., {\"security\": 40}), or a scorecard object; without it, the health of the server's working directory is analyzed (runs git)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Number of weakest metrics to include (default: 3)",
+			},
+			"days": map[string]interface{}{
+				"type":        "number",
+				"description": "Days of consultation history to compare against for trends (default: 7)",
+			},
+		},
+	}
+}
+
+// handleGetConsultationLog implements get_consultation_log tool.
+// Called with no arguments but days, it returns the array of entries, as
+// before the query parameters existed; otherwise a query result.
 func (h *WisdomHandlers) handleGetConsultationLog(params map[string]interface{}) (interface{}, error) {
-	days := 7 // default
+	result, err := h.queryConsultations(params)
+	if err != nil {
+		return nil, err
+	}
+	for key := range params {
+		if key != "days" {
+			return result, nil
+		}
+	}
+	return result.Entries, nil
+}
 
-	if d, ok := params["days"].(float64); ok {
-		days = int(d)
-	} else if d, ok := params["days"].(int); ok {
+// queryConsultations runs a consultation log query built from tool arguments
+// or resource query parameters (see consultationLogInputSchema).
+func (h *WisdomHandlers) queryConsultations(params map[string]interface{}) (*logging.QueryResult, error) {
+	query, err := consultationQuery(params, h.wisdom.Now())
+	if err != nil {
+		return nil, err
+	}
+	if h.logger == nil {
+		// Without a log there is nothing to query
+		return &logging.QueryResult{Entries: []*wisdom.Consultation{}}, nil
+	}
+	result, err := h.logger.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query consultation log: %w", err)
+	}
+	return result, nil
+}
+
+// consultationQuery builds a log query from parameters given as JSON values
+// (tool arguments) or strings (resource query parameters). Unless since is
+// set, entries of the last days (default: 7) are selected.
+func consultationQuery(params map[string]interface{}, now time.Time) (logging.Query, error) {
+	var query logging.Query
+	for key, field := range map[string]*string{
+		"advisor": &query.Advisor,
+		"metric":  &query.Metric,
+		"tool":    &query.Tool,
+		"stage":   &query.Stage,
+		"mode":    &query.Mode,
+		"context": &query.Context,
+		"order":   &query.Order,
+		"cursor":  &query.Cursor,
+	} {
+		*field, _ = params[key].(string)
+	}
+
+	days := 7.0
+	if d, ok, err := numberParam(params, "days"); err != nil {
+		return query, err
+	} else if ok {
 		days = d
 	}
+	if limit, ok, err := numberParam(params, "limit"); err != nil {
+		return query, err
+	} else if ok {
+		query.Limit = int(limit)
+	}
+	for key, field := range map[string]**float64{"min_score": &query.MinScore, "max_score": &query.MaxScore} {
+		if score, ok, err := numberParam(params, key); err != nil {
+			return query, err
+		} else if ok {
+			*field = &score
+		}
+	}
 
-	// Retrieve consultations from logger
-	var consultations []interface{}
-	if h.logger != nil {
-		logs, err := h.logger.GetLogs(days)
-		if err == nil {
-			// Convert to interface{} slice for JSON serialization
-			consultations = make([]interface{}, len(logs))
-			for i, consultation := range logs {
-				consultations[i] = consultation
+	for key, field := range map[string]*time.Time{"since": &query.Since, "until": &query.Until} {
+		value, _ := params[key].(string)
+		if value == "" {
+			continue
+		}
+		t, err := parseQueryTime(value, now.Location())
+		if err != nil {
+			return query, fmt.Errorf("invalid %s %q: use an RFC 3339 timestamp or a YYYY-MM-DD date", key, value)
+		}
+		*field = t
+	}
+	if query.Since.IsZero() {
+		query.Since = now.Add(-time.Duration(days * float64(24*time.Hour)))
+	}
+
+	switch aggregate := params["aggregate"].(type) {
+	case string:
+		for _, name := range strings.Split(aggregate, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				query.Aggregate = append(query.Aggregate, name)
 			}
 		}
-		// If logger is nil or error occurs, consultations remains empty array
+	case []interface{}:
+		for _, name := range aggregate {
+			if name, ok := name.(string); ok {
+				query.Aggregate = append(query.Aggregate, name)
+			}
+		}
+	}
+	return query, nil
+}
+
+// numberParam returns a numeric parameter given as a number or a string.
+// The second return value is false if the parameter is absent.
+func numberParam(params map[string]interface{}, key string) (float64, bool, error) {
+	switch value := params[key].(type) {
+	case float64:
+		return value, true, nil
+	case int:
+		return float64(value), true, nil
+	case string:
+		if value == "" {
+			return 0, false, nil
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s %q: must be a number", key, value)
+		}
+		return n, true, nil
+	}
+	return 0, false, nil
+}
+
+// parseQueryTime parses an RFC 3339 timestamp or a YYYY-MM-DD date (the
+// start of that day in loc).
+func parseQueryTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, loc)
+}
+
+// consultationsURITemplate is the URI template of the consultation log
+// resource. Query parameters are optional and may be given in any order.
+const consultationsURITemplate = "wisdom://consultations/{days}{?advisor,metric,tool,stage,mode,min_score,max_score,since,until,context,order,limit,cursor,aggregate}"
+
+// consultationsResourceDescription describes the consultation log resource.
+const consultationsResourceDescription = "Get consultation log entries for the specified number of days, as an array; " +
+	"with query parameters (those of get_consultation_log), a query result"
+
+// consultationLogDescription describes the get_consultation_log tool.
+const consultationLogDescription = "Query consultation log entries by advisor, metric, tool, stage, mode, score range, time range or context, " +
+	"with sort order, cursor pagination and aggregations. Returns {entries, total, next_cursor, aggregations}, " +
+	"or the array of entries when called with no arguments but days"
+
+// consultationLogInputSchema returns the input schema of the get_consultation_log tool.
+func consultationLogInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"days": map[string]interface{}{
+				"type":        "number",
+				"description": "Number of days to retrieve (default: 7); ignored if since is set",
+			},
+			"advisor": map[string]interface{}{
+				"type":        "string",
+				"description": "Only consultations of this advisor (e.g., 'stoic')",
+			},
+			"metric": map[string]interface{}{
+				"type":        "string",
+				"description": "Only consultations for this metric (e.g., 'security')",
+			},
+			"tool": map[string]interface{}{
+				"type":        "string",
+				"description": "Only consultations for this tool",
+			},
+			"stage": map[string]interface{}{
+				"type":        "string",
+				"description": "Only consultations for this stage",
+			},
+			"mode": map[string]interface{}{
+				"type":        "string",
+				"description": "Only consultations in this mode (e.g., 'chaos', 'mastery')",
+			},
+			"min_score": map[string]interface{}{
+				"type":        "number",
+				"description": "Lowest score at the time of the consultation",
+			},
+			"max_score": map[string]interface{}{
+				"type":        "number",
+				"description": "Highest score at the time of the consultation",
+			},
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "Earliest time, inclusive (RFC 3339 timestamp or YYYY-MM-DD)",
+			},
+			"until": map[string]interface{}{
+				"type":        "string",
+				"description": "Latest time, exclusive (RFC 3339 timestamp or YYYY-MM-DD)",
+			},
+			"context": map[string]interface{}{
+				"type":        "string",
+				"description": "Only consultations whose context contains this text (case-insensitive)",
+			},
+			"order": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{logging.OrderNewest, logging.OrderOldest},
+				"description": "Sort order (default: newest)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum entries to return; pass next_cursor as cursor for the next page",
+			},
+			"cursor": map[string]interface{}{
+				"type":        "string",
+				"description": "next_cursor of the previous page",
+			},
+			"aggregate": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string", "enum": logging.Aggregates},
+				"description": "Aggregations over all matching entries: advisors (counts per advisor), daily_scores (mean score per day), mode_transitions",
+			},
+		},
+	}
+}
+
+// handleSearchQuotes implements search_quotes tool
+func (h *WisdomHandlers) handleSearchQuotes(params map[string]interface{}) (interface{}, error) {
+	if h.wisdom.IsDisabled() {
+		return disabledResult(), nil
+	}
+
+	query, _ := params["query"].(string)
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query parameter is required (e.g., 'courage', 'learn*', or '\"the way\"')")
+	}
+
+	var opts wisdom.SearchOptions
+	opts.Source, _ = params["source"].(string)
+	opts.AeonLevel, _ = params["aeon_level"].(string)
+	opts.Language, _ = params["language"].(string)
+	if l, ok := params["limit"].(float64); ok {
+		opts.Limit = int(l)
+	} else if l, ok := params["limit"].(int); ok {
+		opts.Limit = l
+	}
+
+	results, err := h.wisdom.Search(query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search quotes: %w", err)
 	}
 
-	return consultations, nil
+	return map[string]interface{}{
+		"query":   query,
+		"count":   len(results),
+		"results": results,
+	}, nil
+}
+
+// searchQuotesInputSchema returns the input schema of the search_quotes tool.
+func searchQuotesInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "Search terms; all must match. Use 'term*' for prefixes and double quotes for phrases",
+			},
+			"source": map[string]interface{}{
+				"type":        "string",
+				"description": "Only search this wisdom source ID (e.g., 'stoic')",
+			},
+			"aeon_level": map[string]interface{}{
+				"type":        "string",
+				"description": "Only search this aeon level (chaos, lower_aeons, middle_aeons, upper_aeons, treasury)",
+			},
+			"language": map[string]interface{}{
+				"type":        "string",
+				"description": "Only search sources in this language (e.g., 'english', 'hebrew')",
+			},
+			"limit": map[string]interface{}{
+				"type":        "number",
+				"description": "Maximum number of results (default: 10)",
+			},
+		},
+		"required": []string{"query"},
+	}
 }
 
 // HandleToolsResource handles wisdom://tools resource
@@ -281,6 +693,10 @@ func (h *WisdomHandlers) HandleToolsResource(req *JSONRPCRequest) *JSONRPCRespon
 						"type":        "number",
 						"description": "Project health score (0-100)",
 					},
+					"health": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Compute the score from the health of the server's working directory (runs git; overrides score); uses the metric's own score when it is measured",
+					},
 					"context": map[string]interface{}{
 						"type":        "string",
 						"description": "Additional context for the consultation",
@@ -296,42 +712,33 @@ func (h *WisdomHandlers) HandleToolsResource(req *JSONRPCRequest) *JSONRPCRespon
 				"properties": map[string]interface{}{
 					"score": map[string]interface{}{
 						"type":        "number",
-						"description": "Project health score (0-100)",
-						"required":    true,
+						"description": "Project health score (0-100); required unless health is set",
 					},
 					"source": map[string]interface{}{
 						"type":        "string",
-						"description": "Wisdom source ID (e.g., 'pistis_sophia', 'stoic') or 'random' for date-seeded random selection",
+						"description": "Wisdom source ID (e.g., 'pistis_sophia', 'stoic') or 'random' for date-seeded random selection; defaults to the configured source",
+					},
+					"health": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Compute the score from the health of the server's working directory (runs git; overrides score)",
 					},
 				},
-				"required": []string{"score"},
 			},
 		},
 		{
 			Name:        "get_daily_briefing",
-			Description: "Get a daily wisdom briefing with quotes and guidance",
-			InputSchema: map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"score": map[string]interface{}{
-						"type":        "number",
-						"description": "Project health score (0-100)",
-					},
-				},
-			},
+			Description: "Get a daily advisor briefing for the weakest metrics, with trends against previous consultations",
+			InputSchema: dailyBriefingInputSchema(),
 		},
 		{
 			Name:        "get_consultation_log",
-			Description: "Retrieve consultation log entries",
-			InputSchema: map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"days": map[string]interface{}{
-						"type":        "number",
-						"description": "Number of days to retrieve (default: 7)",
-					},
-				},
-			},
+			Description: consultationLogDescription,
+			InputSchema: consultationLogInputSchema(),
+		},
+		{
+			Name:        "search_quotes",
+			Description: "Search all wisdom quotes by keyword, prefix, or phrase",
+			InputSchema: searchQuotesInputSchema(),
 		},
 	}
 
@@ -354,12 +761,16 @@ func (h *WisdomHandlers) HandleSourcesResource(req *JSONRPCRequest) *JSONRPCResp
 	for _, id := range sourceIDs {
 		source, found := h.wisdom.GetSource(id)
 		if found {
-			sourceDetails = append(sourceDetails, map[string]interface{}{
+			detail := map[string]interface{}{
 				"id":          id,
 				"name":        source.Name,
 				"icon":        source.Icon,
 				"description": source.Description,
-			})
+			}
+			if provenance, ok := h.wisdom.SourceProvenance(id); ok {
+				detail["provenance"] = provenance
+			}
+			sourceDetails = append(sourceDetails, detail)
 		}
 	}
 
@@ -374,6 +785,19 @@ func (h *WisdomHandlers) HandleSourcesResource(req *JSONRPCRequest) *JSONRPCResp
 	})
 }
 
+// HandleSourcesStatusResource handles wisdom://sources/status resource
+func (h *WisdomHandlers) HandleSourcesStatusResource(req *JSONRPCRequest) *JSONRPCResponse {
+	return NewSuccessResponse(req.ID, map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{
+				"uri":      "wisdom://sources/status",
+				"mimeType": "application/json",
+				"text":     string(mustMarshalJSONCompact(h.wisdom.SourceStatuses())),
+			},
+		},
+	})
+}
+
 // HandleAdvisorsResource handles wisdom://advisors resource
 func (h *WisdomHandlers) HandleAdvisorsResource(req *JSONRPCRequest) *JSONRPCResponse {
 	advisorRegistry := h.wisdom.GetAdvisors()
@@ -454,6 +878,24 @@ func (h *WisdomHandlers) HandleAdvisorResource(req *JSONRPCRequest, advisorID st
 	})
 }
 
+// HandleQuoteResource handles wisdom://quote/{id} resource
+func (h *WisdomHandlers) HandleQuoteResource(req *JSONRPCRequest, quoteID string) *JSONRPCResponse {
+	quote, err := h.wisdom.GetQuoteByID(quoteID)
+	if err != nil {
+		return NewErrorResponse(req.ID, ErrCodeInvalidParams, fmt.Sprintf("quote not found: %v", err), nil)
+	}
+
+	return NewSuccessResponse(req.ID, map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{
+				"uri":      "wisdom://quote/" + quoteID,
+				"mimeType": "application/json",
+				"text":     string(mustMarshalJSONCompact(quote)),
+			},
+		},
+	})
+}
+
 // HandleConsultationsResource handles wisdom://consultations/{days} resource
 func (h *WisdomHandlers) HandleConsultationsResource(req *JSONRPCRequest, days int) *JSONRPCResponse {
 	// Retrieve consultations from logger
@@ -481,6 +923,31 @@ func (h *WisdomHandlers) HandleConsultationsResource(req *JSONRPCRequest, days i
 	})
 }
 
+// HandleConsultationsQueryResource handles wisdom://consultations/{days}?{query}.
+// The query parameters are those of the get_consultation_log tool (aggregate
+// takes a comma-separated list), and the result has the same form.
+func (h *WisdomHandlers) HandleConsultationsQueryResource(req *JSONRPCRequest, uri string, days int, query url.Values) *JSONRPCResponse {
+	params := map[string]interface{}{"days": float64(days)}
+	for key, values := range query {
+		params[key] = strings.Join(values, ",")
+	}
+
+	result, err := h.queryConsultations(params)
+	if err != nil {
+		return NewInvalidParamsError(req.ID, err.Error())
+	}
+
+	return NewSuccessResponse(req.ID, map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{
+				"uri":      uri,
+				"mimeType": "application/json",
+				"text":     string(mustMarshalJSONCompact(result)),
+			},
+		},
+	})
+}
+
 // mustMarshalJSONCompact marshals to compact JSON (no indentation)
 // Used for embedding JSON strings in resource responses
 func mustMarshalJSONCompact(v interface{}) []byte {
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/logging"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestConsultationQuery(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	query, err := consultationQuery(map[string]interface{}{
+		"advisor":   "stoic",
+		"mode":      "chaos",
+		"min_score": 20.0,
+		"max_score": "60", // Resource query parameters are strings
+		"until":     "2026-03-09",
+		"limit":     "5",
+		"aggregate": "advisors, daily_scores",
+	}, now)
+	if err != nil {
+		t.Fatalf("consultationQuery failed: %v", err)
+	}
+	if query.Advisor != "stoic" || query.Mode != "chaos" || query.Limit != 5 {
+		t.Errorf("query = %+v", query)
+	}
+	if query.MinScore == nil || *query.MinScore != 20 || query.MaxScore == nil || *query.MaxScore != 60 {
+		t.Errorf("score range = %v..%v, want 20..60", query.MinScore, query.MaxScore)
+	}
+	if !query.Since.Equal(now.AddDate(0, 0, -7)) || !query.Until.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("time range = %v..%v, want the last 7 days until March 9", query.Since, query.Until)
+	}
+	if len(query.Aggregate) != 2 || query.Aggregate[1] != logging.AggregateDailyScores {
+		t.Errorf("aggregate = %v", query.Aggregate)
+	}
+
+	query, err = consultationQuery(map[string]interface{}{"days": 30.0, "since": "2026-03-01T08:00:00Z", "aggregate": []interface{}{"mode_transitions"}}, now)
+	if err != nil {
+		t.Fatalf("consultationQuery failed: %v", err)
+	}
+	if !query.Since.Equal(time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)) || len(query.Aggregate) != 1 {
+		t.Errorf("query = %+v, want since to take precedence over days", query)
+	}
+
+	for _, params := range []map[string]interface{}{
+		{"limit": "many"},
+		{"since": "last week"},
+	} {
+		if _, err := consultationQuery(params, now); err == nil {
+			t.Errorf("consultationQuery(%v) succeeded, want an error", params)
+		}
+	}
+}
+
+func TestSDKAdapterConsultationLogQuery(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, session, _ := connectSubscriber(t, ctx)
+
+	for _, args := range []map[string]interface{}{
+		{"metric": "security", "score": 20, "context": "Before the audit"},
+		{"metric": "testing", "score": 50},
+		{"metric": "security", "score": 90},
+	} {
+		if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "consult_advisor", Arguments: args}); err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+	}
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_consultation_log",
+		Arguments: map[string]interface{}{"metric": "security", "limit": 1, "order": "oldest", "aggregate": []string{"advisors"}},
+	})
+	if err != nil || result.IsError || len(result.Content) == 0 {
+		t.Fatalf("get_consultation_log failed: %v %+v", err, result)
+	}
+	var page logging.QueryResult
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &page); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if page.Total != 2 || len(page.Entries) != 1 || page.Entries[0].ScoreAtTime != 20 || page.NextCursor == "" {
+		t.Errorf("result = %+v, want the first of 2 security consultations and a cursor", page)
+	}
+	if page.Aggregations == nil || len(page.Aggregations.Advisors) == 0 {
+		t.Errorf("aggregations = %+v, want advisor counts", page.Aggregations)
+	}
+
+	read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "wisdom://consultations/7?context=AUDIT&order=oldest"})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &page); err != nil {
+		t.Fatalf("failed to parse resource: %v", err)
+	}
+	if page.Total != 1 || page.Entries[0].Context != "Before the audit" {
+		t.Errorf("resource = %+v, want the consultation with the audit context", page)
+	}
+
+	// Without a query the resource is the array of entries
+	read, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "wisdom://consultations/7"})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &entries); err != nil || len(entries) != 3 {
+		t.Errorf("resource = %s, want an array of 3 entries", read.Contents[0].Text)
+	}
+
+	// So is the tool's result with no arguments but days
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "get_consultation_log", Arguments: map[string]interface{}{"days": 7}})
+	if err != nil || result.IsError || len(result.Content) == 0 {
+		t.Fatalf("get_consultation_log failed: %v %+v", err, result)
+	}
+	entries = nil
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &entries); err != nil || len(entries) != 3 {
+		t.Errorf("get_consultation_log = %s, want an array of 3 entries", result.Content[0].(*mcp.TextContent).Text)
+	}
+
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "wisdom://consultations/7?order=random"}); err == nil {
+		t.Error("ReadResource accepted an invalid order")
+	}
+}
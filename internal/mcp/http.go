@@ -0,0 +1,123 @@
+// Package mcp provides the Model Context Protocol (MCP) server implementation.
+// This file contains the HTTP transport (streamable HTTP with SSE fallback).
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultHTTPAddr is the default listen address for the HTTP transport.
+const DefaultHTTPAddr = "localhost:8765"
+
+// HTTP endpoint paths served by HTTPHandler.
+const (
+	HTTPPathMCP     = "/mcp"     // Streamable HTTP transport
+	HTTPPathSSE     = "/sse"     // SSE transport (fallback for older clients)
+	HTTPPathHealthz = "/healthz" // Liveness: the process is serving HTTP
+	HTTPPathReadyz  = "/readyz"  // Readiness: the engine is initialized and not shutting down
+)
+
+// httpShutdownTimeout bounds how long RunHTTP waits for in-flight requests on shutdown.
+const httpShutdownTimeout = 10 * time.Second
+
+// HTTPHandler returns an http.Handler serving the MCP tools and resources over
+// the streamable HTTP transport (HTTPPathMCP) and the SSE transport (HTTPPathSSE),
+// plus health and readiness endpoints. All sessions share this server's wisdom
+// engine and consultation log.
+func (s *WisdomServerSDK) HTTPHandler() (http.Handler, error) {
+	if err := s.prepare(); err != nil {
+		return nil, err
+	}
+
+	getServer := func(*http.Request) *mcp.Server { return s.server }
+
+	mux := http.NewServeMux()
+	mux.Handle(HTTPPathMCP, mcp.NewStreamableHTTPHandler(getServer, nil))
+	mux.Handle(HTTPPathSSE, mcp.NewSSEHandler(getServer, nil))
+	mux.HandleFunc(HTTPPathHealthz, func(w http.ResponseWriter, r *http.Request) {
+		writeHealthStatus(w, http.StatusOK, "ok")
+	})
+	mux.HandleFunc(HTTPPathReadyz, func(w http.ResponseWriter, r *http.Request) {
+		if !s.ready.Load() {
+			writeHealthStatus(w, http.StatusServiceUnavailable, "unavailable")
+			return
+		}
+		writeHealthStatus(w, http.StatusOK, "ready")
+	})
+
+	s.ready.Store(true)
+	return mux, nil
+}
+
+// RunHTTP serves HTTPHandler on addr until ctx is cancelled, then shuts down
+// gracefully: readiness is reported as unavailable, new connections are refused,
+// open MCP streams are closed, and in-flight requests are given time to finish.
+func (s *WisdomServerSDK) RunHTTP(ctx context.Context, addr string) error {
+	handler, err := s.HTTPHandler()
+	if err != nil {
+		return err
+	}
+	s.watch(ctx)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %q: %w", addr, err)
+	}
+
+	// Long-lived SSE and streamable HTTP streams derive from baseCtx so they
+	// end when shutdown starts instead of holding it open until the timeout.
+	baseCtx, cancelStreams := context.WithCancel(context.Background())
+	defer cancelStreams()
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
+	}
+	srv.RegisterOnShutdown(cancelStreams)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(listener)
+	}()
+
+	s.appLogger.Info("", "MCP server listening on http://%s (streamable HTTP: %s, SSE: %s)", listener.Addr(), HTTPPathMCP, HTTPPathSSE)
+
+	select {
+	case err := <-serveErr:
+		s.ready.Store(false)
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("http server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.appLogger.Info("", "MCP server shutting down")
+	s.ready.Store(false)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("http server shutdown failed: %w", err)
+	}
+	return nil
+}
+
+// writeHealthStatus writes a small JSON status document for health endpoints.
+func writeHealthStatus(w http.ResponseWriter, code int, status string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  status,
+		"version": Version,
+	})
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestHTTPServer(t *testing.T) (*WisdomServerSDK, *httptest.Server) {
+	t.Helper()
+
+	server := newTestServerSDK(t)
+	handler, err := server.HTTPHandler()
+	if err != nil {
+		t.Fatalf("HTTPHandler failed: %v", err)
+	}
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return server, ts
+}
+
+func TestHTTPHandler_HealthEndpoints(t *testing.T) {
+	server, ts := newTestHTTPServer(t)
+
+	for _, path := range []string{HTTPPathHealthz, HTTPPathReadyz} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET %s status = %d, want %d", path, resp.StatusCode, http.StatusOK)
+		}
+	}
+
+	// Readiness drops while shutting down
+	server.ready.Store(false)
+	resp, err := http.Get(ts.URL + HTTPPathReadyz)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", HTTPPathReadyz, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GET %s status = %d, want %d", HTTPPathReadyz, resp.StatusCode, http.StatusServiceUnavailable)
+	}
+}
+
+func TestHTTPHandler_Transports(t *testing.T) {
+	_, ts := newTestHTTPServer(t)
+
+	transports := map[string]mcp.Transport{
+		"streamable": &mcp.StreamableClientTransport{Endpoint: ts.URL + HTTPPathMCP},
+		"sse":        &mcp.SSEClientTransport{Endpoint: ts.URL + HTTPPathSSE},
+	}
+
+	for name, transport := range transports {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0"}, nil)
+			session, err := client.Connect(ctx, transport, nil)
+			if err != nil {
+				t.Fatalf("Connect failed: %v", err)
+			}
+			defer session.Close()
+
+			tools, err := session.ListTools(ctx, nil)
+			if err != nil {
+				t.Fatalf("ListTools failed: %v", err)
+			}
+			if len(tools.Tools) != 5 {
+				t.Errorf("got %d tools, want 5", len(tools.Tools))
+			}
+
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{
+				Name:      "get_wisdom",
+				Arguments: map[string]interface{}{"score": 75.0, "source": "stoic"},
+			})
+			if err != nil {
+				t.Fatalf("CallTool failed: %v", err)
+			}
+			if result.IsError {
+				t.Errorf("get_wisdom returned an error result: %+v", result.Content)
+			}
+		})
+	}
+}
+
+func TestRunHTTP_GracefulShutdown(t *testing.T) {
+	server := newTestServerSDK(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.RunHTTP(ctx, "127.0.0.1:0")
+	}()
+
+	// Wait until the server reports ready, then request shutdown
+	deadline := time.Now().Add(5 * time.Second)
+	for !server.ready.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("server did not become ready")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("RunHTTP returned error on shutdown: %v", err)
+		}
+	case <-time.After(httpShutdownTimeout):
+		t.Fatal("RunHTTP did not return after context cancellation")
+	}
+	if server.ready.Load() {
+		t.Error("server still reports ready after shutdown")
+	}
+}
@@ -0,0 +1,238 @@
+// Package mcp provides the Model Context Protocol (MCP) server implementation.
+// This file contains the MCP prompts (advisor persona templates).
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultPromptScore is used when a prompt is requested without a score.
+const defaultPromptScore = 50.0
+
+// promptDefinition describes an advisor persona prompt.
+type promptDefinition struct {
+	name        string
+	description string
+	// stage selects the advisor from the stage mappings; when empty the
+	// advisor comes from the required "metric" argument.
+	stage string
+	// sessionMode sets the default tone and focus (overridable with "session_mode").
+	sessionMode wisdom.SessionMode
+	// task renders the closing user request from the prompt arguments.
+	task func(args promptArgs) string
+}
+
+// promptArgs holds the parsed arguments of a prompt request.
+type promptArgs struct {
+	metric  string
+	score   float64
+	context string
+}
+
+// promptDefinitions lists the prompts served by the MCP server.
+var promptDefinitions = []promptDefinition{
+	{
+		name:        "advisor_review",
+		description: "Review a project metric with the advisor mapped to it",
+		sessionMode: wisdom.SessionModeAsk,
+		task: func(a promptArgs) string {
+			return fmt.Sprintf("Review the project's %s (currently %.0f/100). Identify the most important weaknesses and recommend concrete next steps.%s",
+				a.metric, a.score, contextSuffix(a.context))
+		},
+	},
+	{
+		name:        "daily_standup",
+		description: "Run a daily standup framed by the daily check-in advisor",
+		stage:       "daily_checkin",
+		sessionMode: wisdom.SessionModeAgent,
+		task: func(a promptArgs) string {
+			return fmt.Sprintf("Run today's standup for a project at %.0f/100: summarize what was done, what is planned next, and what is blocking progress.%s",
+				a.score, contextSuffix(a.context))
+		},
+	},
+	{
+		name:        "retrospective",
+		description: "Lead a retrospective framed by the retrospective advisor",
+		stage:       "retrospective",
+		sessionMode: wisdom.SessionModeManual,
+		task: func(a promptArgs) string {
+			return fmt.Sprintf("Lead a retrospective for a project at %.0f/100: what went well, what did not, and which lessons should change how we work.%s",
+				a.score, contextSuffix(a.context))
+		},
+	},
+	{
+		name:        "debugging_session",
+		description: "Work through a bug with the debugging advisor",
+		stage:       "debugging",
+		sessionMode: wisdom.SessionModeAsk,
+		task: func(a promptArgs) string {
+			return fmt.Sprintf("Help me debug this problem. Form hypotheses, propose the quickest experiments to confirm or rule them out, and suggest a fix.%s",
+				contextSuffix(a.context))
+		},
+	},
+}
+
+// promptArguments returns the MCP argument declarations for a prompt.
+func (d promptDefinition) promptArguments() []*mcp.PromptArgument {
+	args := []*mcp.PromptArgument{}
+	if d.stage == "" {
+		args = append(args, &mcp.PromptArgument{
+			Name:        "metric",
+			Description: "Metric name (e.g., 'security', 'testing')",
+			Required:    true,
+		})
+	}
+	args = append(args,
+		&mcp.PromptArgument{
+			Name:        "score",
+			Description: fmt.Sprintf("Project health score (0-100, default: %.0f)", defaultPromptScore),
+		},
+		&mcp.PromptArgument{
+			Name:        "context",
+			Description: "Additional context (e.g., what changed, the error being debugged)",
+		},
+		&mcp.PromptArgument{
+			Name:        "session_mode",
+			Description: fmt.Sprintf("Session mode for tone and focus: AGENT, ASK, or MANUAL (default: %s)", d.sessionMode),
+		},
+	)
+	return args
+}
+
+// contextSuffix formats optional user context for inclusion in a prompt.
+func contextSuffix(context string) string {
+	if context == "" {
+		return ""
+	}
+	return "\n\nContext: " + context
+}
+
+// BuildPrompt renders the named prompt as a message sequence: the advisor
+// persona framing, the advisor's opening quote, and the task request.
+func (h *WisdomHandlers) BuildPrompt(name string, arguments map[string]string) (*mcp.GetPromptResult, error) {
+	var def *promptDefinition
+	for i := range promptDefinitions {
+		if promptDefinitions[i].name == name {
+			def = &promptDefinitions[i]
+			break
+		}
+	}
+	if def == nil {
+		available := make([]string, 0, len(promptDefinitions))
+		for _, d := range promptDefinitions {
+			available = append(available, d.name)
+		}
+		sort.Strings(available)
+		return nil, fmt.Errorf("unknown prompt %q (available prompts: %v). Check prompt name spelling", name, available)
+	}
+
+	args := promptArgs{
+		metric:  arguments["metric"],
+		score:   defaultPromptScore,
+		context: arguments["context"],
+	}
+	if raw := arguments["score"]; raw != "" {
+		score, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid score %q for prompt %q: must be a number between 0 and 100", raw, name)
+		}
+		// Clamp score to 0-100 range, as the tools do
+		args.score = min(max(score, 0), 100)
+	}
+
+	// Resolve the advisor from the stage or metric mappings
+	var advisorInfo *wisdom.AdvisorInfo
+	var err error
+	if def.stage != "" {
+		advisorInfo, err = h.wisdom.GetAdvisors().GetAdvisorForStage(def.stage)
+	} else {
+		if args.metric == "" {
+			return nil, fmt.Errorf("prompt %q requires the \"metric\" argument", name)
+		}
+		advisorInfo, err = h.wisdom.GetAdvisors().GetAdvisorForMetric(args.metric)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sessionMode := def.sessionMode
+	if raw := arguments["session_mode"]; raw != "" {
+		sessionMode = wisdom.SessionMode(strings.ToUpper(raw))
+	}
+	modeConfig := wisdom.GetModeConfig(sessionMode)
+	if modeConfig == nil {
+		return nil, fmt.Errorf("invalid session_mode %q for prompt %q (expected AGENT, ASK, or MANUAL)", arguments["session_mode"], name)
+	}
+	consultationMode := wisdom.GetConsultationMode(args.score)
+
+	messages := []*mcp.PromptMessage{
+		{Role: "user", Content: &mcp.TextContent{Text: personaFraming(h.wisdom, advisorInfo, modeConfig, consultationMode)}},
+	}
+
+	// The advisor opens with a quote from their source; disabled or filtered
+	// sources simply leave the quote out.
+	if !h.wisdom.IsDisabled() {
+		if quote, err := h.wisdom.GetWisdom(args.score, advisorInfo.Advisor); err == nil {
+			opening := fmt.Sprintf("%s \"%s\"\n— %s", advisorInfo.Icon, quote.Text(), quote.Source)
+			if quote.Encouragement != "" {
+				opening += "\n\n" + quote.Encouragement
+			}
+			messages = append(messages, &mcp.PromptMessage{Role: "assistant", Content: &mcp.TextContent{Text: opening}})
+		}
+	}
+
+	messages = append(messages, &mcp.PromptMessage{Role: "user", Content: &mcp.TextContent{Text: def.task(args)}})
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("%s (advisor: %s %s)", def.description, advisorInfo.Icon, advisorInfo.Advisor),
+		Messages:    messages,
+	}, nil
+}
+
+// personaFraming describes the advisor persona, tone, and focus for the assistant.
+func personaFraming(engine *wisdom.Engine, advisorInfo *wisdom.AdvisorInfo, modeConfig *wisdom.ModeConfig, consultationMode wisdom.ConsultationModeConfig) string {
+	advisorName := advisorInfo.Advisor
+	if source, found := engine.GetSource(advisorInfo.Advisor); found && source.Name != "" {
+		advisorName = source.Name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Act as %s %s, my devwisdom advisor.\n", advisorInfo.Icon, advisorName)
+	fmt.Fprintf(&b, "Why this advisor: %s\n", advisorInfo.Rationale)
+	if advisorInfo.HelpsWith != "" {
+		fmt.Fprintf(&b, "Helps with: %s\n", advisorInfo.HelpsWith)
+	}
+	fmt.Fprintf(&b, "Tone: %s. Focus: %s.\n", modeConfig.Tone, modeConfig.Focus)
+	fmt.Fprintf(&b, "%s %s", consultationMode.Icon, consultationMode.Description)
+	return b.String()
+}
+
+// registerPrompts registers the advisor persona prompts with the SDK server.
+func (s *WisdomServerSDK) registerPrompts() error {
+	handlers := NewWisdomHandlers(s.wisdom, s.logger, s.appLogger)
+
+	for _, def := range promptDefinitions {
+		prompt := &mcp.Prompt{
+			Name:        def.name,
+			Description: def.description,
+			Arguments:   def.promptArguments(),
+		}
+		name := def.name
+		s.server.AddPrompt(prompt, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			var arguments map[string]string
+			if req.Params != nil {
+				arguments = req.Params.Arguments
+			}
+			return handlers.BuildPrompt(name, arguments)
+		})
+	}
+
+	return nil
+}
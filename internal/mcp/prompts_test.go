@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/logging"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestPromptHandlers(t *testing.T) *WisdomHandlers {
+	t.Helper()
+
+	engine := wisdom.NewEngine()
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return NewWisdomHandlers(engine, nil, logging.NewLogger())
+}
+
+func TestBuildPrompt_AdvisorReview(t *testing.T) {
+	handlers := newTestPromptHandlers(t)
+
+	result, err := handlers.BuildPrompt("advisor_review", map[string]string{
+		"metric":  "security",
+		"score":   "20",
+		"context": "new auth endpoints",
+	})
+	if err != nil {
+		t.Fatalf("BuildPrompt failed: %v", err)
+	}
+
+	if len(result.Messages) < 2 {
+		t.Fatalf("got %d messages, want at least 2", len(result.Messages))
+	}
+
+	framing := result.Messages[0].Content.(*mcp.TextContent).Text
+	if !strings.Contains(framing, "BOFH") {
+		t.Errorf("framing does not name the security advisor: %q", framing)
+	}
+	if !strings.Contains(framing, "Tone: direct") {
+		t.Errorf("framing does not include the ASK session tone: %q", framing)
+	}
+	if !strings.Contains(framing, "Chaos mode") {
+		t.Errorf("framing does not include the consultation mode for score 20: %q", framing)
+	}
+
+	last := result.Messages[len(result.Messages)-1]
+	task := last.Content.(*mcp.TextContent).Text
+	if last.Role != "user" || !strings.Contains(task, "security") || !strings.Contains(task, "new auth endpoints") {
+		t.Errorf("task message = %s %q, want user request with metric and context", last.Role, task)
+	}
+}
+
+func TestBuildPrompt_StagePrompts(t *testing.T) {
+	handlers := newTestPromptHandlers(t)
+
+	tests := map[string]string{
+		"daily_standup":     "Tone: strategic",
+		"retrospective":     "Tone: observational",
+		"debugging_session": "Tone: direct",
+	}
+	for name, tone := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := handlers.BuildPrompt(name, nil)
+			if err != nil {
+				t.Fatalf("BuildPrompt failed: %v", err)
+			}
+			framing := result.Messages[0].Content.(*mcp.TextContent).Text
+			if !strings.Contains(framing, tone) {
+				t.Errorf("framing = %q, want %q", framing, tone)
+			}
+		})
+	}
+
+	// session_mode overrides the default tone
+	result, err := handlers.BuildPrompt("retrospective", map[string]string{"session_mode": "agent"})
+	if err != nil {
+		t.Fatalf("BuildPrompt failed: %v", err)
+	}
+	if framing := result.Messages[0].Content.(*mcp.TextContent).Text; !strings.Contains(framing, "Tone: strategic") {
+		t.Errorf("framing = %q, want AGENT tone", framing)
+	}
+}
+
+func TestBuildPrompt_Errors(t *testing.T) {
+	handlers := newTestPromptHandlers(t)
+
+	tests := []struct {
+		name string
+		args map[string]string
+	}{
+		{"nonexistent", nil},
+		{"advisor_review", nil},
+		{"advisor_review", map[string]string{"metric": "nonexistent"}},
+		{"daily_standup", map[string]string{"score": "high"}},
+		{"daily_standup", map[string]string{"session_mode": "sleepy"}},
+	}
+	for _, tt := range tests {
+		if _, err := handlers.BuildPrompt(tt.name, tt.args); err == nil {
+			t.Errorf("BuildPrompt(%q, %v) should fail", tt.name, tt.args)
+		}
+	}
+}
+
+func TestSDKAdapterPrompts(t *testing.T) {
+	server := newTestServerSDK(t)
+	if err := server.prepare(); err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := server.server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server Connect failed: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect failed: %v", err)
+	}
+	defer session.Close()
+
+	prompts, err := session.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListPrompts failed: %v", err)
+	}
+	if len(prompts.Prompts) != len(promptDefinitions) {
+		t.Errorf("got %d prompts, want %d", len(prompts.Prompts), len(promptDefinitions))
+	}
+
+	result, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "advisor_review",
+		Arguments: map[string]string{"metric": "testing", "score": "65"},
+	})
+	if err != nil {
+		t.Fatalf("GetPrompt failed: %v", err)
+	}
+	if len(result.Messages) == 0 {
+		t.Error("GetPrompt returned no messages")
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/davidl71/devwisdom-go/internal/logging"
 	"github.com/davidl71/devwisdom-go/internal/wisdom"
@@ -21,12 +25,28 @@ type WisdomServerSDK struct {
 	wisdom    *wisdom.Engine
 	logger    *logging.ConsultationLogger
 	appLogger *logging.Logger
+
+	prepareOnce sync.Once
+	prepareErr  error
+	ready       atomic.Bool // Set while the HTTP transport accepts requests
+
+	watchInterval   time.Duration // How often sources files are checked for changes; 0 disables reloading
+	sourcesResource *mcp.Resource // Re-added after a reload to notify clients
+	sourcesHandler  mcp.ResourceHandler
+
+	subscriptionsMu sync.Mutex
+	subscriptions   map[string]map[*mcp.ServerSession]bool // Subscribed resource URI -> subscribed sessions
 }
 
 // NewWisdomServerSDK creates a new wisdom MCP server instance using the official SDK.
 func NewWisdomServerSDK() *WisdomServerSDK {
-	// Initialize consultation logger (log directory: .devwisdom)
-	logger, err := logging.NewConsultationLogger(".devwisdom")
+	return newWisdomServerSDK(".devwisdom")
+}
+
+// newWisdomServerSDK creates a server logging consultations to logDir.
+func newWisdomServerSDK(logDir string) *WisdomServerSDK {
+	// Initialize consultation logger
+	logger, err := logging.NewConsultationLogger(logDir)
 	if err != nil {
 		// Log initialization failure is non-fatal - server can still work without logging
 		logger = nil
@@ -35,40 +55,44 @@ func NewWisdomServerSDK() *WisdomServerSDK {
 	// Initialize structured application logger
 	appLogger := logging.NewLogger()
 
-	// Create SDK server
-	sdkServer := mcp.NewServer(&mcp.Implementation{
-		Name:    "devwisdom",
-		Version: Version,
-	}, nil)
-
-	return &WisdomServerSDK{
-		server:    sdkServer,
+	s := &WisdomServerSDK{
 		wisdom:    wisdom.NewEngine(),
 		logger:    logger,
 		appLogger: appLogger,
-	}
-}
 
-// Run starts the MCP server with stdio transport using the SDK.
-func (s *WisdomServerSDK) Run(ctx context.Context) error {
-	// Initialize wisdom engine first (before any output)
-	if err := s.wisdom.Initialize(); err != nil {
-		s.appLogger.Error("", "Failed to initialize wisdom engine: %v", err)
-		return fmt.Errorf("failed to initialize wisdom engine (check sources.json configuration and file permissions): %w", err)
+		watchInterval: wisdom.DefaultWatchInterval,
+		subscriptions: make(map[string]map[*mcp.ServerSession]bool),
 	}
 
-	// Log server startup
-	s.appLogger.Info("", "MCP server v%s starting (SDK)", Version)
+	// Create SDK server; clients may subscribe to sources and consultations
+	s.server = mcp.NewServer(&mcp.Implementation{
+		Name:    "devwisdom",
+		Version: Version,
+	}, &mcp.ServerOptions{
+		SubscribeHandler:   s.subscribe,
+		UnsubscribeHandler: s.unsubscribe,
+	})
+	// Sources fetched in the background change wisdom://sources too
+	s.wisdom.OnSourceUpdate(s.sourceUpdated)
+	return s
+}
 
-	// Register tools
-	if err := s.registerTools(); err != nil {
-		return fmt.Errorf("failed to register tools: %w", err)
-	}
+// WithWatchInterval sets how often Run and RunHTTP check sources, advisors,
+// aeon override and config files for changes (default:
+// wisdom.DefaultWatchInterval). Valid changes are reloaded and clients are
+// sent notifications/resources/list_changed; invalid ones are logged and the
+// current sources are kept. An interval of 0 disables reloading.
+func (s *WisdomServerSDK) WithWatchInterval(interval time.Duration) *WisdomServerSDK {
+	s.watchInterval = interval
+	return s
+}
 
-	// Register resources
-	if err := s.registerResources(); err != nil {
-		return fmt.Errorf("failed to register resources: %w", err)
+// Run starts the MCP server with stdio transport using the SDK.
+func (s *WisdomServerSDK) Run(ctx context.Context) error {
+	if err := s.prepare(); err != nil {
+		return err
 	}
+	s.watch(ctx)
 
 	// Run with stdio transport
 	transport := &mcp.StdioTransport{}
@@ -79,6 +103,101 @@ func (s *WisdomServerSDK) Run(ctx context.Context) error {
 	return nil
 }
 
+// Close releases resources held by the server, such as the consultation log file.
+func (s *WisdomServerSDK) Close() error {
+	if s.logger != nil {
+		return s.logger.Close()
+	}
+	return nil
+}
+
+// watch reloads the engine in the background when its files change, until
+// ctx is done.
+func (s *WisdomServerSDK) watch(ctx context.Context) {
+	if s.watchInterval <= 0 {
+		return
+	}
+	watcher := wisdom.NewWatcher(s.wisdom).
+		WithInterval(s.watchInterval).
+		OnReload(s.sourcesReloaded)
+	go func() { _ = watcher.Run(ctx) }()
+}
+
+// sourcesReloaded logs an automatic reload and, if it succeeded, notifies
+// clients that the resources changed.
+func (s *WisdomServerSDK) sourcesReloaded(event wisdom.ReloadEvent) {
+	changed := strings.Join(event.Changed, ", ")
+	if event.Err != nil {
+		s.appLogger.Warn("", "Not reloading %s: %v", changed, event.Err)
+		for _, d := range event.Diagnostics {
+			s.appLogger.Warn("", "%s", d)
+		}
+		return
+	}
+	s.appLogger.Info("", "Reloaded %s", changed)
+	if s.logger != nil {
+		// The config file may have changed the log retention
+		s.logger.WithRetention(logging.RetentionFromConfig(s.wisdom.GetConfig()))
+	}
+
+	// The SDK sends notifications/resources/list_changed to all sessions
+	// whenever a resource is added; replacing wisdom://sources with itself
+	// is how a change is announced.
+	if s.sourcesResource != nil {
+		s.server.AddResource(s.sourcesResource, s.sourcesHandler)
+	}
+	s.resourceUpdated(sourcesURI)
+}
+
+// prepare initializes the wisdom engine and registers tools and resources.
+// It is shared by all transports and only runs once; later calls return the
+// first result.
+func (s *WisdomServerSDK) prepare() error {
+	s.prepareOnce.Do(func() {
+		// Initialize wisdom engine first (before any output)
+		if err := s.wisdom.Initialize(); err != nil {
+			s.appLogger.Error("", "Failed to initialize wisdom engine: %v", err)
+			s.prepareErr = fmt.Errorf("failed to initialize wisdom engine (check sources.json configuration and file permissions): %w", err)
+			return
+		}
+		if s.logger != nil {
+			// Rotate the consultation log on the configured day boundary; maintenance
+			// failures after a rotation go to the server log
+			s.logger.WithClock(s.wisdom.Clock(), s.wisdom.DayBoundary()).
+				WithLogger(s.appLogger)
+			// Notify clients subscribed to wisdom://consultations/{days}
+			s.logger.OnLog(s.consultationLogged)
+			// Delete, compress and compact rotated log files as configured
+			s.logger.WithRetention(logging.RetentionFromConfig(s.wisdom.GetConfig()))
+			if _, err := s.logger.Maintain(); err != nil {
+				s.appLogger.Warn("", "Consultation log maintenance failed: %v", err)
+			}
+		}
+
+		// Log server startup
+		s.appLogger.Info("", "MCP server v%s starting (SDK)", Version)
+
+		// Register tools
+		if err := s.registerTools(); err != nil {
+			s.prepareErr = fmt.Errorf("failed to register tools: %w", err)
+			return
+		}
+
+		// Register resources
+		if err := s.registerResources(); err != nil {
+			s.prepareErr = fmt.Errorf("failed to register resources: %w", err)
+			return
+		}
+
+		// Register prompts
+		if err := s.registerPrompts(); err != nil {
+			s.prepareErr = fmt.Errorf("failed to register prompts: %w", err)
+			return
+		}
+	})
+	return s.prepareErr
+}
+
 // registerTools registers all MCP tools with the SDK server.
 func (s *WisdomServerSDK) registerTools() error {
 	// Create handlers instance to reuse business logic
@@ -107,6 +226,10 @@ func (s *WisdomServerSDK) registerTools() error {
 					"type":        "number",
 					"description": "Project health score (0-100)",
 				},
+				"health": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Compute the score from the health of the server's working directory (runs git; overrides score); uses the metric's own score when it is measured",
+				},
 				"context": map[string]interface{}{
 					"type":        "string",
 					"description": "Additional context for the consultation",
@@ -177,14 +300,17 @@ func (s *WisdomServerSDK) registerTools() error {
 			"properties": map[string]interface{}{
 				"score": map[string]interface{}{
 					"type":        "number",
-					"description": "Project health score (0-100)",
+					"description": "Project health score (0-100); required unless health is set",
 				},
 				"source": map[string]interface{}{
 					"type":        "string",
-					"description": "Wisdom source ID (e.g., 'pistis_sophia', 'stoic') or 'random' for date-seeded random selection",
+					"description": "Wisdom source ID (e.g., 'pistis_sophia', 'stoic') or 'random' for date-seeded random selection; defaults to the configured source",
+				},
+				"health": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Compute the score from the health of the server's working directory (runs git; overrides score)",
 				},
 			},
-			"required": []string{"score"},
 		},
 	}
 
@@ -241,16 +367,8 @@ func (s *WisdomServerSDK) registerTools() error {
 	// Register get_daily_briefing tool
 	getDailyBriefingTool := &mcp.Tool{
 		Name:        "get_daily_briefing",
-		Description: "Get a daily wisdom briefing with quotes and guidance",
-		InputSchema: map[string]interface{}{
-			"type": "object",
-			"properties": map[string]interface{}{
-				"score": map[string]interface{}{
-					"type":        "number",
-					"description": "Project health score (0-100)",
-				},
-			},
-		},
+		Description: "Get a daily advisor briefing for the weakest metrics, with trends against previous consultations",
+		InputSchema: dailyBriefingInputSchema(),
 	}
 
 	getDailyBriefingHandler := func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -306,16 +424,8 @@ func (s *WisdomServerSDK) registerTools() error {
 	// Register get_consultation_log tool
 	getConsultationLogTool := &mcp.Tool{
 		Name:        "get_consultation_log",
-		Description: "Retrieve consultation log entries",
-		InputSchema: map[string]interface{}{
-			"type": "object",
-			"properties": map[string]interface{}{
-				"days": map[string]interface{}{
-					"type":        "number",
-					"description": "Number of days to retrieve (default: 7)",
-				},
-			},
-		},
+		Description: consultationLogDescription,
+		InputSchema: consultationLogInputSchema(),
 	}
 
 	getConsultationLogHandler := func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -368,9 +478,51 @@ func (s *WisdomServerSDK) registerTools() error {
 
 	s.server.AddTool(getConsultationLogTool, getConsultationLogHandler)
 
+	// Register search_quotes tool
+	searchQuotesTool := &mcp.Tool{
+		Name:        "search_quotes",
+		Description: "Search all wisdom quotes by keyword, prefix, or phrase",
+		InputSchema: searchQuotesInputSchema(),
+	}
+	s.server.AddTool(searchQuotesTool, newToolHandler(handlers.handleSearchQuotes))
+
 	return nil
 }
 
+// newToolHandler adapts a handler taking decoded arguments to an SDK tool
+// handler. Errors are reported as tool results with IsError set.
+func newToolHandler(call func(params map[string]interface{}) (interface{}, error)) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		errorResult := func(format string, err error) *mcp.CallToolResult {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(format, err)}},
+			}
+		}
+
+		args := make(map[string]interface{})
+		if req.Params != nil && len(req.Params.Arguments) > 0 {
+			if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+				return errorResult("Failed to parse arguments: %v", err), nil
+			}
+		}
+
+		result, err := call(args)
+		if err != nil {
+			return errorResult("Tool execution error: %v", err), nil
+		}
+
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			return errorResult("Failed to marshal result: %v", err), nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(resultJSON)}},
+		}, nil
+	}
+}
+
 // registerResources registers all MCP resources with the SDK server.
 func (s *WisdomServerSDK) registerResources() error {
 	// Create handlers instance to reuse business logic
@@ -414,6 +566,17 @@ func (s *WisdomServerSDK) registerAllResources(handlers *WisdomHandlers) error {
 	}
 	sourcesHandler := s.createResourceHandler("wisdom://sources", handlers.HandleSourcesResource)
 	s.server.AddResource(sourcesResource, sourcesHandler)
+	s.sourcesResource, s.sourcesHandler = sourcesResource, sourcesHandler
+
+	// Register wisdom://sources/status
+	sourcesStatusResource := &mcp.Resource{
+		URI:         "wisdom://sources/status",
+		Name:        "Wisdom Source Status",
+		Description: "Load status of each wisdom source (pending, ready, failed, or stale)",
+		MIMEType:    "application/json",
+	}
+	sourcesStatusHandler := s.createResourceHandler("wisdom://sources/status", handlers.HandleSourcesStatusResource)
+	s.server.AddResource(sourcesStatusResource, sourcesStatusHandler)
 
 	// Register wisdom://advisors
 	advisorsResource := &mcp.Resource{
@@ -455,11 +618,41 @@ func (s *WisdomServerSDK) registerAllResources(handlers *WisdomHandlers) error {
 	}
 	s.server.AddResourceTemplate(advisorTemplate, advisorTemplateHandler)
 
+	// Register wisdom://quote/{id} - use ResourceTemplate for dynamic URI
+	quoteTemplate := &mcp.ResourceTemplate{
+		URITemplate: "wisdom://quote/{id}",
+		Name:        "Quote",
+		Description: "Get a specific quote by its stable ID",
+		MIMEType:    "application/json",
+	}
+	quoteTemplateHandler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		if req.Params == nil || req.Params.URI == "" {
+			return nil, fmt.Errorf("resource URI is required")
+		}
+		uri := req.Params.URI
+
+		// Extract quote ID from URI (wisdom://quote/{id})
+		if !strings.HasPrefix(uri, "wisdom://quote/") {
+			return nil, fmt.Errorf("invalid quote URI format: %s", uri)
+		}
+		quoteID := strings.TrimPrefix(uri, "wisdom://quote/")
+
+		mockReq := &JSONRPCRequest{
+			ID:     "resource",
+			Method: "resources/read",
+			Params: json.RawMessage(fmt.Sprintf(`{"uri": "%s"}`, uri)),
+		}
+
+		resp := handlers.HandleQuoteResource(mockReq, quoteID)
+		return s.convertResourceResponse(resp, uri)
+	}
+	s.server.AddResourceTemplate(quoteTemplate, quoteTemplateHandler)
+
 	// Register wisdom://consultations/{days} - use ResourceTemplate for dynamic URI
 	consultationsTemplate := &mcp.ResourceTemplate{
-		URITemplate: "wisdom://consultations/{days}",
+		URITemplate: consultationsURITemplate,
 		Name:        "Consultation Log",
-		Description: "Get consultation log entries for the specified number of days",
+		Description: consultationsResourceDescription,
 		MIMEType:    "application/json",
 	}
 	consultationsTemplateHandler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
@@ -472,7 +665,7 @@ func (s *WisdomServerSDK) registerAllResources(handlers *WisdomHandlers) error {
 		if !strings.HasPrefix(uri, "wisdom://consultations/") {
 			return nil, fmt.Errorf("invalid consultations URI format: %s", uri)
 		}
-		daysStr := strings.TrimPrefix(uri, "wisdom://consultations/")
+		daysStr, rawQuery, hasQuery := strings.Cut(strings.TrimPrefix(uri, "wisdom://consultations/"), "?")
 		days := 7 // default
 		if daysStr != "" {
 			if d, err := strconv.Atoi(daysStr); err == nil {
@@ -486,8 +679,14 @@ func (s *WisdomServerSDK) registerAllResources(handlers *WisdomHandlers) error {
 			Params: json.RawMessage(fmt.Sprintf(`{"uri": "%s"}`, uri)),
 		}
 		
-		resp := handlers.HandleConsultationsResource(mockReq, days)
-		return s.convertResourceResponse(resp, uri)
+		if !hasQuery {
+			return s.convertResourceResponse(handlers.HandleConsultationsResource(mockReq, days), uri)
+		}
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query in consultations URI %q: %w", uri, err)
+		}
+		return s.convertResourceResponse(handlers.HandleConsultationsQueryResource(mockReq, uri, days, query), uri)
 	}
 	s.server.AddResourceTemplate(consultationsTemplate, consultationsTemplateHandler)
 
@@ -515,19 +714,29 @@ func (s *WisdomServerSDK) convertResourceResponse(resp *JSONRPCResponse, uri str
 	}
 
 	if result, ok := resp.Result.(map[string]interface{}); ok {
-		if contents, ok := result["contents"].([]interface{}); ok && len(contents) > 0 {
-			// Convert first content item
-			if contentMap, ok := contents[0].(map[string]interface{}); ok {
-				return &mcp.ReadResourceResult{
-					Contents: []*mcp.ResourceContents{
-						{
-							URI:      getString(contentMap, "uri", uri),
-							MIMEType: getString(contentMap, "mimeType", "application/json"),
-							Text:     getString(contentMap, "text", ""),
-						},
-					},
-				}, nil
+		// Handlers build contents as []map[string]interface{}; decoded JSON has []interface{}
+		var contentMap map[string]interface{}
+		switch contents := result["contents"].(type) {
+		case []map[string]interface{}:
+			if len(contents) > 0 {
+				contentMap = contents[0]
 			}
+		case []interface{}:
+			if len(contents) > 0 {
+				contentMap, _ = contents[0].(map[string]interface{})
+			}
+		}
+		// Convert first content item
+		if contentMap != nil {
+			return &mcp.ReadResourceResult{
+				Contents: []*mcp.ResourceContents{
+					{
+						URI:      getString(contentMap, "uri", uri),
+						MIMEType: getString(contentMap, "mimeType", "application/json"),
+						Text:     getString(contentMap, "text", ""),
+					},
+				},
+			}, nil
 		}
 	}
 
@@ -1,8 +1,17 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // TestSDKAdapterBasic tests basic SDK adapter functionality
@@ -98,3 +107,81 @@ func TestSDKAdapterResourceHandlers(t *testing.T) {
 	}
 }
 
+// TestSDKAdapterWatchSources tests that changed sources files are reloaded and
+// announced to clients, and that invalid ones are not
+func TestSDKAdapterWatchSources(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, ".wisdom", "sources.json")
+	writeSources := func(content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	writeSources(`{"sources": {"watched": {"name": "Watched", "quotes": {"chaos": [{"quote": "Q", "source": "S"}]}}}}`)
+
+	server := newTestServerSDK(t).WithWatchInterval(10 * time.Millisecond)
+	server.wisdom = wisdom.NewEngine().
+		WithLoader(wisdom.NewSourceLoader().WithProjectRoot(root)).
+		WithConfig(config.NewConfig())
+	if err := server.prepare(); err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := server.server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server Connect failed: %v", err)
+	}
+	changed := make(chan struct{}, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0"}, &mcp.ClientOptions{
+		ResourceListChangedHandler: func(context.Context, *mcp.ResourceListChangedRequest) { changed <- struct{}{} },
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect failed: %v", err)
+	}
+	defer session.Close()
+
+	server.watch(ctx)
+	time.Sleep(100 * time.Millisecond) // Let the watcher record the files' initial state
+	for len(changed) > 0 {
+		<-changed // Sent for the resources registered before connecting
+	}
+
+	readSources := func() string {
+		t.Helper()
+		result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "wisdom://sources"})
+		if err != nil || len(result.Contents) == 0 {
+			t.Fatalf("ReadResource failed: %v", err)
+		}
+		return result.Contents[0].Text
+	}
+
+	writeSources(`{"sources": {"watched": {"name": "Watched", "quotes": {"chaos": [{"quote": "Q", "source": "S"}]}},
+  "added": {"name": "Added", "quotes": {"chaos": [{"quote": "Q2", "source": "S"}]}}}}`)
+	select {
+	case <-changed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("no notifications/resources/list_changed after a sources change")
+	}
+	if sources := readSources(); !strings.Contains(sources, `"added"`) {
+		t.Errorf("wisdom://sources = %s, want the added source", sources)
+	}
+
+	// An invalid file is not loaded and not announced
+	writeSources(`{"sources": {`)
+	select {
+	case <-changed:
+		t.Error("notifications/resources/list_changed sent for an invalid sources file")
+	case <-time.After(2 * time.Second):
+	}
+	if sources := readSources(); !strings.Contains(sources, `"added"`) {
+		t.Errorf("wisdom://sources = %s, want the previous sources kept", sources)
+	}
+}
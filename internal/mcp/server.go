@@ -16,6 +16,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -39,8 +40,13 @@ type WisdomServer struct {
 // NewWisdomServer creates a new wisdom MCP server instance.
 // The server must be started with Run() to begin processing requests.
 func NewWisdomServer() *WisdomServer {
-	// Initialize consultation logger (log directory: .devwisdom)
-	logger, err := logging.NewConsultationLogger(".devwisdom")
+	return newWisdomServer(".devwisdom")
+}
+
+// newWisdomServer creates a server logging consultations to logDir.
+func newWisdomServer(logDir string) *WisdomServer {
+	// Initialize consultation logger
+	logger, err := logging.NewConsultationLogger(logDir)
 	if err != nil {
 		// Log initialization failure is non-fatal - server can still work without logging
 		// In production, you might want to log this to stderr or handle it differently
@@ -64,6 +70,17 @@ func (s *WisdomServer) Run(ctx context.Context, stdin io.Reader, stdout io.Write
 		s.appLogger.Error("", "Failed to initialize wisdom engine: %v", err)
 		return fmt.Errorf("failed to initialize wisdom engine (check sources.json configuration and file permissions): %w", err)
 	}
+	if s.logger != nil {
+		// Rotate the consultation log on the configured day boundary; maintenance
+		// failures after a rotation go to the server log
+		s.logger.WithClock(s.wisdom.Clock(), s.wisdom.DayBoundary()).
+			WithLogger(s.appLogger)
+		// Delete, compress and compact rotated log files as configured
+		s.logger.WithRetention(logging.RetentionFromConfig(s.wisdom.GetConfig()))
+		if _, err := s.logger.Maintain(); err != nil {
+			s.appLogger.Warn("", "Consultation log maintenance failed: %v", err)
+		}
+	}
 
 	// Log server startup
 	s.appLogger.Info("", "MCP server v%s starting", Version)
@@ -222,6 +239,10 @@ func (s *WisdomServer) handleToolsList(req *JSONRPCRequest) *JSONRPCResponse {
 						"type":        "number",
 						"description": "Project health score (0-100)",
 					},
+					"health": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Compute the score from the health of the server's working directory (runs git; overrides score); uses the metric's own score when it is measured",
+					},
 					"context": map[string]interface{}{
 						"type":        "string",
 						"description": "Additional context for the consultation",
@@ -237,42 +258,33 @@ func (s *WisdomServer) handleToolsList(req *JSONRPCRequest) *JSONRPCResponse {
 				"properties": map[string]interface{}{
 					"score": map[string]interface{}{
 						"type":        "number",
-						"description": "Project health score (0-100)",
-						"required":    true,
+						"description": "Project health score (0-100); required unless health is set",
 					},
 					"source": map[string]interface{}{
 						"type":        "string",
-						"description": "Wisdom source ID (e.g., 'pistis_sophia', 'stoic') or 'random' for date-seeded random selection",
+						"description": "Wisdom source ID (e.g., 'pistis_sophia', 'stoic') or 'random' for date-seeded random selection; defaults to the configured source",
+					},
+					"health": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Compute the score from the health of the server's working directory (runs git; overrides score)",
 					},
 				},
-				"required": []string{"score"},
 			},
 		},
 		{
 			Name:        "get_daily_briefing",
-			Description: "Get a daily wisdom briefing with quotes and guidance",
-			InputSchema: map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"score": map[string]interface{}{
-						"type":        "number",
-						"description": "Project health score (0-100)",
-					},
-				},
-			},
+			Description: "Get a daily advisor briefing for the weakest metrics, with trends against previous consultations",
+			InputSchema: dailyBriefingInputSchema(),
 		},
 		{
 			Name:        "get_consultation_log",
-			Description: "Retrieve consultation log entries",
-			InputSchema: map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"days": map[string]interface{}{
-						"type":        "number",
-						"description": "Number of days to retrieve (default: 7)",
-					},
-				},
-			},
+			Description: consultationLogDescription,
+			InputSchema: consultationLogInputSchema(),
+		},
+		{
+			Name:        "search_quotes",
+			Description: "Search all wisdom quotes by keyword, prefix, or phrase",
+			InputSchema: searchQuotesInputSchema(),
 		},
 	}
 
@@ -324,6 +336,12 @@ func (s *WisdomServer) handleResourcesList(req *JSONRPCRequest) *JSONRPCResponse
 			Description: "List all available wisdom sources",
 			MimeType:    "application/json",
 		},
+		{
+			URI:         "wisdom://sources/status",
+			Name:        "Wisdom Source Status",
+			Description: "Load status of each wisdom source (pending, ready, failed, or stale)",
+			MimeType:    "application/json",
+		},
 		{
 			URI:         "wisdom://advisors",
 			Name:        "Wisdom Advisors",
@@ -337,9 +355,15 @@ func (s *WisdomServer) handleResourcesList(req *JSONRPCRequest) *JSONRPCResponse
 			MimeType:    "application/json",
 		},
 		{
-			URI:         "wisdom://consultations/{days}",
+			URI:         "wisdom://quote/{id}",
+			Name:        "Quote",
+			Description: "Get a specific quote by its stable ID",
+			MimeType:    "application/json",
+		},
+		{
+			URI:         consultationsURITemplate,
 			Name:        "Consultation Log",
-			Description: "Get consultation log entries for the specified number of days",
+			Description: consultationsResourceDescription,
 			MimeType:    "application/json",
 		},
 	}
@@ -369,6 +393,8 @@ func (s *WisdomServer) handleResourceRead(req *JSONRPCRequest) *JSONRPCResponse
 
 	if uri == "wisdom://tools" {
 		resp = s.handleToolsResource(req)
+	} else if uri == "wisdom://sources/status" {
+		resp = s.handleSourcesStatusResource(req)
 	} else if strings.HasPrefix(uri, "wisdom://sources") {
 		resp = s.handleSourcesResource(req)
 	} else if uri == "wisdom://advisors" {
@@ -382,13 +408,28 @@ func (s *WisdomServer) handleResourceRead(req *JSONRPCRequest) *JSONRPCResponse
 		} else {
 			resp = NewInvalidParamsError(req.ID, fmt.Sprintf("invalid advisor resource URI: expected format 'wisdom://advisor/{id}', got %q", uri))
 		}
+	} else if strings.HasPrefix(uri, "wisdom://quote/") {
+		// Handle wisdom://quote/{id}
+		quoteID := strings.TrimPrefix(uri, "wisdom://quote/")
+		if quoteID != "" {
+			resp = s.handleQuoteResource(req, quoteID)
+		} else {
+			resp = NewInvalidParamsError(req.ID, fmt.Sprintf("invalid quote resource URI: expected format 'wisdom://quote/{id}', got %q", uri))
+		}
 	} else if strings.HasPrefix(uri, "wisdom://consultations/") {
-		parts := strings.Split(uri, "/")
+		path, rawQuery, hasQuery := strings.Cut(uri, "?")
+		parts := strings.Split(path, "/")
 		if len(parts) >= 3 {
 			daysStr := parts[len(parts)-1]
 			days, err := strconv.Atoi(daysStr)
+			query, queryErr := url.ParseQuery(rawQuery)
 			if err != nil {
 				resp = NewInvalidParamsError(req.ID, fmt.Sprintf("invalid days parameter %q in URI: must be a number (got %q)", daysStr, uri))
+			} else if queryErr != nil {
+				resp = NewInvalidParamsError(req.ID, fmt.Sprintf("invalid query in consultations URI %q: %v", uri, queryErr))
+			} else if hasQuery {
+				handlers := NewWisdomHandlers(s.wisdom, s.logger, s.appLogger)
+				resp = handlers.HandleConsultationsQueryResource(req, uri, days, query)
 			} else {
 				resp = s.handleConsultationsResource(req, days)
 			}
@@ -396,7 +437,7 @@ func (s *WisdomServer) handleResourceRead(req *JSONRPCRequest) *JSONRPCResponse
 			resp = NewInvalidParamsError(req.ID, fmt.Sprintf("invalid consultations resource URI: expected format 'wisdom://consultations/{days}', got %q", uri))
 		}
 	} else {
-		resp = NewErrorResponse(req.ID, -32602, fmt.Sprintf("unknown resource URI %q. Use 'wisdom://sources', 'wisdom://advisors', 'wisdom://advisor/{id}', or 'wisdom://consultations/{days}'", uri), nil)
+		resp = NewErrorResponse(req.ID, -32602, fmt.Sprintf("unknown resource URI %q. Use 'wisdom://sources', 'wisdom://sources/status', 'wisdom://advisors', 'wisdom://advisor/{id}', 'wisdom://quote/{id}', or 'wisdom://consultations/{days}'", uri), nil)
 	}
 
 	// Log resource read completion
@@ -458,6 +499,13 @@ func (s *WisdomServer) handleSourcesResource(req *JSONRPCRequest) *JSONRPCRespon
 	return handlers.HandleSourcesResource(req)
 }
 
+// DEPRECATED: Handler methods moved to handlers.go. This delegates to handlers.go.
+// handleSourcesStatusResource returns the load status of each source
+func (s *WisdomServer) handleSourcesStatusResource(req *JSONRPCRequest) *JSONRPCResponse {
+	handlers := NewWisdomHandlers(s.wisdom, s.logger, s.appLogger)
+	return handlers.HandleSourcesStatusResource(req)
+}
+
 // DEPRECATED: Handler methods moved to handlers.go. This delegates to handlers.go.
 // handleAdvisorsResource returns all advisors
 func (s *WisdomServer) handleAdvisorsResource(req *JSONRPCRequest) *JSONRPCResponse {
@@ -472,6 +520,13 @@ func (s *WisdomServer) handleAdvisorResource(req *JSONRPCRequest, advisorID stri
 	return handlers.HandleAdvisorResource(req, advisorID)
 }
 
+// DEPRECATED: Handler methods moved to handlers.go. This delegates to handlers.go.
+// handleQuoteResource returns a quote by ID
+func (s *WisdomServer) handleQuoteResource(req *JSONRPCRequest, quoteID string) *JSONRPCResponse {
+	handlers := NewWisdomHandlers(s.wisdom, s.logger, s.appLogger)
+	return handlers.HandleQuoteResource(req, quoteID)
+}
+
 // DEPRECATED: Handler methods moved to handlers.go. This delegates to handlers.go.
 // handleConsultationsResource returns consultation log entries
 func (s *WisdomServer) handleConsultationsResource(req *JSONRPCRequest, days int) *JSONRPCResponse {
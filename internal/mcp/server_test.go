@@ -7,9 +7,23 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/davidl71/devwisdom-go/internal/health"
+	"github.com/davidl71/devwisdom-go/internal/logging"
 	"github.com/davidl71/devwisdom-go/internal/wisdom"
 )
 
+// newTestServer returns a server logging consultations to a temp directory,
+// so tests leave no .devwisdom behind.
+func newTestServer(t *testing.T) *WisdomServer {
+	t.Helper()
+	server := newWisdomServer(t.TempDir())
+	if server.logger == nil {
+		t.Fatal("consultation logger unavailable")
+	}
+	t.Cleanup(func() { server.logger.Close() })
+	return server
+}
+
 func TestNewWisdomServer(t *testing.T) {
 	server := NewWisdomServer()
 	if server == nil {
@@ -376,3 +390,233 @@ func TestWisdomServer_HandleGetDailyBriefing(t *testing.T) {
 		t.Log("Response quotes array is empty (may be acceptable)")
 	}
 }
+
+func TestWisdomHandlers_HealthScore(t *testing.T) {
+	engine := wisdom.NewEngine()
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	consultationLog, err := logging.NewConsultationLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer consultationLog.Close()
+	handlers := NewWisdomHandlers(engine, consultationLog, logging.NewLogger())
+
+	// The test runs inside this repository, as the server runs in its project
+	report, err := health.NewAnalyzer(".").Analyze(context.Background())
+	if err != nil {
+		t.Skipf("health analysis unavailable: %v", err)
+	}
+	metric, ok := report.Metric("testing")
+	if !ok {
+		t.Fatalf("report has no testing metric: %+v", report.Metrics)
+	}
+
+	result, err := handlers.HandleToolCall("consult_advisor", map[string]interface{}{"metric": "testing", "score": 5.0, "health": true})
+	if err != nil {
+		t.Fatalf("consult_advisor failed: %v", err)
+	}
+	if consultation := result.(wisdom.Consultation); consultation.ScoreAtTime != metric.Score {
+		t.Errorf("ScoreAtTime = %v, want the testing health score %v", consultation.ScoreAtTime, metric.Score)
+	}
+
+	if _, err := handlers.HandleToolCall("get_wisdom", map[string]interface{}{"health": true}); err != nil {
+		t.Errorf("get_wisdom with health and no score failed: %v", err)
+	}
+	if _, err := handlers.HandleToolCall("get_wisdom", map[string]interface{}{}); err == nil {
+		t.Error("get_wisdom without score or health should fail")
+	}
+}
+
+func TestWisdomHandlers_GetDailyBriefing_Scores(t *testing.T) {
+	engine := wisdom.NewEngine()
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	consultationLog, err := logging.NewConsultationLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewConsultationLogger failed: %v", err)
+	}
+	defer consultationLog.Close()
+	handlers := NewWisdomHandlers(engine, consultationLog, logging.NewLogger())
+
+	briefing := func(params map[string]interface{}) map[string]interface{} {
+		t.Helper()
+		result, err := handlers.HandleToolCall("get_daily_briefing", params)
+		if err != nil {
+			t.Fatalf("get_daily_briefing failed: %v", err)
+		}
+		return result.(map[string]interface{})
+	}
+
+	first := briefing(map[string]interface{}{
+		"scores": map[string]interface{}{"security": 40.0, "testing": 30.0, "documentation": 90.0},
+		"limit":  2.0,
+	})
+	entries := first["advisory_quotes"].([]wisdom.BriefingEntry)
+	if len(entries) != 2 || entries[0].Metric != "testing" || entries[1].Metric != "security" {
+		t.Fatalf("advisory_quotes = %+v, want testing and security", entries)
+	}
+	if entries[0].Trend != nil {
+		t.Errorf("testing trend = %+v, want none without consultations", entries[0].Trend)
+	}
+	if first["score"] != 53.3 {
+		t.Errorf("score = %v, want mean of metric scores 53.3", first["score"])
+	}
+
+	// Trends compare against advisor consultations, not earlier briefings
+	if _, err := handlers.HandleToolCall("consult_advisor", map[string]interface{}{"metric": "testing", "score": 30.0}); err != nil {
+		t.Fatalf("consult_advisor failed: %v", err)
+	}
+	second := briefing(map[string]interface{}{"scores": map[string]interface{}{"testing": 10.0}})
+	entries = second["advisory_quotes"].([]wisdom.BriefingEntry)
+	if entries[0].Metric != "testing" || entries[0].Trend == nil || entries[0].Trend.Direction != wisdom.TrendDeclining {
+		t.Errorf("testing entry = %+v, want declining trend", entries[0])
+	}
+	if logs, err := consultationLog.GetLogs(1); err != nil || len(logs) != 1 {
+		t.Errorf("GetLogs() = %d entries, %v; want only the advisor consultation", len(logs), err)
+	}
+
+	if _, err := handlers.HandleToolCall("get_daily_briefing", map[string]interface{}{"scores_file": "/etc/passwd"}); err == nil {
+		t.Error("scores_file should be rejected")
+	}
+
+	if _, err := handlers.HandleToolCall("get_daily_briefing", map[string]interface{}{"scores": map[string]interface{}{"testing": 200.0}}); err == nil {
+		t.Error("out-of-range score should fail")
+	}
+}
+
+func TestWisdomHandlers_SearchQuotes(t *testing.T) {
+	engine := wisdom.NewEngine()
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	handlers := NewWisdomHandlers(engine, nil, logging.NewLogger())
+
+	result, err := handlers.HandleToolCall("search_quotes", map[string]interface{}{
+		"query":  "way",
+		"source": "stoic",
+		"limit":  2.0,
+	})
+	if err != nil {
+		t.Fatalf("search_quotes failed: %v", err)
+	}
+	response := result.(map[string]interface{})
+	results := response["results"].([]wisdom.SearchResult)
+	if len(results) == 0 || len(results) > 2 {
+		t.Fatalf("got %d results, want 1-2", len(results))
+	}
+	for _, r := range results {
+		if r.SourceID != "stoic" {
+			t.Errorf("result from source %q, want stoic", r.SourceID)
+		}
+	}
+
+	if _, err := handlers.HandleToolCall("search_quotes", map[string]interface{}{}); err == nil {
+		t.Error("search_quotes without query should fail")
+	}
+}
+
+func TestWisdomServer_HandleQuoteResource(t *testing.T) {
+	server := newTestServer(t)
+	if err := server.wisdom.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	quote, err := server.wisdom.GetWisdom(75, "stoic")
+	if err != nil {
+		t.Fatalf("GetWisdom failed: %v", err)
+	}
+
+	read := func(uri string) *JSONRPCResponse {
+		return server.handleRequest(&JSONRPCRequest{
+			JSONRPC: "2.0",
+			ID:      11,
+			Method:  "resources/read",
+			Params:  json.RawMessage(`{"uri": "` + uri + `"}`),
+		})
+	}
+
+	resp := read("wisdom://quote/" + quote.ID)
+	if resp.Error != nil {
+		t.Fatalf("reading quote resource failed: %v", resp.Error.Message)
+	}
+	contents := resp.Result.(map[string]interface{})["contents"].([]map[string]interface{})
+	var got wisdom.Quote
+	if err := json.Unmarshal([]byte(contents[0]["text"].(string)), &got); err != nil {
+		t.Fatalf("invalid quote JSON: %v", err)
+	}
+	if got.ID != quote.ID || got.Quote != quote.Quote {
+		t.Errorf("quote resource = %+v, want %+v", got, quote)
+	}
+
+	if resp := read("wisdom://quote/stoic-000000000000"); resp.Error == nil {
+		t.Error("reading an unknown quote should fail")
+	}
+}
+
+func TestWisdomServer_HandleSourcesStatusResource(t *testing.T) {
+	server := newTestServer(t)
+	if err := server.wisdom.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	resp := server.handleRequest(&JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      12,
+		Method:  "resources/read",
+		Params:  json.RawMessage(`{"uri": "wisdom://sources/status"}`),
+	})
+	if resp.Error != nil {
+		t.Fatalf("reading sources status resource failed: %v", resp.Error.Message)
+	}
+	contents := resp.Result.(map[string]interface{})["contents"].([]map[string]interface{})
+	var statuses []wisdom.SourceStatus
+	if err := json.Unmarshal([]byte(contents[0]["text"].(string)), &statuses); err != nil {
+		t.Fatalf("invalid status JSON: %v", err)
+	}
+	for _, status := range statuses {
+		if status.ID == "stoic" {
+			if status.State != wisdom.SourceReady || status.Remote {
+				t.Errorf("stoic status = %+v, want ready local source", status)
+			}
+			return
+		}
+	}
+	t.Errorf("stoic missing from source statuses: %+v", statuses)
+}
+
+func TestWisdomServer_HandleSourcesResource_Provenance(t *testing.T) {
+	server := newTestServer(t)
+	if err := server.wisdom.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	resp := server.handleRequest(&JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      13,
+		Method:  "resources/read",
+		Params:  json.RawMessage(`{"uri": "wisdom://sources"}`),
+	})
+	if resp.Error != nil {
+		t.Fatalf("reading sources resource failed: %v", resp.Error.Message)
+	}
+	contents := resp.Result.(map[string]interface{})["contents"].([]map[string]interface{})
+	var sources []struct {
+		ID         string                   `json:"id"`
+		Provenance *wisdom.SourceProvenance `json:"provenance"`
+	}
+	if err := json.Unmarshal([]byte(contents[0]["text"].(string)), &sources); err != nil {
+		t.Fatalf("invalid sources JSON: %v", err)
+	}
+	for _, source := range sources {
+		if source.ID == "stoic" {
+			if source.Provenance == nil || !strings.HasSuffix(source.Provenance.Origin, "sources.json") {
+				t.Errorf("stoic provenance = %+v, want its sources.json", source.Provenance)
+			}
+			return
+		}
+	}
+	t.Errorf("stoic missing from sources: %+v", sources)
+}
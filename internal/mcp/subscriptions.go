@@ -0,0 +1,120 @@
+// Package mcp provides the Model Context Protocol (MCP) server implementation.
+// This file contains resource subscriptions (resources/subscribe).
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Resources clients can subscribe to.
+const (
+	sourcesURI             = "wisdom://sources"        // Updated when sources are reloaded or fetched
+	consultationsURIPrefix = "wisdom://consultations/" // Followed by days; updated when a consultation is logged
+)
+
+// subscribe accepts a subscription to wisdom://sources or
+// wisdom://consultations/{days}. The SDK tracks which sessions subscribed to
+// a URI; the server keeps the same sessions to know which consultations URIs
+// to notify.
+func (s *WisdomServerSDK) subscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	if err := validateSubscription(uri); err != nil {
+		return err
+	}
+
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+	if s.subscriptions[uri] == nil {
+		s.subscriptions[uri] = make(map[*mcp.ServerSession]bool)
+	}
+	s.subscriptions[uri][req.Session] = true
+	return nil
+}
+
+// unsubscribe removes a subscription added by subscribe.
+func (s *WisdomServerSDK) unsubscribe(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+	uri := req.Params.URI
+
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+	delete(s.subscriptions[uri], req.Session)
+	if len(s.subscriptions[uri]) == 0 {
+		delete(s.subscriptions, uri)
+	}
+	return nil
+}
+
+// validateSubscription checks that a resource URI supports subscriptions.
+func validateSubscription(uri string) error {
+	if uri == sourcesURI {
+		return nil
+	}
+	if rest, ok := strings.CutPrefix(uri, consultationsURIPrefix); ok {
+		days, _, _ := strings.Cut(rest, "?") // Filtered views are notified like the whole log
+		if n, err := strconv.Atoi(days); err != nil || n < 1 {
+			return fmt.Errorf("invalid consultations URI %q: days must be a positive integer (e.g., %s7)", uri, consultationsURIPrefix)
+		}
+		return nil
+	}
+	return fmt.Errorf("resource %q does not support subscriptions (subscribe to %s or %s{days})", uri, sourcesURI, consultationsURIPrefix)
+}
+
+// subscribedURIs returns the subscribed URIs starting with prefix, sorted.
+// Subscriptions of sessions that closed without unsubscribing are dropped.
+func (s *WisdomServerSDK) subscribedURIs(prefix string) []string {
+	open := make(map[*mcp.ServerSession]bool)
+	for session := range s.server.Sessions() {
+		open[session] = true
+	}
+
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+
+	var uris []string
+	for uri, sessions := range s.subscriptions {
+		for session := range sessions {
+			if !open[session] {
+				delete(sessions, session)
+			}
+		}
+		if len(sessions) == 0 {
+			delete(s.subscriptions, uri)
+			continue
+		}
+		if strings.HasPrefix(uri, prefix) {
+			uris = append(uris, uri)
+		}
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// sourceUpdated notifies subscribers of wisdom://sources when a source
+// fetched in the background (e.g. Sefaria) has loaded its quotes.
+func (s *WisdomServerSDK) sourceUpdated(string) {
+	s.resourceUpdated(sourcesURI)
+}
+
+// consultationLogged notifies subscribers of every consultations resource,
+// as a new entry falls within any number of days.
+func (s *WisdomServerSDK) consultationLogged(*wisdom.Consultation) {
+	for _, uri := range s.subscribedURIs(consultationsURIPrefix) {
+		s.resourceUpdated(uri)
+	}
+}
+
+// resourceUpdated sends notifications/resources/updated to the clients
+// subscribed to uri, if any.
+func (s *WisdomServerSDK) resourceUpdated(uri string) {
+	params := &mcp.ResourceUpdatedNotificationParams{URI: uri}
+	if err := s.server.ResourceUpdated(context.Background(), params); err != nil {
+		s.appLogger.Warn("", "Failed to notify subscribers of %s: %v", uri, err)
+	}
+}
@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newTestServerSDK returns a server logging consultations to a temp directory,
+// so tests leave no .devwisdom behind.
+func newTestServerSDK(t *testing.T) *WisdomServerSDK {
+	t.Helper()
+	server := newWisdomServerSDK(t.TempDir())
+	if server.logger == nil {
+		t.Fatal("consultation logger unavailable")
+	}
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+// connectSubscriber connects a client to a server logging to a temp directory
+// and returns the URIs of the resources/updated notifications it receives.
+func connectSubscriber(t *testing.T, ctx context.Context) (*WisdomServerSDK, *mcp.ClientSession, <-chan string) {
+	t.Helper()
+	server := newTestServerSDK(t)
+	if err := server.prepare(); err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := server.server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server Connect failed: %v", err)
+	}
+	updated := make(chan string, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect failed: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return server, session, updated
+}
+
+// expectUpdate waits for a resources/updated notification for uri.
+func expectUpdate(t *testing.T, updated <-chan string, uri string) {
+	t.Helper()
+	select {
+	case got := <-updated:
+		if got != uri {
+			t.Errorf("resources/updated for %s, want %s", got, uri)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no resources/updated notification for %s", uri)
+	}
+}
+
+// expectNoUpdate checks that no resources/updated notification arrives.
+func expectNoUpdate(t *testing.T, updated <-chan string) {
+	t.Helper()
+	select {
+	case uri := <-updated:
+		t.Errorf("unexpected resources/updated notification for %s", uri)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestSubscriptions_Capability(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, session, _ := connectSubscriber(t, ctx)
+
+	resources := session.InitializeResult().Capabilities.Resources
+	if resources == nil || !resources.Subscribe {
+		t.Errorf("resources capability = %+v, want subscribe", resources)
+	}
+}
+
+func TestSubscriptions_Consultations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, session, updated := connectSubscriber(t, ctx)
+
+	consult := func() {
+		t.Helper()
+		_, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      "consult_advisor",
+			Arguments: map[string]interface{}{"metric": "security", "score": 60},
+		})
+		if err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+	}
+
+	for _, uri := range []string{"wisdom://consultations/7", "wisdom://consultations/30"} {
+		if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+			t.Fatalf("Subscribe(%s) failed: %v", uri, err)
+		}
+	}
+	consult()
+	expectUpdate(t, updated, "wisdom://consultations/30")
+	expectUpdate(t, updated, "wisdom://consultations/7")
+
+	// Reading the resource after the notification includes the new entry
+	result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "wisdom://consultations/7"})
+	if err != nil || len(result.Contents) == 0 || result.Contents[0].Text == "[]" || result.Contents[0].Text == "null" {
+		t.Errorf("ReadResource() = %+v, %v; want the logged consultation", result, err)
+	}
+
+	for _, uri := range []string{"wisdom://consultations/7", "wisdom://consultations/30"} {
+		if err := session.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: uri}); err != nil {
+			t.Fatalf("Unsubscribe(%s) failed: %v", uri, err)
+		}
+	}
+	consult()
+	expectNoUpdate(t, updated)
+}
+
+func TestSubscriptions_ClosedSession(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	server, session, _ := connectSubscriber(t, ctx)
+
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: "wisdom://consultations/7"}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if uris := server.subscribedURIs(consultationsURIPrefix); len(uris) != 1 {
+		t.Fatalf("subscribed URIs = %v, want wisdom://consultations/7", uris)
+	}
+
+	// A session closed without unsubscribing no longer counts
+	session.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(server.subscribedURIs("")) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("subscribed URIs = %v after the session closed, want none", server.subscribedURIs(""))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(server.subscriptions) != 0 {
+		t.Errorf("subscriptions = %v, want none kept", server.subscriptions)
+	}
+}
+
+func TestSubscriptions_Sources(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	server, session, updated := connectSubscriber(t, ctx)
+
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: "wisdom://sources"}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	// Rejected reloads keep the sources unchanged
+	server.sourcesReloaded(wisdom.ReloadEvent{Changed: []string{"sources.json"}, Err: context.Canceled})
+	expectNoUpdate(t, updated)
+
+	server.sourcesReloaded(wisdom.ReloadEvent{Changed: []string{"sources.json"}})
+	expectUpdate(t, updated, "wisdom://sources")
+
+	// A source fetched in the background (e.g. Sefaria) changes the sources too
+	server.sourceUpdated("sefaria")
+	expectUpdate(t, updated, "wisdom://sources")
+}
+
+func TestSubscriptions_InvalidURI(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	server, session, _ := connectSubscriber(t, ctx)
+
+	for _, uri := range []string{"wisdom://advisors", "wisdom://consultations/", "wisdom://consultations/week", "wisdom://consultations/0"} {
+		if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err == nil {
+			t.Errorf("Subscribe(%s) succeeded, want an error", uri)
+		}
+	}
+	if uris := server.subscribedURIs(""); len(uris) != 0 {
+		t.Errorf("subscribed URIs = %v, want none", uris)
+	}
+}
@@ -283,7 +283,7 @@ func (r *AdvisorRegistry) GetAdvisorForMetric(metric string) (*AdvisorInfo, erro
 		for m := range r.metricAdvisors {
 			availableMetrics = append(availableMetrics, m)
 		}
-		return nil, fmt.Errorf("no advisor found for metric %q (available metrics: %v). Check metric name spelling", metric, availableMetrics)
+		return nil, fmt.Errorf("%w for metric %q (available metrics: %v). Check metric name spelling", ErrUnknownAdvisor, metric, availableMetrics)
 	}
 	return advisor, nil
 }
@@ -296,7 +296,7 @@ func (r *AdvisorRegistry) GetAdvisorForTool(tool string) (*AdvisorInfo, error) {
 		for t := range r.toolAdvisors {
 			availableTools = append(availableTools, t)
 		}
-		return nil, fmt.Errorf("no advisor found for tool %q (available tools: %v). Check tool name spelling", tool, availableTools)
+		return nil, fmt.Errorf("%w for tool %q (available tools: %v). Check tool name spelling", ErrUnknownAdvisor, tool, availableTools)
 	}
 	return advisor, nil
 }
@@ -309,7 +309,7 @@ func (r *AdvisorRegistry) GetAdvisorForStage(stage string) (*AdvisorInfo, error)
 		for s := range r.stageAdvisors {
 			availableStages = append(availableStages, s)
 		}
-		return nil, fmt.Errorf("no advisor found for stage %q (available stages: %v). Check stage name spelling", stage, availableStages)
+		return nil, fmt.Errorf("%w for stage %q (available stages: %v). Check stage name spelling", ErrUnknownAdvisor, stage, availableStages)
 	}
 	return advisor, nil
 }
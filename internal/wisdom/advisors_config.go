@@ -0,0 +1,151 @@
+package wisdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// AdvisorsConfig represents an advisors.json file mapping metrics, tools, and
+// stages to advisors. Each advisor must be the ID of a loaded wisdom source.
+type AdvisorsConfig struct {
+	Version string                  `json:"version"`
+	Metrics map[string]*AdvisorInfo `json:"metrics,omitempty"`
+	Tools   map[string]*AdvisorInfo `json:"tools,omitempty"`
+	Stages  map[string]*AdvisorInfo `json:"stages,omitempty"`
+}
+
+// LoadAdvisorsConfig reads an advisors.json file.
+func LoadAdvisorsConfig(path string) (*AdvisorsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read advisors config file %q: %w", path, err)
+	}
+
+	var advisorsConfig AdvisorsConfig
+	if err := json.Unmarshal(data, &advisorsConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse advisors config file %q (invalid JSON): %w", path, err)
+	}
+	return &advisorsConfig, nil
+}
+
+// ApplyConfig adds or overrides advisor mappings from an advisors.json file.
+// The built-in mappings are loaded first, so entries not mentioned in the file
+// keep their defaults. Entries whose advisor is not a loaded source are skipped
+// and reported in the returned errors. A missing icon or language is taken
+// from the advisor's source.
+func (r *AdvisorRegistry) ApplyConfig(config *AdvisorsConfig, path string, lookupSource func(id string) (*Source, bool)) []error {
+	if !r.initialized {
+		r.Initialize()
+	}
+
+	var errs []error
+	apply := func(kind string, target map[string]*AdvisorInfo, entries map[string]*AdvisorInfo) {
+		// Sorted for deterministic error order
+		names := make([]string, 0, len(entries))
+		for name := range entries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			entry := entries[name]
+			if entry == nil || entry.Advisor == "" {
+				errs = append(errs, fmt.Errorf("%s: %s %q has no advisor", path, kind, name))
+				continue
+			}
+			source, found := lookupSource(entry.Advisor)
+			if !found {
+				errs = append(errs, fmt.Errorf("%s: %s %q: %w %q. Use 'devwisdom sources' to list available sources", path, kind, name, ErrUnknownSource, entry.Advisor))
+				continue
+			}
+
+			info := *entry
+			if info.Icon == "" {
+				info.Icon = source.Icon
+			}
+			if info.Language == "" {
+				info.Language = source.Language
+			}
+			target[name] = &info
+		}
+	}
+
+	apply("metric", r.metricAdvisors, config.Metrics)
+	apply("tool", r.toolAdvisors, config.Tools)
+	apply("stage", r.stageAdvisors, config.Stages)
+
+	return errs
+}
+
+// AdvisorConfigPaths returns the advisors.json locations next to the sources.json
+// search path, from lowest to highest priority: global (XDG, home), explicit
+// config paths, current working directory, then the project root.
+// Later files override earlier ones, so project entries override global ones.
+func (sl *SourceLoader) AdvisorConfigPaths() []string {
+	return sl.configFilePaths("advisors.json")
+}
+
+// configFilePaths returns the locations of a configuration file named name,
+// from lowest to highest priority (see AdvisorConfigPaths).
+func (sl *SourceLoader) configFilePaths(name string) []string {
+	var dirs []string
+
+	// GLOBAL (lowest priority)
+	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
+		dirs = append(dirs, filepath.Join(xdgConfig, "wisdom"))
+	} else if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".config", "wisdom"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs,
+			filepath.Join(home, ".exarp_wisdom"),
+			filepath.Join(home, ".wisdom"),
+		)
+	}
+
+	// Explicit config paths
+	for _, path := range sl.configPaths {
+		dirs = append(dirs, filepath.Dir(path))
+	}
+
+	// Current working directory
+	if cwd, err := os.Getwd(); err == nil && cwd != sl.projectRoot {
+		dirs = append(dirs,
+			filepath.Join(cwd, "wisdom"),
+			cwd,
+			filepath.Join(cwd, ".wisdom"),
+		)
+	}
+
+	// PROJECT (highest priority)
+	if sl.projectRoot != "" {
+		dirs = append(dirs,
+			filepath.Join(sl.projectRoot, "wisdom"),
+			sl.projectRoot,
+			filepath.Join(sl.projectRoot, ".wisdom"),
+		)
+	}
+
+	// Deduplicate, keeping the highest-priority (last) position
+	paths := make([]string, 0, len(dirs))
+	seen := make(map[string]bool, len(dirs))
+	for i := len(dirs) - 1; i >= 0; i-- {
+		path := filepath.Join(dirs[i], name)
+		key := path
+		if abs, err := filepath.Abs(path); err == nil {
+			key = abs
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		paths = append(paths, path)
+	}
+	for i, j := 0, len(paths)-1; i < j; i, j = i+1, j-1 {
+		paths[i], paths[j] = paths[j], paths[i]
+	}
+	return paths
+}
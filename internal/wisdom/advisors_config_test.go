@@ -0,0 +1,141 @@
+package wisdom
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAdvisorRegistry_ApplyConfig(t *testing.T) {
+	registry := NewAdvisorRegistry()
+	sources := map[string]*Source{
+		"stoic":      {Name: "Stoics", Icon: "🏛️"},
+		"art_of_war": {Name: "Art of War", Icon: "⚔️"},
+	}
+	lookup := func(id string) (*Source, bool) {
+		source, exists := sources[id]
+		return source, exists
+	}
+
+	errs := registry.ApplyConfig(&AdvisorsConfig{
+		Metrics: map[string]*AdvisorInfo{
+			"performance": {Advisor: "art_of_war", Rationale: "Speed is the essence of war"},
+			"security":    {Advisor: "stoic", Icon: "🛡️", Rationale: "Calm vigilance"},
+			"missing":     {Advisor: "nonexistent"},
+			"empty":       {},
+		},
+		Tools: map[string]*AdvisorInfo{
+			"load_test": {Advisor: "art_of_war"},
+		},
+	}, "advisors.json", lookup)
+
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+	if !errors.Is(errs[1], ErrUnknownSource) {
+		t.Errorf("error for unknown advisor = %v, want ErrUnknownSource", errs[1])
+	}
+
+	performance, err := registry.GetAdvisorForMetric("performance")
+	if err != nil {
+		t.Fatalf("new metric not added: %v", err)
+	}
+	if performance.Icon != "⚔️" {
+		t.Errorf("Icon = %q, want icon inherited from source", performance.Icon)
+	}
+
+	security, _ := registry.GetAdvisorForMetric("security")
+	if security.Advisor != "stoic" || security.Icon != "🛡️" {
+		t.Errorf("security advisor = %+v, want override to stoic", security)
+	}
+
+	// Built-in mappings remain as defaults
+	if builtin, err := registry.GetAdvisorForMetric("testing"); err != nil || builtin.Advisor != "stoic" {
+		t.Errorf("built-in testing advisor lost: %+v, %v", builtin, err)
+	}
+	if _, err := registry.GetAdvisorForMetric("missing"); err == nil {
+		t.Error("invalid entry should be skipped")
+	}
+	if _, err := registry.GetAdvisorForTool("load_test"); err != nil {
+		t.Errorf("new tool not added: %v", err)
+	}
+}
+
+func TestSourceLoader_AdvisorConfigPaths(t *testing.T) {
+	home := t.TempDir()
+	xdg := t.TempDir()
+	root := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	paths := NewSourceLoader().WithProjectRoot(root).AdvisorConfigPaths()
+	if len(paths) == 0 {
+		t.Fatal("AdvisorConfigPaths returned no paths")
+	}
+
+	if want := filepath.Join(xdg, "wisdom", "advisors.json"); paths[0] != want {
+		t.Errorf("lowest priority path = %q, want %q", paths[0], want)
+	}
+	if want := filepath.Join(root, ".wisdom", "advisors.json"); paths[len(paths)-1] != want {
+		t.Errorf("highest priority path = %q, want %q", paths[len(paths)-1], want)
+	}
+}
+
+func TestEngine_AdvisorsConfig_ProjectOverridesGlobal(t *testing.T) {
+	home := t.TempDir()
+	root := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	quotes := `{"chaos": [{"quote": "q", "source": "s", "encouragement": "e"}]}`
+	writeFile(filepath.Join(root, ".wisdom", "sources.json"), `{
+  "version": "1.0",
+  "sources": {
+    "stoic": {"name": "Stoics", "icon": "🏛️", "quotes": `+quotes+`},
+    "tao": {"name": "Tao", "icon": "☯️", "quotes": `+quotes+`},
+    "art_of_war": {"name": "Art of War", "icon": "⚔️", "quotes": `+quotes+`}
+  }
+}`)
+	writeFile(filepath.Join(home, ".wisdom", "advisors.json"), `{
+  "metrics": {
+    "performance": {"advisor": "stoic", "rationale": "global"},
+    "observability": {"advisor": "tao", "rationale": "global"}
+  }
+}`)
+	writeFile(filepath.Join(root, ".wisdom", "advisors.json"), `{
+  "metrics": {
+    "performance": {"advisor": "art_of_war", "rationale": "project"},
+    "accessibility": {"advisor": "nonexistent"}
+  }
+}`)
+
+	engine := NewEngine().WithLoader(NewSourceLoader().WithProjectRoot(root))
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	advisors := engine.GetAdvisors()
+
+	performance, err := advisors.GetAdvisorForMetric("performance")
+	if err != nil || performance.Rationale != "project" {
+		t.Errorf("performance advisor = %+v, %v; want project entry", performance, err)
+	}
+	if observability, err := advisors.GetAdvisorForMetric("observability"); err != nil || observability.Advisor != "tao" {
+		t.Errorf("observability advisor = %+v, %v; want global entry", observability, err)
+	}
+	if _, err := advisors.GetAdvisorForMetric("accessibility"); err == nil {
+		t.Error("entry with unknown source should be skipped")
+	}
+	if errs := engine.AdvisorConfigErrors(); len(errs) != 1 {
+		t.Errorf("AdvisorConfigErrors = %v, want 1 error", errs)
+	}
+}
@@ -0,0 +1,147 @@
+package wisdom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AeonOverrides represents an aeon_overrides.json file placing quotes on aeon
+// levels by reference, correcting the classifier. Keys are quote references
+// as attributed in quotes (e.g. "Psalms 23:4") or Sefaria references
+// ("Psalms.23.4"); a chapter or book reference applies to all its verses.
+// Values are aeon level names or 0-100 scores.
+type AeonOverrides struct {
+	Version   string                 `json:"version"`
+	Overrides map[string]interface{} `json:"overrides"`
+}
+
+// LoadAeonOverrides reads an aeon_overrides.json file. It fails if any entry
+// is not an aeon level name or score.
+func LoadAeonOverrides(path string) (*AeonOverrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aeon overrides file %q: %w", path, err)
+	}
+
+	var overrides AeonOverrides
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse aeon overrides file %q (invalid JSON): %w", path, err)
+	}
+
+	refs := make([]string, 0, len(overrides.Overrides))
+	for ref := range overrides.Overrides {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		if aeonLevelOf(overrides.Overrides[ref]) == "" {
+			return nil, fmt.Errorf("%s: override for %q must be an aeon level (%s) or a 0-100 score, got %v",
+				path, ref, strings.Join(aeonLevels, ", "), overrides.Overrides[ref])
+		}
+	}
+	return &overrides, nil
+}
+
+// AeonOverridePaths returns the aeon_overrides.json locations, from lowest to
+// highest priority (see AdvisorConfigPaths).
+func (sl *SourceLoader) AeonOverridePaths() []string {
+	return sl.configFilePaths("aeon_overrides.json")
+}
+
+// AeonOverrideErrors returns the problems found in aeon_overrides.json files
+// when sources were last loaded. Invalid files are ignored.
+func (sl *SourceLoader) AeonOverrideErrors() []error {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return append([]error(nil), sl.overrideErrs...)
+}
+
+// WithClassifier sets the classifier placing fetched quotes on aeon levels
+// (default: NewLexiconClassifier). Entries of aeon_overrides.json files take
+// precedence over it.
+func (sl *SourceLoader) WithClassifier(classifier AeonClassifier) *SourceLoader {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.classifier = classifier
+	return sl
+}
+
+// loadAeonOverrides reads the aeon_overrides.json files; later files override
+// earlier ones. The caller must hold sl.mu.
+func (sl *SourceLoader) loadAeonOverrides() {
+	sl.overrides = make(map[string]string)
+	sl.overrideErrs = nil
+	for _, path := range sl.AeonOverridePaths() {
+		overrides, err := LoadAeonOverrides(path)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				sl.overrideErrs = append(sl.overrideErrs, err)
+			}
+			continue
+		}
+		for ref, value := range overrides.Overrides {
+			sl.overrides[normalizeRef(ref)] = aeonLevelOf(value)
+		}
+	}
+}
+
+// aeonClassifier returns the loader's classifier with its aeon overrides
+// applied. The caller must hold sl.mu.
+func (sl *SourceLoader) aeonClassifier() AeonClassifier {
+	if len(sl.overrides) == 0 {
+		return sl.classifier
+	}
+	return &overrideClassifier{overrides: sl.overrides, next: sl.classifier}
+}
+
+// overrideClassifier places quotes listed in aeon overrides, deferring to
+// next for all others.
+type overrideClassifier struct {
+	overrides map[string]string // Normalized reference to aeon level
+	next      AeonClassifier
+}
+
+// Classify implements AeonClassifier. The most specific matching reference
+// wins, e.g. "Psalms 23:4" over "Psalms 23".
+func (c *overrideClassifier) Classify(quote *Quote) Classification {
+	ref := normalizeRef(quote.Source)
+	for ref != "" {
+		if level, exists := c.overrides[ref]; exists {
+			return Classification{AeonLevel: level, Confidence: 1, Override: true}
+		}
+		ref = parentRef(ref)
+	}
+	return c.next.Classify(quote)
+}
+
+// sefariaAddress matches the dotted address of a Sefaria reference, e.g.
+// the ".23.4" of "Psalms.23.4".
+var sefariaAddress = regexp.MustCompile(`\.(\d+(?:\.\d+)*(?:-\d+)?)$`)
+
+// normalizeRef returns a comparable form of a text reference: Sefaria
+// references ("Pirkei_Avot.2.1") become attributions ("pirkei avot 2:1").
+func normalizeRef(ref string) string {
+	ref = strings.ReplaceAll(strings.TrimSpace(ref), "_", " ")
+	if m := sefariaAddress.FindStringSubmatchIndex(ref); m != nil {
+		ref = ref[:m[0]] + " " + strings.ReplaceAll(ref[m[2]:m[3]], ".", ":")
+	}
+	return strings.ToLower(strings.Join(strings.Fields(ref), " "))
+}
+
+// parentRef returns the reference containing ref ("psalms 23" for
+// "psalms 23:4", "psalms" for "psalms 23"), or "" if there is none.
+func parentRef(ref string) string {
+	if i := strings.LastIndex(ref, ":"); i > 0 {
+		return ref[:i]
+	}
+	if i := strings.LastIndex(ref, " "); i > 0 && strings.Trim(ref[i+1:], "0123456789") == "" {
+		return ref[:i]
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package wisdom
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeRef(t *testing.T) {
+	tests := map[string]string{
+		"Psalms 23:4":      "psalms 23:4",
+		"Psalms.23.4":      "psalms 23:4",
+		"Pirkei_Avot.2":    "pirkei avot 2",
+		"Proverbs.3.5-6":   "proverbs 3:5-6",
+		"  Pirkei  Avot  ": "pirkei avot",
+	}
+	for ref, want := range tests {
+		if got := normalizeRef(ref); got != want {
+			t.Errorf("normalizeRef(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestOverrideClassifier_MostSpecificRef(t *testing.T) {
+	classifier := &overrideClassifier{
+		overrides: map[string]string{
+			"psalms":      "treasury",
+			"psalms 23":   "middle_aeons",
+			"psalms 23:4": "chaos",
+		},
+		next: NewLexiconClassifier(),
+	}
+	tests := map[string]string{
+		"Psalms 23:4":  "chaos",
+		"Psalms 23:1":  "middle_aeons",
+		"Psalms 150:1": "treasury",
+	}
+	for ref, want := range tests {
+		got := classifier.Classify(&Quote{Quote: "Text", Source: ref})
+		if got.AeonLevel != want || !got.Override || got.Confidence != 1 {
+			t.Errorf("Classify(%q) = %+v, want override to %q", ref, got, want)
+		}
+	}
+
+	// Unlisted references fall through to the classifier
+	if got := classifier.Classify(&Quote{Quote: "Rejoice!", Source: "Proverbs 1:1"}); got.Override || got.AeonLevel != "treasury" {
+		t.Errorf("Classify(unlisted) = %+v, want the lexicon classification", got)
+	}
+}
+
+func TestLoadAeonOverrides(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`{"version": "1.0", "overrides": {"Psalms 23:4": "chaos", "Psalms 150": 95}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	overrides, err := LoadAeonOverrides(valid)
+	if err != nil {
+		t.Fatalf("LoadAeonOverrides failed: %v", err)
+	}
+	if len(overrides.Overrides) != 2 {
+		t.Errorf("Overrides = %v, want 2 entries", overrides.Overrides)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"overrides": {"Psalms 1": "paradise"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAeonOverrides(invalid); err == nil || !strings.Contains(err.Error(), "Psalms 1") {
+		t.Errorf("LoadAeonOverrides error = %v, want an invalid entry error", err)
+	}
+}
+
+func TestSourceLoader_AeonOverrides(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	loader := newSefariaTestLoader(t, release, func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`{"ref": "Psalms 23:1-2", "book": "Psalms", "sections": [23, 1], "toSections": [23, 2], "textDepth": 2,
+				"text": ["The Lord is my shepherd; I shall not want.", "He makes me lie down in green pastures."]}`)),
+		}, nil
+	})
+	wisdomDir := filepath.Join(loader.projectRoot, ".wisdom")
+	if err := os.MkdirAll(wisdomDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wisdomDir, "aeon_overrides.json"), []byte(`{"overrides": {"Psalms.23.2": "treasury"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if errs := loader.AeonOverrideErrors(); len(errs) != 0 {
+		t.Fatalf("AeonOverrideErrors() = %v", errs)
+	}
+
+	config := sefariaTestConfig()
+	config.SefariaSource = ""
+	config.SefariaRefs = []string{"Psalms.23.1-2"}
+	if err := loader.AddSource(config); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	if err := loader.WaitForSources(context.Background()); err != nil {
+		t.Fatalf("WaitForSources failed: %v", err)
+	}
+
+	source, _ := loader.GetSource("async_test")
+	treasury := source.Quotes["treasury"]
+	if len(treasury) != 1 || treasury[0].Source != "Psalms 23:2" || !treasury[0].Classification.Override {
+		t.Errorf("treasury = %+v, want the overridden Psalms 23:2", treasury)
+	}
+
+	status, _ := loader.SourceStatus("async_test")
+	if status.Distribution == nil || status.Distribution.Overridden != 1 || status.Distribution.Levels["treasury"] != 1 {
+		t.Errorf("status distribution = %+v, want one overridden treasury quote", status.Distribution)
+	}
+}
@@ -0,0 +1,281 @@
+package wisdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultBriefingLimit is the number of weakest metrics included in a briefing.
+const DefaultBriefingLimit = 3
+
+// steadyTrendThreshold is the score change below which a metric is considered steady.
+const steadyTrendThreshold = 1.0
+
+// Trend directions.
+const (
+	TrendImproving = "improving"
+	TrendDeclining = "declining"
+	TrendSteady    = "steady"
+)
+
+// Scorecard holds metric scores read from a scorecard file or flags.
+type Scorecard struct {
+	Overall    float64            // Overall score, valid when HasOverall is true
+	HasOverall bool               // Whether the scorecard includes an overall score
+	Metrics    map[string]float64 // Per-metric scores, 0-100
+}
+
+// OverallScore returns the scorecard's overall score, or the mean of its
+// metric scores if it has none.
+func (s *Scorecard) OverallScore() float64 {
+	if s.HasOverall || len(s.Metrics) == 0 {
+		return s.Overall
+	}
+	total := 0.0
+	for _, score := range s.Metrics {
+		total += score
+	}
+	return math.Round(total/float64(len(s.Metrics))*10) / 10
+}
+
+// overallScoreKeys are the keys read as the overall score rather than a metric.
+var overallScoreKeys = map[string]bool{"score": true, "overall_score": true}
+
+// metricContainerKeys are the keys whose value holds the metric scores.
+var metricContainerKeys = []string{"metrics", "scores", "component_scores"}
+
+// ParseScorecard parses metric scores from scorecard JSON. Accepted forms:
+//
+//	{"security": 40, "testing": 30}
+//	{"overall_score": 55, "scores": {"security": 40, "testing": 30}}
+//	{"score": 55, "metrics": [{"name": "security", "score": 40}]}
+//
+// "metrics", "scores" and "component_scores" may hold either form, so the
+// output of 'devwisdom health --json' is a valid scorecard.
+// Scores must be within 0-100.
+func ParseScorecard(data []byte) (*Scorecard, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse scorecard JSON (expected an object of metric scores): %w", err)
+	}
+
+	card := &Scorecard{Metrics: make(map[string]float64)}
+	for key, value := range raw {
+		if !overallScoreKeys[key] {
+			continue
+		}
+		var score float64
+		if err := json.Unmarshal(value, &score); err != nil {
+			return nil, fmt.Errorf("scorecard %q must be a number: %w", key, err)
+		}
+		card.Overall, card.HasOverall = score, true
+	}
+
+	container := raw
+	for _, key := range metricContainerKeys {
+		value, exists := raw[key]
+		if !exists {
+			continue
+		}
+		metrics, err := parseMetricContainer(value)
+		if err != nil {
+			return nil, fmt.Errorf("scorecard %q: %w", key, err)
+		}
+		card.Metrics = metrics
+		container = nil
+		break
+	}
+
+	// Flat form: every numeric top-level value is a metric
+	for key, value := range container {
+		if overallScoreKeys[key] {
+			continue
+		}
+		var score float64
+		if err := json.Unmarshal(value, &score); err == nil {
+			card.Metrics[key] = score
+		}
+	}
+
+	if card.HasOverall {
+		if err := ValidateScore(card.Overall); err != nil {
+			return nil, fmt.Errorf("scorecard overall score: %w", err)
+		}
+	}
+	for metric, score := range card.Metrics {
+		if err := ValidateScore(score); err != nil {
+			return nil, fmt.Errorf("scorecard metric %q: %w", metric, err)
+		}
+	}
+	if len(card.Metrics) == 0 {
+		return nil, fmt.Errorf("scorecard contains no metric scores")
+	}
+	return card, nil
+}
+
+// parseMetricContainer reads metric scores from an object of scores or a
+// list of {"name"|"metric": ..., "score": ...} entries.
+func parseMetricContainer(data json.RawMessage) (map[string]float64, error) {
+	metrics := make(map[string]float64)
+	if err := json.Unmarshal(data, &metrics); err == nil {
+		return metrics, nil
+	}
+
+	var entries []struct {
+		Name   string   `json:"name"`
+		Metric string   `json:"metric"`
+		Score  *float64 `json:"score"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("expected an object of scores or a list of {\"name\", \"score\"} entries: %w", err)
+	}
+	for i, entry := range entries {
+		name := entry.Name
+		if name == "" {
+			name = entry.Metric
+		}
+		if name == "" || entry.Score == nil {
+			return nil, fmt.Errorf("entry %d needs a name and a score", i)
+		}
+		metrics[name] = *entry.Score
+	}
+	return metrics, nil
+}
+
+// ValidateScore reports an error if score is outside 0-100.
+func ValidateScore(score float64) error {
+	if math.IsNaN(score) || score < 0 || score > 100 {
+		return fmt.Errorf("score %v is out of range: must be between 0 and 100", score)
+	}
+	return nil
+}
+
+// WeakestMetrics returns up to limit metric names ordered by ascending score.
+// Ties are broken by name for deterministic output.
+func WeakestMetrics(scores map[string]float64, limit int) []string {
+	metrics := make([]string, 0, len(scores))
+	for metric := range scores {
+		metrics = append(metrics, metric)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		si, sj := scores[metrics[i]], scores[metrics[j]]
+		if si != sj {
+			return si < sj
+		}
+		return metrics[i] < metrics[j]
+	})
+	if limit > 0 && len(metrics) > limit {
+		metrics = metrics[:limit]
+	}
+	return metrics
+}
+
+// MetricTrend compares a metric score with its most recent earlier consultation.
+type MetricTrend struct {
+	PreviousScore float64 `json:"previous_score"`
+	Change        float64 `json:"change"`
+	Direction     string  `json:"direction"` // improving, declining, or steady
+	Since         string  `json:"since"`     // Timestamp of the previous consultation
+}
+
+// ComputeTrend returns the trend for metric against the most recent consultation
+// in history that recorded a score for it, or nil if there is none.
+func ComputeTrend(metric string, score float64, history []*Consultation) *MetricTrend {
+	var latest *Consultation
+	var latestTime time.Time
+	for _, c := range history {
+		if c == nil || c.Metric != metric {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, c.Timestamp)
+		if err != nil {
+			continue
+		}
+		if latest == nil || ts.After(latestTime) {
+			latest, latestTime = c, ts
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+
+	change := math.Round((score-latest.ScoreAtTime)*10) / 10
+	direction := TrendSteady
+	switch {
+	case change >= steadyTrendThreshold:
+		direction = TrendImproving
+	case change <= -steadyTrendThreshold:
+		direction = TrendDeclining
+	}
+	return &MetricTrend{
+		PreviousScore: latest.ScoreAtTime,
+		Change:        change,
+		Direction:     direction,
+		Since:         latest.Timestamp,
+	}
+}
+
+// BriefingEntry is the advice for a single weak metric in a daily briefing.
+type BriefingEntry struct {
+	Metric        string       `json:"metric"`
+	Score         float64      `json:"score"`
+	Advisor       string       `json:"advisor"`
+	AdvisorIcon   string       `json:"advisor_icon"`
+	Rationale     string       `json:"rationale,omitempty"`
+	QuoteID       string       `json:"quote_id,omitempty"`
+	Quote         string       `json:"quote"`
+	Translation   string       `json:"translation,omitempty"`
+	Source        string       `json:"source"`
+	Encouragement string       `json:"encouragement"`
+	Trend         *MetricTrend `json:"trend,omitempty"`
+
+	// Source and icon the quote was selected from, for library callers
+	WisdomSource string `json:"-"`
+	WisdomIcon   string `json:"-"`
+}
+
+// BuildBriefing returns advice for the limit weakest metrics in scores, each
+// with a trend against history (previous consultations, e.g. from the
+// consultation log). Metrics without an advisor or an available quote are skipped.
+func (e *Engine) BuildBriefing(scores map[string]float64, limit int, history []*Consultation) []BriefingEntry {
+	if limit <= 0 {
+		limit = DefaultBriefingLimit
+	}
+
+	advisors := e.GetAdvisors()
+	entries := make([]BriefingEntry, 0, limit)
+	for _, metric := range WeakestMetrics(scores, 0) {
+		if len(entries) >= limit {
+			break
+		}
+		advisorInfo, err := advisors.GetAdvisorForMetric(metric)
+		if err != nil {
+			continue
+		}
+		score := scores[metric]
+		quote, err := e.GetWisdom(score, advisorInfo.Advisor)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, BriefingEntry{
+			Metric:        metric,
+			Score:         score,
+			Advisor:       advisorInfo.Advisor,
+			AdvisorIcon:   advisorInfo.Icon,
+			Rationale:     advisorInfo.Rationale,
+			QuoteID:       quote.ID,
+			Quote:         quote.Quote,
+			Translation:   quote.Translation,
+			Source:        quote.Source,
+			Encouragement: quote.Encouragement,
+			Trend:         ComputeTrend(metric, score, history),
+			WisdomSource:  quote.WisdomSource,
+			WisdomIcon:    quote.WisdomIcon,
+		})
+	}
+	return entries
+}
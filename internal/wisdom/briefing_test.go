@@ -0,0 +1,151 @@
+package wisdom
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScorecard(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantMetrics map[string]float64
+		wantOverall float64
+		wantErr     bool
+	}{
+		{
+			name:        "flat object",
+			data:        `{"security": 40, "testing": 30, "label": "ignored"}`,
+			wantMetrics: map[string]float64{"security": 40, "testing": 30},
+			wantOverall: 35,
+		},
+		{
+			name:        "nested scores with overall",
+			data:        `{"overall_score": 55, "scores": {"security": 40, "testing": 30}}`,
+			wantMetrics: map[string]float64{"security": 40, "testing": 30},
+			wantOverall: 55,
+		},
+		{
+			name:        "health report",
+			data:        `{"root": "/repo", "score": 70, "metrics": [{"name": "testing", "score": 60, "details": {}}, {"name": "ci_cd", "score": 80}]}`,
+			wantMetrics: map[string]float64{"testing": 60, "ci_cd": 80},
+			wantOverall: 70,
+		},
+		{
+			name:        "metric entries",
+			data:        `{"metrics": [{"metric": "security", "score": 10}]}`,
+			wantMetrics: map[string]float64{"security": 10},
+			wantOverall: 10,
+		},
+		{name: "out of range", data: `{"security": 140}`, wantErr: true},
+		{name: "no metrics", data: `{"score": 50}`, wantErr: true},
+		{name: "entry without score", data: `{"metrics": [{"name": "security"}]}`, wantErr: true},
+		{name: "not an object", data: `[1, 2]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := ParseScorecard([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseScorecard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(card.Metrics) != len(tt.wantMetrics) {
+				t.Errorf("Metrics = %v, want %v", card.Metrics, tt.wantMetrics)
+			}
+			for metric, want := range tt.wantMetrics {
+				if got := card.Metrics[metric]; got != want {
+					t.Errorf("Metrics[%q] = %v, want %v", metric, got, want)
+				}
+			}
+			if got := card.OverallScore(); got != tt.wantOverall {
+				t.Errorf("OverallScore() = %v, want %v", got, tt.wantOverall)
+			}
+		})
+	}
+}
+
+func TestWeakestMetrics(t *testing.T) {
+	scores := map[string]float64{"security": 40, "testing": 30, "documentation": 60, "alignment": 40}
+
+	got := WeakestMetrics(scores, 3)
+	want := []string{"testing", "alignment", "security"}
+	if len(got) != len(want) {
+		t.Fatalf("WeakestMetrics() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("WeakestMetrics()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if all := WeakestMetrics(scores, 0); len(all) != len(scores) {
+		t.Errorf("WeakestMetrics(limit 0) returned %d metrics, want all %d", len(all), len(scores))
+	}
+}
+
+func TestComputeTrend(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	history := []*Consultation{
+		{Metric: "testing", ScoreAtTime: 20, Timestamp: now.Add(-48 * time.Hour).Format(time.RFC3339)},
+		{Metric: "testing", ScoreAtTime: 25, Timestamp: now.Add(-24 * time.Hour).Format(time.RFC3339)},
+		{Metric: "security", ScoreAtTime: 90, Timestamp: now.Add(-time.Hour).Format(time.RFC3339)},
+		{Metric: "testing", ScoreAtTime: 99, Timestamp: "not a timestamp"},
+	}
+
+	tests := []struct {
+		metric        string
+		score         float64
+		wantDirection string
+		wantPrevious  float64
+	}{
+		{"testing", 35, TrendImproving, 25},
+		{"testing", 25.5, TrendSteady, 25},
+		{"security", 60, TrendDeclining, 90},
+	}
+	for _, tt := range tests {
+		trend := ComputeTrend(tt.metric, tt.score, history)
+		if trend == nil {
+			t.Fatalf("ComputeTrend(%q) = nil, want trend", tt.metric)
+		}
+		if trend.Direction != tt.wantDirection || trend.PreviousScore != tt.wantPrevious {
+			t.Errorf("ComputeTrend(%q, %v) = %+v, want %s from %v", tt.metric, tt.score, trend, tt.wantDirection, tt.wantPrevious)
+		}
+	}
+
+	if trend := ComputeTrend("documentation", 50, history); trend != nil {
+		t.Errorf("ComputeTrend without history = %+v, want nil", trend)
+	}
+}
+
+func TestEngine_BuildBriefing(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	scores := map[string]float64{"security": 40, "testing": 30, "documentation": 60, "no_such_metric": 5}
+	history := []*Consultation{
+		{Metric: "testing", ScoreAtTime: 50, Timestamp: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+	}
+
+	entries := engine.BuildBriefing(scores, 2, history)
+	if len(entries) != 2 {
+		t.Fatalf("BuildBriefing returned %d entries, want 2", len(entries))
+	}
+	// Metrics without an advisor are skipped
+	if entries[0].Metric != "testing" || entries[1].Metric != "security" {
+		t.Errorf("entries = %s, %s; want testing, security", entries[0].Metric, entries[1].Metric)
+	}
+	if entries[0].Quote == "" || entries[0].Advisor == "" {
+		t.Errorf("entry missing advisor or quote: %+v", entries[0])
+	}
+	if entries[0].Trend == nil || entries[0].Trend.Direction != TrendDeclining {
+		t.Errorf("testing trend = %+v, want declining", entries[0].Trend)
+	}
+	if entries[1].Trend != nil {
+		t.Errorf("security trend = %+v, want nil without history", entries[1].Trend)
+	}
+}
@@ -0,0 +1,245 @@
+package wisdom
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Classification is the aeon level assigned to a quote that its source does
+// not place on a level itself (e.g. Sefaria verses).
+type Classification struct {
+	AeonLevel  string  `json:"aeon_level"`
+	Confidence float64 `json:"confidence"`         // 0-1; 0 means the quote was placed without evidence
+	Override   bool    `json:"override,omitempty"` // Placed by an aeon_overrides.json entry
+}
+
+// AeonClassifier assigns aeon levels to quotes. Classify returns a zero
+// Classification if it finds no evidence for any level; such quotes are spread
+// over the levels with the fewest quotes.
+type AeonClassifier interface {
+	Classify(quote *Quote) Classification
+}
+
+// aeonLexicon lists keywords suggesting each aeon level. Chaos quotes speak to
+// trouble and fear, lower aeons to correction and humility, middle aeons to
+// steady work and counsel, upper aeons to wisdom and integrity, and the
+// treasury to joy and praise. Hebrew keywords are stems without vowel points.
+var aeonLexicon = map[string][]string{
+	string(AeonChaos): {
+		"fear", "afraid", "enemy", "enemies", "foe", "trouble", "distress", "darkness", "dark",
+		"death", "perish", "weep", "sorrow", "affliction", "afflicted", "despair", "destroy",
+		"destruction", "calamity", "storm", "pit", "grave", "anguish", "tears", "flood", "ruin",
+		"צרה", "צרות", "אויב", "פחד", "חשך", "מות", "אבד", "בכי", "דמע", "אנחה", "צעק", "שבר", "אסון",
+	},
+	string(AeonLower): {
+		"rebuke", "correction", "discipline", "chastening", "reproof", "instruction", "sin",
+		"fool", "folly", "foolish", "lazy", "sluggard", "slothful", "humble", "humility", "pride",
+		"proud", "mistake", "repent", "stumble",
+		"מוסר", "תוכח", "חטא", "עון", "כסיל", "אויל", "עצל", "ענו", "גאוה", "תשובה",
+	},
+	string(AeonMiddle): {
+		"work", "labor", "diligent", "diligence", "counsel", "plan", "patience", "patient",
+		"steady", "path", "step", "prudent", "careful", "effort", "toil", "persevere", "study",
+		"מלאכה", "עבודה", "עמל", "חרוץ", "עצה", "ארח", "זריז", "מתון", "למד", "השתדל",
+	},
+	string(AeonUpper): {
+		"wisdom", "wise", "understanding", "knowledge", "righteous", "righteousness", "upright",
+		"integrity", "truth", "justice", "discern", "discernment", "insight", "mighty",
+		"חכמה", "חכם", "בינה", "דעת", "צדק", "צדיק", "ישרים", "יושר", "אמת", "תבונה", "משפט", "גבור",
+	},
+	string(AeonTreasury): {
+		"joy", "rejoice", "glad", "gladness", "praise", "sing", "song", "hallelujah", "bless",
+		"blessed", "glory", "thank", "thanks", "gratitude", "abundance", "riches", "treasure",
+		"peace", "rest", "delight", "celebrate", "crown", "honor",
+		"שמח", "הלל", "הללויה", "שיר", "רנן", "תהלה", "ברכה", "ברוך", "כבוד", "הודו", "עושר", "שלום", "מנוחה",
+	},
+}
+
+// LexiconClassifier is the default AeonClassifier. It counts English and
+// Hebrew keywords of each aeon level in a quote and its translation and picks
+// the level with the most matches; confidence grows with the share and number
+// of matches. It is a heuristic: use aeon_overrides.json to correct it.
+type LexiconClassifier struct {
+	keywords map[string][]string // Normalized keywords by aeon level
+}
+
+// NewLexiconClassifier creates a classifier using the built-in English and
+// Hebrew lexicon.
+func NewLexiconClassifier() *LexiconClassifier {
+	keywords := make(map[string][]string, len(aeonLexicon))
+	for level, words := range aeonLexicon {
+		for _, word := range words {
+			keywords[level] = append(keywords[level], normalizeHebrew(word))
+		}
+	}
+	return &LexiconClassifier{keywords: keywords}
+}
+
+// Classify implements AeonClassifier.
+func (c *LexiconClassifier) Classify(quote *Quote) Classification {
+	counts := make(map[string]int, len(aeonLevels))
+	total := 0
+	for _, token := range tokenize(normalizeHebrew(quote.Quote + " " + quote.Translation)) {
+		for _, level := range aeonLevels {
+			for _, keyword := range c.keywords[level] {
+				if matchesKeyword(token, keyword) {
+					counts[level]++
+					total++
+					break
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return Classification{}
+	}
+
+	// Ties go to the lower level
+	best := ""
+	for _, level := range aeonLevels {
+		if best == "" || counts[level] > counts[best] {
+			best = level
+		}
+	}
+	share := float64(counts[best]) / float64(total)
+	return Classification{
+		AeonLevel:  best,
+		Confidence: share * float64(total) / float64(total+1),
+	}
+}
+
+// hebrewPrefixes are the one-letter prefixes (and, the, in, like, to, from,
+// that) stripped from Hebrew words before matching.
+const hebrewPrefixes = "והבכלמש"
+
+// matchesKeyword reports whether token is keyword or an inflection of it.
+// Hebrew tokens may carry up to two prefix letters and any suffix; English
+// keywords match their common inflections, and long ones any suffix.
+func matchesKeyword(token, keyword string) bool {
+	if isHebrew(keyword) {
+		for strip := 0; strip <= 2; strip++ {
+			if strings.HasPrefix(token, keyword) {
+				return true
+			}
+			first, size := firstRune(token)
+			if !strings.ContainsRune(hebrewPrefixes, first) || len(token)-size < len(keyword) {
+				return false
+			}
+			token = token[size:]
+		}
+		return false
+	}
+	if token == keyword {
+		return true
+	}
+	if len(keyword) >= 5 {
+		return strings.HasPrefix(token, keyword)
+	}
+	for _, suffix := range []string{"s", "es", "ed", "ing", "eth", "est"} {
+		if token == keyword+suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// finalForms maps Hebrew final letters to their regular forms.
+var finalForms = strings.NewReplacer("ך", "כ", "ם", "מ", "ן", "נ", "ף", "פ", "ץ", "צ")
+
+// normalizeHebrew removes Hebrew vowel points and cantillation marks, turns
+// the maqaf (hyphen) into a space and replaces final letters with their
+// regular forms, so stems match inflected and pointed words.
+func normalizeHebrew(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r == '־' { // Maqaf
+			return ' '
+		}
+		if unicode.Is(unicode.Hebrew, r) && unicode.IsMark(r) {
+			return -1
+		}
+		return r
+	}, text)
+	return finalForms.Replace(text)
+}
+
+// isHebrew reports whether text starts with a Hebrew letter.
+func isHebrew(text string) bool {
+	r, _ := firstRune(text)
+	return unicode.Is(unicode.Hebrew, r)
+}
+
+// firstRune returns the first rune of text and its size in bytes.
+func firstRune(text string) (rune, int) {
+	for _, r := range text {
+		return r, len(string(r))
+	}
+	return 0, 0
+}
+
+// classifyQuotes places quotes on aeon levels with classifier. Quotes it
+// cannot classify are spread over the levels with the fewest quotes, so every
+// level has quotes when there are enough of them.
+func classifyQuotes(classifier AeonClassifier, quotes []Quote) map[string][]Quote {
+	classified := make(map[string][]Quote, len(aeonLevels))
+	for _, level := range aeonLevels {
+		classified[level] = make([]Quote, 0)
+	}
+
+	var unclassified []Quote
+	for _, quote := range quotes {
+		classification := classifier.Classify(&quote)
+		if classification.AeonLevel == "" {
+			unclassified = append(unclassified, quote)
+			continue
+		}
+		quote.Classification = &classification
+		classified[classification.AeonLevel] = append(classified[classification.AeonLevel], quote)
+	}
+
+	for _, quote := range unclassified {
+		emptiest := aeonLevels[0]
+		for _, level := range aeonLevels {
+			if len(classified[level]) < len(classified[emptiest]) {
+				emptiest = level
+			}
+		}
+		quote.Classification = &Classification{AeonLevel: emptiest}
+		classified[emptiest] = append(classified[emptiest], quote)
+	}
+	return classified
+}
+
+// AeonDistribution reports how a source's quotes are placed on aeon levels.
+type AeonDistribution struct {
+	Levels         map[string]int `json:"levels"`                    // Quotes per aeon level
+	Classified     int            `json:"classified"`                // Placed by the classifier
+	Overridden     int            `json:"overridden"`                // Placed by aeon_overrides.json
+	Unclassified   int            `json:"unclassified"`              // Spread over the emptiest levels for lack of evidence
+	MeanConfidence float64        `json:"mean_confidence,omitempty"` // Of the classified quotes
+}
+
+// Distribution reports how the source's quotes are placed on aeon levels.
+// Quotes placed by the source configuration count only toward Levels.
+func (s *Source) Distribution() *AeonDistribution {
+	distribution := &AeonDistribution{Levels: make(map[string]int, len(s.Quotes))}
+	confidence := 0.0
+	for level, quotes := range s.Quotes {
+		distribution.Levels[level] = len(quotes)
+		for _, quote := range quotes {
+			switch c := quote.Classification; {
+			case c == nil:
+			case c.Override:
+				distribution.Overridden++
+			case c.Confidence == 0:
+				distribution.Unclassified++
+			default:
+				distribution.Classified++
+				confidence += c.Confidence
+			}
+		}
+	}
+	if distribution.Classified > 0 {
+		distribution.MeanConfidence = confidence / float64(distribution.Classified)
+	}
+	return distribution
+}
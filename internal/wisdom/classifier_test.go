@@ -0,0 +1,86 @@
+package wisdom
+
+import "testing"
+
+func TestLexiconClassifier_Classify(t *testing.T) {
+	classifier := NewLexiconClassifier()
+	tests := []struct {
+		name  string
+		quote Quote
+		want  string
+	}{
+		{"english chaos", Quote{Quote: "Though I walk through the valley of the shadow of death, I will fear no evil."}, "chaos"},
+		{"english lower", Quote{Quote: "Whoever loves discipline loves knowledge, but he who hates reproof is stupid; the fool rejects correction."}, "lower_aeons"},
+		{"english middle", Quote{Quote: "The plans of the diligent lead surely to abundance, through steady work."}, "middle_aeons"},
+		{"english upper", Quote{Quote: "The fear of the Lord is the beginning of wisdom, and knowledge of the Holy One is understanding."}, "upper_aeons"},
+		{"english treasury", Quote{Quote: "Sing to the Lord a new song; rejoice and praise!"}, "treasury"},
+		{"pointed hebrew", Quote{Quote: "שִׁירוּ לַיהוָה שִׁיר חָדָשׁ, הַלְלוּיָהּ"}, "treasury"},
+		{"hebrew prefixes", Quote{Quote: "וּבְחָכְמָה וּבִתְבוּנָה"}, "upper_aeons"},
+		{"translation counts", Quote{Quote: "ללא מילים", Translation: "In the day of my trouble I call"}, "chaos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifier.Classify(&tt.quote)
+			if got.AeonLevel != tt.want {
+				t.Errorf("Classify() level = %q, want %q", got.AeonLevel, tt.want)
+			}
+			if got.Confidence <= 0 || got.Confidence >= 1 {
+				t.Errorf("Classify() confidence = %v, want between 0 and 1", got.Confidence)
+			}
+		})
+	}
+
+	if got := classifier.Classify(&Quote{Quote: "The quick brown fox."}); got.AeonLevel != "" || got.Confidence != 0 {
+		t.Errorf("Classify() without keywords = %+v, want no classification", got)
+	}
+
+	// More matching keywords give more confidence
+	one := classifier.Classify(&Quote{Quote: "Rejoice."})
+	three := classifier.Classify(&Quote{Quote: "Rejoice, sing and praise."})
+	if three.Confidence <= one.Confidence {
+		t.Errorf("confidence with three matches (%v) should exceed one match (%v)", three.Confidence, one.Confidence)
+	}
+}
+
+func TestClassifyQuotes_SpreadsUnclassified(t *testing.T) {
+	quotes := []Quote{
+		{Quote: "Rejoice and sing."},
+		{Quote: "A"},
+		{Quote: "B"},
+		{Quote: "C"},
+		{Quote: "D"},
+	}
+	levels := classifyQuotes(NewLexiconClassifier(), quotes)
+
+	for _, level := range aeonLevels {
+		if len(levels[level]) != 1 {
+			t.Errorf("level %q has %d quotes, want 1 (unclassified quotes fill empty levels)", level, len(levels[level]))
+		}
+	}
+	if got := levels["treasury"][0]; got.Quote != "Rejoice and sing." || got.Classification == nil || got.Classification.Confidence == 0 {
+		t.Errorf("treasury = %+v, want the classified quote", got)
+	}
+
+	distribution := (&Source{Quotes: levels}).Distribution()
+	if distribution.Classified != 1 || distribution.Unclassified != 4 || distribution.Overridden != 0 {
+		t.Errorf("Distribution() = %+v, want 1 classified and 4 unclassified", distribution)
+	}
+	if distribution.MeanConfidence != levels["treasury"][0].Classification.Confidence {
+		t.Errorf("MeanConfidence = %v, want the classified quote's confidence", distribution.MeanConfidence)
+	}
+}
+
+func TestSource_Distribution_ConfiguredQuotes(t *testing.T) {
+	source := &Source{Quotes: map[string][]Quote{
+		"chaos":    {{Quote: "A"}, {Quote: "B"}},
+		"treasury": {{Quote: "C"}},
+	}}
+	distribution := source.Distribution()
+	if distribution.Levels["chaos"] != 2 || distribution.Levels["treasury"] != 1 {
+		t.Errorf("Levels = %v, want chaos 2 and treasury 1", distribution.Levels)
+	}
+	if distribution.Classified+distribution.Overridden+distribution.Unclassified != 0 {
+		t.Errorf("configured quotes counted as classified: %+v", distribution)
+	}
+}
@@ -0,0 +1,83 @@
+package wisdom
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock supplies the current time. Inject one (e.g. with Engine.WithClock) to
+// control timestamps and date-seeded selection in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts an ordinary function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the default Clock backed by time.Now.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// DayBoundary defines when a "day" starts for daily quote seeding and log
+// rotation: at StartHour o'clock in Location. The zero value uses midnight
+// in the zone of the times it is given (local time for the system clock).
+//
+// A team that shares a Location and StartHour sees the same daily quotes
+// regardless of where its members are.
+type DayBoundary struct {
+	Location  *time.Location // Time zone; nil uses the zone of each time
+	StartHour int            // Hour (0-23) at which a new day begins
+}
+
+// NewDayBoundary returns the day boundary for an IANA time zone name (e.g.
+// "Europe/Berlin", "UTC", or "Local"; "" keeps the zone of each time) and the
+// hour at which a day starts.
+func NewDayBoundary(timezone string, startHour int) (DayBoundary, error) {
+	if startHour < 0 || startHour > 23 {
+		return DayBoundary{}, fmt.Errorf("day start hour %d is out of range: must be between 0 and 23", startHour)
+	}
+	var loc *time.Location
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return DayBoundary{}, fmt.Errorf("invalid time zone %q (use an IANA name such as \"UTC\" or \"America/New_York\"): %w", timezone, err)
+		}
+	}
+	return DayBoundary{Location: loc, StartHour: startHour}, nil
+}
+
+// location returns the boundary's time zone for t.
+func (d DayBoundary) location(t time.Time) *time.Location {
+	if d.Location == nil {
+		return t.Location()
+	}
+	return d.Location
+}
+
+// Date returns midnight of the calendar date that t belongs to, in the
+// boundary's time zone. Before StartHour, t belongs to the previous date.
+func (d DayBoundary) Date(t time.Time) time.Time {
+	loc := d.location(t)
+	shifted := t.In(loc).Add(-time.Duration(d.StartHour) * time.Hour)
+	year, month, day := shifted.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// Start returns the instant the day containing t began.
+func (d DayBoundary) Start(t time.Time) time.Time {
+	return d.StartOf(d.Date(t))
+}
+
+// StartOf returns the instant the given calendar date began.
+func (d DayBoundary) StartOf(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), d.StartHour, 0, 0, 0, d.location(date))
+}
+
+// Key returns the day containing t in YYYYMMDD format, used to seed daily selection.
+func (d DayBoundary) Key(t time.Time) string {
+	return d.Date(t).Format("20060102")
+}
@@ -0,0 +1,131 @@
+package wisdom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+)
+
+// fixedClock returns a clock stopped at t.
+func fixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+func TestNewDayBoundary(t *testing.T) {
+	if _, err := NewDayBoundary("Not/AZone", 0); err == nil {
+		t.Error("NewDayBoundary should reject unknown time zones")
+	}
+	for _, hour := range []int{-1, 24} {
+		if _, err := NewDayBoundary("UTC", hour); err == nil {
+			t.Errorf("NewDayBoundary should reject start hour %d", hour)
+		}
+	}
+	d, err := NewDayBoundary("", 0)
+	if err != nil {
+		t.Fatalf("NewDayBoundary(\"\") failed: %v", err)
+	}
+	// Without a zone, each time's own zone decides its date
+	late := time.Date(2026, 3, 1, 23, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	if got := d.Key(late); got != "20260301" {
+		t.Errorf("Key = %q, want the date in the time's own zone", got)
+	}
+}
+
+func TestDayBoundary_Key(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	// 2026-03-01 23:30 UTC is 2026-03-02 08:30 in Tokyo
+	instant := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		day  DayBoundary
+		want string
+	}{
+		{"utc midnight", DayBoundary{Location: time.UTC}, "20260301"},
+		{"tokyo midnight", DayBoundary{Location: tokyo}, "20260302"},
+		{"tokyo day starts at 9", DayBoundary{Location: tokyo, StartHour: 9}, "20260301"},
+		{"input zone is irrelevant", DayBoundary{Location: time.UTC}, "20260301"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.day.Key(instant.In(tokyo)); got != tt.want {
+				t.Errorf("Key = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	d := DayBoundary{Location: time.UTC, StartHour: 6}
+	start := d.Start(time.Date(2026, 3, 2, 5, 0, 0, 0, time.UTC))
+	if want := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("Start = %v, want %v", start, want)
+	}
+}
+
+func TestEngine_DailyQuote_SharedDayBoundary(t *testing.T) {
+	quotes := rotationQuotes(20)
+	newEngine := func(now time.Time) *Engine {
+		engine := newConfiguredEngine(&config.Config{Source: "stoic", Timezone: "UTC", DayStartHour: 6})
+		engine.sources["stoic"].Quotes["middle_aeons"] = quotes
+		engine.clock = fixedClock(now)
+		day, err := NewDayBoundary("UTC", 6)
+		if err != nil {
+			t.Fatalf("NewDayBoundary failed: %v", err)
+		}
+		engine.day = day
+		return engine
+	}
+	quoteAt := func(now time.Time) string {
+		t.Helper()
+		quote, err := newEngine(now).GetWisdom(50, "stoic")
+		if err != nil {
+			t.Fatalf("GetWisdom failed: %v", err)
+		}
+		return quote.ID
+	}
+
+	// Teammates in different zones get the same quote within one configured day
+	morning := time.Date(2026, 3, 1, 7, 0, 0, 0, time.UTC)
+	newYork := morning.Add(20 * time.Hour).In(time.FixedZone("EST", -5*3600)) // 03:00 UTC next day, still before 06:00
+	if quoteAt(morning) != quoteAt(newYork) {
+		t.Error("quotes differ within the same configured day")
+	}
+
+	// The quote is the same on repeated calls and depends only on the day
+	days := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		days[quoteAt(morning.AddDate(0, 0, i))] = true
+	}
+	if len(days) < 2 {
+		t.Error("daily quote never changed over 10 days")
+	}
+}
+
+func TestEngine_WithClock(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	engine := NewEngine().WithClock(fixedClock(now))
+	if got := engine.Now(); !got.Equal(now) {
+		t.Errorf("Now = %v, want %v", got, now)
+	}
+
+	t.Setenv("EXARP_WISDOM_TIMEZONE", "Not/AZone")
+	if err := NewEngine().Initialize(); err == nil {
+		t.Error("Initialize should fail with an invalid time zone")
+	}
+}
+
+func TestSourceLoader_WithClock(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	loader := NewSourceLoader().WithClock(fixedClock(now))
+	if !loader.clock.Now().Equal(now) {
+		t.Errorf("clock = %v, want %v", loader.clock.Now(), now)
+	}
+
+	// A nil clock keeps the current one
+	if loader.WithClock(nil).clock == nil {
+		t.Error("WithClock(nil) cleared the clock")
+	}
+}
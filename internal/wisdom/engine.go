@@ -1,8 +1,11 @@
 package wisdom
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"hash/fnv"
+	"io/fs"
 	"math/rand"
 	"sort"
 	"sync"
@@ -18,12 +21,24 @@ type Engine struct {
 	sources     map[string]*Source
 	loader      *SourceLoader
 	advisors    *AdvisorRegistry
+	advisorErrs []error // invalid advisors.json entries skipped at load time
+	index       *SearchIndex
+	quotesByID  map[string]quoteRef
+	history     *QuoteHistory // quote rotation history; nil until needed
+	historyDir  string        // where a history created when needed is kept
+	historyUser string        // whose history is created when needed; "" for the OS user
+	clock       Clock
+	clockSet    bool        // clock was provided via WithClock and is passed to the loader
+	day         DayBoundary // from the config's timezone and day start hour
 	config      *config.Config
+	configSet   bool // config was provided via WithConfig and is used as-is
 	initialized bool
+	onUpdate    func(sourceID string) // called after a background source update; see OnSourceUpdate
 	mu          sync.RWMutex
-	// Performance optimization: cached sorted source list
-	sortedSources   []string
-	sortedSourcesMu sync.RWMutex
+	// Performance optimization: cached sorted list of sources allowed by config
+	sortedSources      []string
+	sortedSourcesTotal int // len(sources) when sortedSources was computed
+	sortedSourcesMu    sync.RWMutex
 	// Performance optimization: cached date hash for random source selection
 	cachedDateHash int64
 	cachedDate     string // YYYYMMDD format
@@ -34,13 +49,101 @@ type Engine struct {
 // The engine is not initialized by default; call Initialize() before use.
 func NewEngine() *Engine {
 	return &Engine{
-		sources:  make(map[string]*Source),
-		loader:   NewSourceLoader(),
+		sources: make(map[string]*Source),
+		loader: NewSourceLoader().
+			WithConfigPaths(
+				"sources.json",
+				"wisdom/sources.json",
+				".wisdom/sources.json",
+			),
 		advisors: NewAdvisorRegistry(),
+		clock:    SystemClock,
 		config:   config.NewConfig(),
 	}
 }
 
+// WithLoader replaces the engine's source loader.
+// This allows callers to configure config paths, project root, and caching before Initialize().
+// It has no effect once the engine is initialized.
+func (e *Engine) WithLoader(loader *SourceLoader) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.initialized && loader != nil {
+		e.loader = loader
+	}
+	return e
+}
+
+// WithConfig sets the engine configuration.
+// The config is used as-is: call its Load() method first if file and environment
+// settings should apply. It has no effect once the engine is initialized.
+func (e *Engine) WithConfig(cfg *config.Config) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.initialized && cfg != nil {
+		e.config = cfg
+		e.configSet = true
+	}
+	return e
+}
+
+// WithClock sets the clock used for date-seeded selection, rotation history, and
+// source caching. It has no effect once the engine is initialized.
+func (e *Engine) WithClock(clock Clock) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.initialized && clock != nil {
+		e.clock = clock
+		e.clockSet = true
+	}
+	return e
+}
+
+// WithHistory sets the quote history used by the "cycle" and "cycle_daily"
+// rotation modes. By default a history is created when one of those modes is
+// configured (see WithHistoryDir).
+// It has no effect once the engine is initialized.
+func (e *Engine) WithHistory(history *QuoteHistory) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.initialized && history != nil {
+		e.history = history
+	}
+	return e
+}
+
+// WithHistoryDir sets where the history created for the "cycle" and
+// "cycle_daily" rotation modes is kept (default DefaultHistoryDir), and whose
+// history it is (default the current OS user). No history is created unless
+// one of those modes is configured.
+// It has no effect once the engine is initialized.
+func (e *Engine) WithHistoryDir(dir, user string) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.initialized {
+		e.historyDir, e.historyUser = dir, user
+	}
+	return e
+}
+
+// ensureHistory creates the quote history if a cycling rotation mode is
+// configured and there is none yet. The caller must hold e.mu.
+func (e *Engine) ensureHistory() {
+	if e.history != nil || !usesHistory(e.config.Rotation) {
+		return
+	}
+	dir := e.historyDir
+	if dir == "" {
+		dir = DefaultHistoryDir
+	}
+	e.history = NewQuoteHistory(dir, e.historyUser)
+}
+
 // Initialize loads wisdom sources and configuration.
 // This method is idempotent and can be called multiple times safely.
 // It loads sources from configuration files or falls back to built-in sources.
@@ -52,18 +155,34 @@ func (e *Engine) Initialize() error {
 		return nil
 	}
 
-	// Load configuration
-	if err := e.config.Load(); err != nil {
-		return fmt.Errorf("failed to load engine configuration: %w", err)
+	// Load configuration (file, environment variables, .exarp_no_wisdom marker)
+	if !e.configSet {
+		if err := e.config.Load(); err != nil {
+			return fmt.Errorf("failed to load engine configuration: %w", err)
+		}
+	}
+
+	// Daily selection follows the configured time zone and day start hour
+	day, err := NewDayBoundary(e.config.Timezone, e.config.DayStartHour)
+	if err != nil {
+		return fmt.Errorf("invalid day boundary configuration (check EXARP_WISDOM_TIMEZONE and EXARP_WISDOM_DAY_START_HOUR): %w", err)
 	}
+	e.day = day
 
-	// Configure source loader
-	e.loader = NewSourceLoader().
-		WithConfigPaths(
-			"sources.json",
-			"wisdom/sources.json",
-			".wisdom/sources.json",
-		)
+	// A mistyped rotation mode would otherwise silently rotate daily
+	if _, err := ParseRotation(e.config.Rotation); err != nil {
+		return fmt.Errorf("invalid rotation configuration (check EXARP_WISDOM_ROTATION): %w", err)
+	}
+
+	// Configure source loader if none was provided
+	if e.loader == nil {
+		e.loader = NewSourceLoader()
+	}
+	if e.clockSet {
+		e.loader.WithClock(e.clock)
+	}
+	// API-backed sources finish loading in the background
+	e.loader.OnSourceUpdate(e.sourceUpdated)
 
 	// Try to load from default locations
 	if err := e.loader.Load(); err != nil {
@@ -88,9 +207,13 @@ func (e *Engine) Initialize() error {
 
 	// Pre-compute sorted source list for performance optimization
 	e.updateSortedSources()
+	e.rebuildIndexes()
+
+	// Initialize advisors (built-in mappings plus advisors.json overrides)
+	e.loadAdvisors()
 
-	// Initialize advisors
-	e.advisors.Initialize()
+	// Keep rotation history when a cycling mode is configured
+	e.ensureHistory()
 
 	e.initialized = true
 	return nil
@@ -103,7 +226,7 @@ func (e *Engine) ReloadSources() error {
 	defer e.mu.Unlock()
 
 	if !e.initialized {
-		return fmt.Errorf("engine not initialized: call Initialize() before reloading sources")
+		return fmt.Errorf("%w: call Initialize() before reloading sources", ErrNotInitialized)
 	}
 
 	if e.loader == nil {
@@ -115,20 +238,216 @@ func (e *Engine) ReloadSources() error {
 	}
 
 	e.sources = e.loader.GetAllSources()
-	// Update cached sorted source list
+	// Update cached sorted source list and the quote indexes
 	e.updateSortedSources()
+	e.rebuildIndexes()
+	// Reload advisor mappings, which are validated against the new sources
+	e.loadAdvisors()
 	return nil
 }
 
+// ReloadIfValid reloads configuration, sources and advisors, but only if the
+// sources files and the config file are valid. Otherwise the engine keeps
+// serving its current state and the errors found are returned: diagnostics
+// for sources files, or an error for the config file. The new state is
+// swapped in at once, so readers see either the old or the new one.
+func (e *Engine) ReloadIfValid() ([]Diagnostic, error) {
+	loader := e.GetLoader()
+	if loader == nil {
+		return nil, fmt.Errorf("loader not initialized")
+	}
+
+	var errs []Diagnostic
+	for _, d := range loader.ValidateSources() {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		}
+	}
+	if len(errs) > 0 {
+		return errs, fmt.Errorf("%d error(s) in sources files; keeping the current sources", len(errs))
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.initialized {
+		return nil, fmt.Errorf("%w: call Initialize() before reloading sources", ErrNotInitialized)
+	}
+
+	cfg, day := e.config, e.day
+	if !e.configSet {
+		reloaded, err := e.config.Reloaded()
+		if err != nil {
+			return nil, fmt.Errorf("keeping the current configuration: %w", err)
+		}
+		day, err = NewDayBoundary(reloaded.Timezone, reloaded.DayStartHour)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day boundary configuration; keeping the current configuration: %w", err)
+		}
+		if _, err := ParseRotation(reloaded.Rotation); err != nil {
+			return nil, fmt.Errorf("invalid rotation configuration; keeping the current configuration: %w", err)
+		}
+		cfg = reloaded
+	}
+
+	if err := e.loader.Reload(); err != nil {
+		return nil, fmt.Errorf("failed to reload sources: %w", err)
+	}
+
+	e.config, e.day = cfg, day
+	e.dateHashMu.Lock()
+	e.cachedDate = "" // The day boundary may have changed
+	e.dateHashMu.Unlock()
+	e.sources = e.loader.GetAllSources()
+	e.updateSortedSources()
+	e.rebuildIndexes()
+	e.loadAdvisors()
+	e.ensureHistory()
+	return nil, nil
+}
+
+// OnSourceUpdate sets a function called after an API-backed source (such as
+// Sefaria or an api_endpoint) finished loading in the background and its
+// quotes changed. It is called without the engine's lock held, so it may use
+// the engine.
+func (e *Engine) OnSourceUpdate(fn func(sourceID string)) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onUpdate = fn
+	return e
+}
+
+// sourceUpdated refreshes the engine's sources and quote indexes after the
+// loader replaced a source's quotes in the background.
+func (e *Engine) sourceUpdated(sourceID string) {
+	e.mu.Lock()
+	if !e.initialized || e.loader == nil {
+		e.mu.Unlock()
+		return
+	}
+	e.sources = e.loader.GetAllSources()
+	e.updateSortedSources()
+	e.rebuildIndexes()
+	onUpdate := e.onUpdate
+	e.mu.Unlock()
+
+	if onUpdate != nil {
+		onUpdate(sourceID)
+	}
+}
+
+// WaitForSources blocks until API-backed sources have finished loading in the
+// background or ctx is done. Until then their fallback quotes are served.
+func (e *Engine) WaitForSources(ctx context.Context) error {
+	loader := e.GetLoader()
+	if loader == nil {
+		return nil
+	}
+	return loader.WaitForSources(ctx)
+}
+
+// SourceStatuses returns the load status of each source allowed by the
+// Hebrew language settings, ordered by ID.
+// Returns an empty slice if the engine is not initialized.
+func (e *Engine) SourceStatuses() []SourceStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.initialized || e.loader == nil {
+		return []SourceStatus{}
+	}
+	statuses := make([]SourceStatus, 0)
+	for _, status := range e.loader.SourceStatuses() {
+		if src, exists := e.loader.GetSource(status.ID); exists && e.sourceAllowed(src) {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// SourceProvenance returns where a source allowed by the Hebrew language
+// settings was defined: the file in effect and the definitions it shadows
+// or extends.
+func (e *Engine) SourceProvenance(id string) (*SourceProvenance, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.initialized || e.loader == nil {
+		return nil, false
+	}
+	if src, exists := e.loader.GetSource(id); !exists || !e.sourceAllowed(src) {
+		return nil, false
+	}
+	return e.loader.Provenance(id)
+}
+
+// loadAdvisors builds a new advisor registry from the built-in mappings and any
+// advisors.json files on the loader's search path, then swaps it in.
+// The caller must hold e.mu for writing.
+func (e *Engine) loadAdvisors() {
+	registry := NewAdvisorRegistry()
+	registry.Initialize()
+
+	var errs []error
+	if e.loader != nil {
+		lookup := func(id string) (*Source, bool) {
+			source, exists := e.sources[id]
+			return source, exists
+		}
+		for _, path := range e.loader.AdvisorConfigPaths() {
+			advisorsConfig, err := LoadAdvisorsConfig(path)
+			if err != nil {
+				if !errors.Is(err, fs.ErrNotExist) {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			errs = append(errs, registry.ApplyConfig(advisorsConfig, path, lookup)...)
+		}
+	}
+
+	e.advisors = registry
+	e.advisorErrs = errs
+}
+
+// AdvisorConfigErrors returns the problems found in advisors.json files when
+// advisors were last loaded. Invalid entries are skipped; built-in mappings
+// remain in effect for them.
+func (e *Engine) AdvisorConfigErrors() []error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]error(nil), e.advisorErrs...)
+}
+
 // GetWisdom retrieves a wisdom quote based on score and source.
 // The score determines the aeon level, which selects appropriate quotes from the source.
+// If source is empty, the configured default source is used; if none is configured
+// or that source is not available, a random source is selected instead.
 // If source is "random", a date-seeded random source is selected for consistency.
+// Returns ErrDisabled if wisdom is disabled by configuration, and ErrSourceFiltered
+// if the source is excluded by the Hebrew language settings.
+// The returned quote is a copy with WisdomSource and WisdomIcon set to the selected source.
+// For translated quotes it holds the texts shown by the Hebrew language settings:
+// the original alone with HebrewOnly, both with HebrewEnabled, and otherwise
+// the translation alone (in Quote). Use Quote.Text to display it.
 func (e *Engine) GetWisdom(score float64, source string) (*Quote, error) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
 	if !e.initialized {
-		return nil, fmt.Errorf("engine not initialized: call Initialize() before retrieving wisdom. This usually means sources.json could not be loaded")
+		return nil, fmt.Errorf("%w: call Initialize() before retrieving wisdom. This usually means sources.json could not be loaded", ErrNotInitialized)
+	}
+
+	if e.config.Disabled {
+		return nil, ErrDisabled
+	}
+
+	// Use the configured default source, falling back to random if it is unavailable
+	if source == "" {
+		source = e.config.Source
+		if src, exists := e.sources[source]; !exists || !e.sourceAllowed(src) {
+			source = "random"
+		}
 	}
 
 	// Handle "random" source selection
@@ -148,17 +467,110 @@ func (e *Engine) GetWisdom(score float64, source string) (*Quote, error) {
 			availableSources = append(availableSources, id)
 		}
 		if len(availableSources) > 0 {
-			return nil, fmt.Errorf("unknown source %q (available sources: %v). Use 'devwisdom sources' to list all sources", source, availableSources)
+			return nil, fmt.Errorf("%w %q (available sources: %v). Use 'devwisdom sources' to list all sources", ErrUnknownSource, source, availableSources)
 		}
-		return nil, fmt.Errorf("unknown source %q and no sources are available. Ensure sources.json is properly configured", source)
+		return nil, fmt.Errorf("%w %q and no sources are available. Ensure sources.json is properly configured", ErrUnknownSource, source)
+	}
+
+	if !e.sourceAllowed(src) {
+		return nil, fmt.Errorf("%w: source %q (language %q) is not enabled. Set EXARP_WISDOM_HEBREW=1 or adjust EXARP_WISDOM_HEBREW_ONLY", ErrSourceFiltered, source, src.Language)
 	}
 
 	// Determine aeon level from score
 	aeonLevel := GetAeonLevel(score)
 
 	// Get quote from source based on aeon level
-	quote := src.GetQuote(aeonLevel)
-	return quote, nil
+	quote := *e.selectQuote(source, src, aeonLevel, e.day.Key(e.clock.Now()))
+	if quote.WisdomSource == "" {
+		quote.WisdomSource = source
+	}
+	if quote.WisdomIcon == "" {
+		quote.WisdomIcon = src.Icon
+	}
+	quote = e.localize(quote)
+	return &quote, nil
+}
+
+// selectQuote picks a quote of src for aeonLevel on day using the configured
+// rotation mode. Cycling modes fall back to date-seeded selection when no
+// history is available.
+func (e *Engine) selectQuote(sourceID string, src *Source, aeonLevel, day string) *Quote {
+	if e.history == nil || !usesHistory(e.config.Rotation) {
+		return src.GetQuoteForDay(aeonLevel, day)
+	}
+	level, quotes := src.quotesForLevel(aeonLevel)
+	if len(quotes) == 0 {
+		return src.GetQuoteForDay(aeonLevel, day)
+	}
+	// The history is best-effort: a quote is still returned if it cannot be saved
+	i, _ := e.history.Next(sourceID, level, quotes, e.config.Rotation, day)
+	return &quotes[i]
+}
+
+// Now returns the current time from the engine's clock.
+func (e *Engine) Now() time.Time {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.clock.Now()
+}
+
+// Clock returns the engine's clock, for sharing with consultation logs.
+func (e *Engine) Clock() Clock {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.clock
+}
+
+// DayBoundary returns the configured day boundary used for daily selection.
+// It is the zero value (local midnight) until the engine is initialized.
+func (e *Engine) DayBoundary() DayBoundary {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.day
+}
+
+// IsDisabled reports whether wisdom output is disabled by configuration
+// (EXARP_DISABLE_WISDOM=1, a .exarp_no_wisdom marker file, or "disabled" in the config file).
+// Callers should produce no wisdom output when this returns true.
+func (e *Engine) IsDisabled() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.config.Disabled
+}
+
+// GetConfig returns the engine configuration.
+func (e *Engine) GetConfig() *config.Config {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.config
+}
+
+// Localize returns a copy of q with the texts shown by the Hebrew language
+// settings, as returned by GetWisdom. Use it for quotes read from a Source directly.
+func (e *Engine) Localize(q Quote) Quote {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.localize(q)
+}
+
+// localize returns q with the texts shown by the Hebrew language settings:
+// the translation is dropped unless config.ShowsTranslation, and replaces the
+// original unless config.ShowsOriginal.
+func (e *Engine) localize(q Quote) Quote {
+	if q.Translation == "" {
+		return q
+	}
+	if !e.config.ShowsTranslation() {
+		q.Translation = ""
+	} else if !e.config.ShowsOriginal() {
+		q.Quote, q.Translation = q.Translation, ""
+	}
+	return q
+}
+
+// sourceAllowed reports whether a source passes the Hebrew language settings.
+func (e *Engine) sourceAllowed(src *Source) bool {
+	return e.config.AllowsLanguage(src.Language)
 }
 
 // GetRandomSource returns a random wisdom source ID.
@@ -170,29 +582,32 @@ func (e *Engine) GetRandomSource(seedDate bool) (string, error) {
 	return e.getRandomSourceLocked(seedDate)
 }
 
-// updateSortedSources updates the cached sorted source list.
+// updateSortedSources updates the cached sorted list of sources allowed by the config.
 // This should be called whenever sources are loaded or reloaded.
 func (e *Engine) updateSortedSources() {
 	e.sortedSourcesMu.Lock()
 	defer e.sortedSourcesMu.Unlock()
 
-	// Get all available source IDs
+	// Get all available source IDs that pass the language settings
 	allSources := make([]string, 0, len(e.sources))
-	for id := range e.sources {
-		allSources = append(allSources, id)
+	for id, src := range e.sources {
+		if e.sourceAllowed(src) {
+			allSources = append(allSources, id)
+		}
 	}
 
 	// Sort sources to ensure deterministic order (Go map iteration is non-deterministic)
 	sort.Strings(allSources)
 
 	e.sortedSources = allSources
+	e.sortedSourcesTotal = len(e.sources)
 }
 
 // getDateHash computes and caches the date hash for random source selection.
 // The hash is cached per day to avoid recomputation.
 func (e *Engine) getDateHash() int64 {
-	now := time.Now()
-	dateStr := now.Format("20060102") // YYYYMMDD format
+	now := e.clock.Now()
+	dateStr := e.day.Key(now) // YYYYMMDD format
 
 	// Check if we have a cached hash for today
 	e.dateHashMu.RLock()
@@ -236,16 +651,17 @@ func (e *Engine) getDateHash() int64 {
 // getRandomSourceLocked is the internal implementation (assumes RLock is held)
 func (e *Engine) getRandomSourceLocked(seedDate bool) (string, error) {
 	if !e.initialized {
-		return "", fmt.Errorf("engine not initialized: call Initialize() before use")
+		return "", fmt.Errorf("%w: call Initialize() before use", ErrNotInitialized)
 	}
 
 	// Use cached sorted source list (performance optimization)
 	e.sortedSourcesMu.RLock()
 	allSources := e.sortedSources
+	total := e.sortedSourcesTotal
 	e.sortedSourcesMu.RUnlock()
 
 	// If cache is empty or out of sync, update it
-	if len(allSources) == 0 || len(allSources) != len(e.sources) {
+	if len(allSources) == 0 || total != len(e.sources) {
 		e.updateSortedSources()
 		e.sortedSourcesMu.RLock()
 		allSources = e.sortedSources
@@ -253,7 +669,7 @@ func (e *Engine) getRandomSourceLocked(seedDate bool) (string, error) {
 	}
 
 	if len(allSources) == 0 {
-		return "", fmt.Errorf("no sources available: ensure sources.json exists and contains valid source definitions")
+		return "", fmt.Errorf("%w: ensure sources.json exists and contains valid source definitions", ErrNoSources)
 	}
 
 	// Date-seeded random selection for consistency
@@ -261,7 +677,7 @@ func (e *Engine) getRandomSourceLocked(seedDate bool) (string, error) {
 	if seedDate {
 		seed = e.getDateHash()
 	} else {
-		seed = time.Now().UnixNano()
+		seed = e.clock.Now().UnixNano()
 	}
 
 	// Create seeded random generator
@@ -273,34 +689,50 @@ func (e *Engine) getRandomSourceLocked(seedDate bool) (string, error) {
 }
 
 // ListSources returns all available wisdom source IDs.
+// Sources excluded by the Hebrew language settings are omitted.
 // Returns an empty slice if the engine is not initialized.
 func (e *Engine) ListSources() []string {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
 	if e.loader != nil {
-		return e.loader.ListSourceIDs()
+		ids := e.loader.ListSourceIDs()
+		sources := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if src, exists := e.loader.GetSource(id); exists && e.sourceAllowed(src) {
+				sources = append(sources, id)
+			}
+		}
+		return sources
 	}
 
 	sources := make([]string, 0, len(e.sources))
-	for name := range e.sources {
-		sources = append(sources, name)
+	for name, src := range e.sources {
+		if e.sourceAllowed(src) {
+			sources = append(sources, name)
+		}
 	}
 	return sources
 }
 
 // GetSource returns a specific source by ID.
-// The second return value indicates whether the source was found.
+// The second return value indicates whether the source was found and is
+// allowed by the Hebrew language settings.
 func (e *Engine) GetSource(id string) (*Source, bool) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
+	var source *Source
+	var exists bool
 	if e.loader != nil {
-		return e.loader.GetSource(id)
+		source, exists = e.loader.GetSource(id)
+	} else {
+		source, exists = e.sources[id]
 	}
-
-	source, exists := e.sources[id]
-	return source, exists
+	if !exists || !e.sourceAllowed(source) {
+		return nil, false
+	}
+	return source, true
 }
 
 // GetLoader returns the source loader for advanced usage.
@@ -346,6 +778,56 @@ func (e *Engine) AddProjectSource(config *SourceConfig) error {
 
 	// Update engine's sources map from loader
 	e.sources = e.loader.GetAllSources()
+	e.updateSortedSources()
+	e.rebuildIndexes()
 
 	return nil
 }
+
+// Search finds quotes matching query across the loaded sources.
+// See SearchIndex.Search for the query syntax. Sources excluded by the Hebrew
+// language settings are never returned, and translated quotes are localized
+// as by GetWisdom. The index is rebuilt whenever sources
+// are reloaded.
+func (e *Engine) Search(query string, opts SearchOptions) ([]SearchResult, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.initialized {
+		return nil, fmt.Errorf("%w: call Initialize() before searching", ErrNotInitialized)
+	}
+	if e.config.Disabled {
+		return nil, ErrDisabled
+	}
+	if opts.Source != "" {
+		src, exists := e.sources[opts.Source]
+		if !exists {
+			return nil, fmt.Errorf("%w %q. Use 'devwisdom sources' to list all sources", ErrUnknownSource, opts.Source)
+		}
+		if !e.sourceAllowed(src) {
+			return nil, fmt.Errorf("%w: source %q (language %q) is not enabled", ErrSourceFiltered, opts.Source, src.Language)
+		}
+	}
+
+	// Filter excluded sources before limiting so they cannot crowd out results
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	opts.Limit = e.index.Len()
+	results, err := e.index.Search(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	allowed := results[:0]
+	for _, result := range results {
+		if src, exists := e.sources[result.SourceID]; exists && e.sourceAllowed(src) {
+			result.Quote = e.localize(result.Quote)
+			allowed = append(allowed, result)
+			if len(allowed) == limit {
+				break
+			}
+		}
+	}
+	return allowed, nil
+}
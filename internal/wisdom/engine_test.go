@@ -1,7 +1,11 @@
 package wisdom
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
 )
 
 func TestNewEngine(t *testing.T) {
@@ -514,3 +518,209 @@ func TestEngine_GetWisdom_EmptySourceQuotes(t *testing.T) {
 		t.Error("GetWisdom returned empty quote for empty source")
 	}
 }
+
+// newConfiguredEngine returns an initialized engine with one English and one
+// Hebrew source and the given configuration.
+func newConfiguredEngine(cfg *config.Config) *Engine {
+	engine := NewEngine().WithConfig(cfg)
+	engine.loader = nil // Serve sources from the engine map
+	engine.sources = map[string]*Source{
+		"stoic": {
+			Name: "Stoics",
+			Icon: "🏛️",
+			Quotes: map[string][]Quote{
+				"middle_aeons": {{Quote: "The obstacle is the way.", Source: "Marcus Aurelius"}},
+			},
+		},
+		"rebbe": {
+			Name:     "Rebbe",
+			Icon:     "🕎",
+			Language: "hebrew",
+			Quotes: map[string][]Quote{
+				"middle_aeons": {{Quote: "Who is wise? One who learns from everyone.", Source: "Pirkei Avot 4:1"}},
+			},
+		},
+	}
+	engine.initialized = true
+	engine.updateSortedSources()
+	engine.rebuildIndexes()
+	return engine
+}
+
+func TestEngine_GetWisdom_Disabled(t *testing.T) {
+	engine := newConfiguredEngine(&config.Config{Source: "stoic", Disabled: true})
+
+	if !engine.IsDisabled() {
+		t.Error("IsDisabled should report true")
+	}
+	if _, err := engine.GetWisdom(50, "stoic"); !errors.Is(err, ErrDisabled) {
+		t.Errorf("GetWisdom error = %v, want ErrDisabled", err)
+	}
+}
+
+func TestEngine_GetWisdom_DefaultSource(t *testing.T) {
+	engine := newConfiguredEngine(&config.Config{Source: "stoic"})
+
+	quote, err := engine.GetWisdom(50, "")
+	if err != nil {
+		t.Fatalf("GetWisdom failed: %v", err)
+	}
+	if quote.WisdomSource != "stoic" {
+		t.Errorf("WisdomSource = %q, want configured source stoic", quote.WisdomSource)
+	}
+
+	// An unknown default source falls back to random selection
+	engine = newConfiguredEngine(&config.Config{Source: "nonexistent"})
+	quote, err = engine.GetWisdom(50, "")
+	if err != nil {
+		t.Fatalf("GetWisdom with unknown default source failed: %v", err)
+	}
+	if quote.WisdomSource != "stoic" {
+		t.Errorf("WisdomSource = %q, want stoic (Hebrew sources are disabled by default)", quote.WisdomSource)
+	}
+
+	// Without a configured source, the default config selects a random source
+	engine = newConfiguredEngine(config.NewConfig())
+	quote, err = engine.GetWisdom(50, "")
+	if err != nil {
+		t.Fatalf("GetWisdom with the default config failed: %v", err)
+	}
+	if quote.WisdomSource != "stoic" {
+		t.Errorf("WisdomSource = %q, want a random allowed source (stoic)", quote.WisdomSource)
+	}
+}
+
+func TestEngine_HebrewFiltering(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		allowed map[string]bool
+	}{
+		{"default", &config.Config{}, map[string]bool{"stoic": true, "rebbe": false}},
+		{"hebrew enabled", &config.Config{HebrewEnabled: true}, map[string]bool{"stoic": true, "rebbe": true}},
+		{"hebrew only", &config.Config{HebrewOnly: true}, map[string]bool{"stoic": false, "rebbe": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newConfiguredEngine(tt.cfg)
+
+			listed := make(map[string]bool)
+			for _, id := range engine.ListSources() {
+				listed[id] = true
+			}
+
+			for id, want := range tt.allowed {
+				if listed[id] != want {
+					t.Errorf("ListSources includes %q = %v, want %v", id, listed[id], want)
+				}
+				if _, found := engine.GetSource(id); found != want {
+					t.Errorf("GetSource(%q) found = %v, want %v", id, found, want)
+				}
+				_, err := engine.GetWisdom(50, id)
+				if want && err != nil {
+					t.Errorf("GetWisdom(%q) failed: %v", id, err)
+				}
+				if !want && !errors.Is(err, ErrSourceFiltered) {
+					t.Errorf("GetWisdom(%q) error = %v, want ErrSourceFiltered", id, err)
+				}
+			}
+
+			quote, err := engine.GetWisdom(50, "random")
+			if err != nil {
+				t.Fatalf("GetWisdom(random) failed: %v", err)
+			}
+			if !tt.allowed[quote.WisdomSource] {
+				t.Errorf("random selection picked filtered source %q", quote.WisdomSource)
+			}
+		})
+	}
+}
+
+func TestEngine_TranslatedQuotes(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             *config.Config
+		source          string
+		query           string
+		wantQuote       string
+		wantTranslation string
+	}{
+		{"default shows the translation", &config.Config{}, "stoic", "fate", "Love of fate.", ""},
+		{"hebrew enabled shows both", &config.Config{HebrewEnabled: true}, "rebbe", "wise", "איזהו חכם", "Who is wise?"},
+		{"hebrew only shows the original", &config.Config{HebrewOnly: true}, "rebbe", "wise", "איזהו חכם", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newConfiguredEngine(tt.cfg)
+			engine.sources["stoic"].Quotes["middle_aeons"] = []Quote{{Quote: "Amor fati.", Translation: "Love of fate.", Source: "Nietzsche"}}
+			engine.sources["rebbe"].Quotes["middle_aeons"] = []Quote{{Quote: "איזהו חכם", Translation: "Who is wise?", Source: "Pirkei Avot 4:1"}}
+			engine.rebuildIndexes()
+
+			quote, err := engine.GetWisdom(50, tt.source)
+			if err != nil {
+				t.Fatalf("GetWisdom failed: %v", err)
+			}
+			if quote.Quote != tt.wantQuote || quote.Translation != tt.wantTranslation {
+				t.Errorf("GetWisdom = (%q, %q), want (%q, %q)", quote.Quote, quote.Translation, tt.wantQuote, tt.wantTranslation)
+			}
+
+			byID, err := engine.GetQuoteByID(quote.ID)
+			if err != nil {
+				t.Fatalf("GetQuoteByID failed: %v", err)
+			}
+			if byID.Text() != quote.Text() {
+				t.Errorf("GetQuoteByID text = %q, want %q", byID.Text(), quote.Text())
+			}
+
+			// Translations are searchable whatever is shown
+			results, err := engine.Search(tt.query, SearchOptions{Source: tt.source})
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(results) != 1 || results[0].Quote.Text() != quote.Text() {
+				t.Errorf("Search results = %+v, want the localized quote", results)
+			}
+		})
+	}
+}
+
+func TestEngine_IndependentProjects(t *testing.T) {
+	ids := []string{"project_alpha", "project_beta", "project_gamma"}
+	engines := make([]*Engine, len(ids))
+	for i, id := range ids {
+		engines[i] = NewEngine().WithLoader(newProjectLoader(t, id))
+	}
+
+	errs := make(chan error, len(ids))
+	for i, engine := range engines {
+		go func(id string, engine *Engine) {
+			if err := engine.Initialize(); err != nil {
+				errs <- err
+				return
+			}
+			quote, err := engine.GetWisdom(10, id)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if quote.Quote != "From "+id {
+				errs <- fmt.Errorf("engine for %s served %q", id, quote.Quote)
+				return
+			}
+			for _, other := range ids {
+				if _, exists := engine.GetSource(other); exists && other != id {
+					errs <- fmt.Errorf("engine for %s has source %s", id, other)
+					return
+				}
+			}
+			errs <- nil
+		}(ids[i], engine)
+	}
+	for range engines {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
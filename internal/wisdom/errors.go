@@ -0,0 +1,25 @@
+package wisdom
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by the engine and advisor registry.
+// Use errors.Is to check for them; the wrapping error carries the details.
+var (
+	// ErrNotInitialized is returned when the engine is used before Initialize().
+	ErrNotInitialized = errors.New("engine not initialized")
+	// ErrUnknownSource is returned when a wisdom source ID does not exist.
+	ErrUnknownSource = errors.New("unknown source")
+	// ErrNoSources is returned when no wisdom sources are loaded.
+	ErrNoSources = errors.New("no sources available")
+	// ErrUnknownAdvisor is returned when no advisor is mapped to a metric, tool, or stage.
+	ErrUnknownAdvisor = errors.New("no advisor found")
+	// ErrDisabled is returned when wisdom output is disabled by configuration
+	// (EXARP_DISABLE_WISDOM, .exarp_no_wisdom, or "disabled" in the config file).
+	ErrDisabled = errors.New("wisdom disabled")
+	// ErrSourceFiltered is returned when a source is excluded by the Hebrew language settings.
+	ErrSourceFiltered = errors.New("source excluded by language settings")
+	// ErrUnknownQuote is returned when no loaded quote has the requested ID.
+	ErrUnknownQuote = errors.New("unknown quote")
+	// ErrInvalidQuery is returned when a search query has no terms or cannot be parsed.
+	ErrInvalidQuery = errors.New("invalid search query")
+)
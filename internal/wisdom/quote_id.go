@@ -0,0 +1,121 @@
+package wisdom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// quoteIDHashLength is the number of hex digits of the content hash in a quote ID.
+const quoteIDHashLength = 12
+
+// QuoteID returns the content-derived ID of a quote in the given source:
+// the source ID followed by a hash of the quote text and attribution, e.g.
+// "stoic-3f2a9c1b7d40". The ID does not depend on the quote's position in
+// the source or its aeon level, so reordering sources.json keeps it stable.
+func QuoteID(sourceID string, q *Quote) string {
+	return sourceID + "-" + quoteContentHash(q)
+}
+
+// quoteContentHash hashes the quote text and attribution. A translation is
+// hashed as displayed (see Quote.Text), so moving an inline "original
+// (translation)" text into a translation field keeps the ID.
+func quoteContentHash(q *Quote) string {
+	sum := sha256.Sum256([]byte(q.Text() + "\x00" + q.Source))
+	return hex.EncodeToString(sum[:])[:quoteIDHashLength]
+}
+
+// assignQuoteIDs sets a content-derived ID on every quote of src that has no
+// explicit ID.
+func assignQuoteIDs(sourceID string, src *Source) {
+	for level := range src.Quotes {
+		quotes := src.Quotes[level]
+		for i := range quotes {
+			if quotes[i].ID == "" {
+				quotes[i].ID = QuoteID(sourceID, &quotes[i])
+			}
+		}
+	}
+}
+
+// quoteRef locates a quote in the engine's sources.
+type quoteRef struct {
+	sourceID  string
+	aeonLevel string
+	index     int
+}
+
+// buildQuoteIndex assigns missing quote IDs and maps every ID to its quote.
+// Sources are visited in sorted order, so when an explicit ID is reused the
+// first source by ID wins.
+func buildQuoteIndex(sources map[string]*Source) map[string]quoteRef {
+	ids := make([]string, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	refs := make(map[string]quoteRef)
+	for _, sourceID := range ids {
+		src := sources[sourceID]
+		assignQuoteIDs(sourceID, src)
+
+		levels := make([]string, 0, len(src.Quotes))
+		for level := range src.Quotes {
+			levels = append(levels, level)
+		}
+		sort.Strings(levels)
+		for _, level := range levels {
+			for i, quote := range src.Quotes[level] {
+				if _, exists := refs[quote.ID]; !exists {
+					refs[quote.ID] = quoteRef{sourceID: sourceID, aeonLevel: level, index: i}
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// rebuildIndexes refreshes the quote ID and search indexes after sources change.
+// The caller must hold e.mu for writing.
+func (e *Engine) rebuildIndexes() {
+	e.quotesByID = buildQuoteIndex(e.sources)
+	e.index = NewSearchIndex(e.sources)
+}
+
+// GetQuoteByID returns the quote with the given ID and the ID of its source.
+// The returned quote is a copy with WisdomSource and WisdomIcon set, holding
+// the texts shown by the Hebrew language settings (see Engine.GetWisdom).
+// Returns ErrUnknownQuote if no loaded quote has the ID, and ErrSourceFiltered
+// if its source is excluded by the Hebrew language settings.
+func (e *Engine) GetQuoteByID(id string) (*Quote, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.initialized {
+		return nil, fmt.Errorf("%w: call Initialize() before retrieving quotes", ErrNotInitialized)
+	}
+	if e.config.Disabled {
+		return nil, ErrDisabled
+	}
+
+	ref, exists := e.quotesByID[id]
+	if !exists {
+		return nil, fmt.Errorf("%w %q. Quote IDs are shown in JSON output (e.g., 'devwisdom quote --json')", ErrUnknownQuote, id)
+	}
+	src := e.sources[ref.sourceID]
+	if !e.sourceAllowed(src) {
+		return nil, fmt.Errorf("%w: quote %q is from source %q (language %q), which is not enabled", ErrSourceFiltered, id, ref.sourceID, src.Language)
+	}
+
+	quote := src.Quotes[ref.aeonLevel][ref.index]
+	if quote.WisdomSource == "" {
+		quote.WisdomSource = ref.sourceID
+	}
+	if quote.WisdomIcon == "" {
+		quote.WisdomIcon = src.Icon
+	}
+	quote = e.localize(quote)
+	return &quote, nil
+}
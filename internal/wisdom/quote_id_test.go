@@ -0,0 +1,119 @@
+package wisdom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+)
+
+func TestQuoteID_Stable(t *testing.T) {
+	q := Quote{Quote: "The obstacle is the way.", Source: "Marcus Aurelius", Encouragement: "Keep going."}
+	id := QuoteID("stoic", &q)
+
+	if !strings.HasPrefix(id, "stoic-") || len(id) != len("stoic-")+quoteIDHashLength {
+		t.Errorf("QuoteID = %q, want stoic- followed by %d hex digits", id, quoteIDHashLength)
+	}
+
+	// Encouragement and aeon level do not affect the ID
+	edited := q
+	edited.Encouragement = "Different encouragement."
+	if got := QuoteID("stoic", &edited); got != id {
+		t.Errorf("QuoteID changed with encouragement: %q != %q", got, id)
+	}
+
+	// Text and attribution do
+	edited = q
+	edited.Source = "Seneca"
+	if got := QuoteID("stoic", &edited); got == id {
+		t.Error("QuoteID should change with the attribution")
+	}
+}
+
+func TestQuoteID_Translation(t *testing.T) {
+	inline := Quote{Quote: "איזהו חכם (Who is wise?)", Source: "Pirkei Avot 4:1"}
+	split := Quote{Quote: "איזהו חכם", Translation: "Who is wise?", Source: "Pirkei Avot 4:1"}
+
+	if split.Text() != inline.Quote {
+		t.Errorf("Text() = %q, want %q", split.Text(), inline.Quote)
+	}
+	if QuoteID("rebbe", &split) != QuoteID("rebbe", &inline) {
+		t.Error("moving the translation into its own field should keep the quote ID")
+	}
+}
+
+func TestBuildQuoteIndex_ReorderKeepsIDs(t *testing.T) {
+	first := Quote{Quote: "First.", Source: "A"}
+	second := Quote{Quote: "Second.", Source: "B"}
+	explicit := Quote{ID: "custom-id", Quote: "Third.", Source: "C"}
+
+	ids := func(quotes ...Quote) map[string]string {
+		sources := map[string]*Source{"src": {Quotes: map[string][]Quote{"chaos": quotes}}}
+		refs := buildQuoteIndex(sources)
+		byText := make(map[string]string)
+		for _, q := range sources["src"].Quotes["chaos"] {
+			if _, ok := refs[q.ID]; !ok {
+				t.Fatalf("quote %q with ID %q is not indexed", q.Quote, q.ID)
+			}
+			byText[q.Quote] = q.ID
+		}
+		return byText
+	}
+
+	before := ids(first, second, explicit)
+	after := ids(explicit, second, first)
+	for text, id := range before {
+		if after[text] != id {
+			t.Errorf("ID of %q changed after reordering: %q -> %q", text, id, after[text])
+		}
+	}
+	if before["Third."] != "custom-id" {
+		t.Errorf("explicit ID = %q, want custom-id", before["Third."])
+	}
+}
+
+func TestEngine_GetQuoteByID(t *testing.T) {
+	engine := newConfiguredEngine(&config.Config{Source: "stoic"})
+	id := QuoteID("stoic", &Quote{Quote: "The obstacle is the way.", Source: "Marcus Aurelius"})
+
+	quote, err := engine.GetQuoteByID(id)
+	if err != nil {
+		t.Fatalf("GetQuoteByID(%q) failed: %v", id, err)
+	}
+	if quote.ID != id || quote.Quote != "The obstacle is the way." {
+		t.Errorf("GetQuoteByID returned %+v", quote)
+	}
+	if quote.WisdomSource != "stoic" || quote.WisdomIcon != "🏛️" {
+		t.Errorf("WisdomSource/WisdomIcon = %q/%q, want stoic/🏛️", quote.WisdomSource, quote.WisdomIcon)
+	}
+
+	// The returned quote is a copy
+	quote.Quote = "modified"
+	if again, _ := engine.GetQuoteByID(id); again.Quote == "modified" {
+		t.Error("GetQuoteByID returned a quote sharing the source's storage")
+	}
+
+	// GetWisdom results carry the same ID
+	if wisdomQuote, err := engine.GetWisdom(50, "stoic"); err != nil || wisdomQuote.ID != id {
+		t.Errorf("GetWisdom ID = %v (err %v), want %q", wisdomQuote, err, id)
+	}
+
+	if _, err := engine.GetQuoteByID("stoic-000000000000"); !errors.Is(err, ErrUnknownQuote) {
+		t.Errorf("unknown ID error = %v, want ErrUnknownQuote", err)
+	}
+
+	hebrewID := QuoteID("rebbe", &Quote{Quote: "Who is wise? One who learns from everyone.", Source: "Pirkei Avot 4:1"})
+	if _, err := engine.GetQuoteByID(hebrewID); !errors.Is(err, ErrSourceFiltered) {
+		t.Errorf("filtered source error = %v, want ErrSourceFiltered", err)
+	}
+
+	disabled := newConfiguredEngine(&config.Config{Source: "stoic", Disabled: true})
+	if _, err := disabled.GetQuoteByID(id); !errors.Is(err, ErrDisabled) {
+		t.Errorf("disabled error = %v, want ErrDisabled", err)
+	}
+
+	if _, err := NewEngine().GetQuoteByID(id); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("uninitialized error = %v, want ErrNotInitialized", err)
+	}
+}
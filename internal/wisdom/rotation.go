@@ -0,0 +1,233 @@
+package wisdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Quote rotation modes (config "rotation" or EXARP_WISDOM_ROTATION).
+const (
+	// RotationDaily picks a date-seeded quote: the same quote all day, with no
+	// memory of earlier days. This is the default.
+	RotationDaily = "daily"
+	// RotationCycle shows a different quote on every request, cycling through
+	// all quotes of an aeon level before any repeats.
+	RotationCycle = "cycle"
+	// RotationCycleDaily cycles like RotationCycle but keeps the same quote all
+	// day, advancing to the next unseen quote once per day.
+	RotationCycleDaily = "cycle_daily"
+)
+
+// DefaultHistoryDir is the directory holding quote rotation history.
+const DefaultHistoryDir = ".devwisdom"
+
+// ParseRotation validates a rotation mode. An empty mode is RotationDaily.
+func ParseRotation(mode string) (string, error) {
+	switch mode {
+	case "", RotationDaily:
+		return RotationDaily, nil
+	case RotationCycle, RotationCycleDaily:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown rotation mode %q: must be %q, %q, or %q", mode, RotationDaily, RotationCycle, RotationCycleDaily)
+	}
+}
+
+// usesHistory reports whether a rotation mode needs a quote history.
+func usesHistory(mode string) bool {
+	return mode == RotationCycle || mode == RotationCycleDaily
+}
+
+// rotationState is the persisted rotation of one source's aeon level.
+type rotationState struct {
+	Cycle int      `json:"cycle"`          // Number of completed cycles
+	Seen  []string `json:"seen"`           // Quote IDs shown in the current cycle
+	Last  string   `json:"last,omitempty"` // Most recently shown quote ID
+	Day   string   `json:"day,omitempty"`  // Date Last was shown (YYYYMMDD)
+}
+
+// historyFile is the on-disk quote history of one user.
+type historyFile struct {
+	User    string                               `json:"user"`
+	Sources map[string]map[string]*rotationState `json:"sources"` // Source ID -> aeon level -> state
+}
+
+// QuoteHistory records which quotes a user has seen, per source and aeon
+// level, so rotation can show every quote before repeating one.
+// The history is persisted as JSON in <dir>/quote_history_<user>.json.
+// It is safe for concurrent use.
+type QuoteHistory struct {
+	path   string
+	user   string
+	mu     sync.Mutex
+	loaded bool
+	state  historyFile
+}
+
+// NewQuoteHistory creates a quote history for user stored in dir.
+// An empty user uses the current OS user.
+func NewQuoteHistory(dir, userName string) *QuoteHistory {
+	if userName == "" {
+		userName = currentUser()
+	}
+	return &QuoteHistory{
+		path: filepath.Join(dir, "quote_history_"+sanitizeFileName(userName)+".json"),
+		user: userName,
+	}
+}
+
+// Path returns the history file path.
+func (h *QuoteHistory) Path() string {
+	return h.path
+}
+
+// currentUser returns the current OS user name, or "default" if unknown.
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "default"
+}
+
+// sanitizeFileName replaces characters that are unsafe in file names
+// (e.g. the backslash in Windows DOMAIN\user names).
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// load reads the history file once. A missing or corrupt file starts a new history.
+// The caller must hold h.mu.
+func (h *QuoteHistory) load() {
+	if h.loaded {
+		return
+	}
+	h.loaded = true
+	h.state = historyFile{}
+	if data, err := os.ReadFile(h.path); err == nil {
+		_ = json.Unmarshal(data, &h.state)
+	}
+	h.state.User = h.user
+	if h.state.Sources == nil {
+		h.state.Sources = make(map[string]map[string]*rotationState)
+	}
+}
+
+// save writes the history file atomically. The caller must hold h.mu.
+func (h *QuoteHistory) save() error {
+	data, err := json.MarshalIndent(h.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal quote history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create quote history directory %q: %w", filepath.Dir(h.path), err)
+	}
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write quote history %q: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		return fmt.Errorf("failed to replace quote history %q: %w", h.path, err)
+	}
+	return nil
+}
+
+// Next selects the next quote of a source's aeon level for rotation mode and
+// records it in the history. It returns an index into quotes.
+//
+// Unseen quotes are chosen in a per-user order; once every quote has been
+// seen a new cycle starts, never beginning with the quote just shown.
+// In RotationCycleDaily mode the same quote is returned until day (YYYYMMDD,
+// see DayBoundary.Key) changes.
+// The selection is returned even if saving the history fails.
+func (h *QuoteHistory) Next(sourceID, aeonLevel string, quotes []Quote, mode, day string) (int, error) {
+	if len(quotes) == 0 {
+		return 0, fmt.Errorf("source %q has no quotes for aeon level %q", sourceID, aeonLevel)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.load()
+
+	levels := h.state.Sources[sourceID]
+	if levels == nil {
+		levels = make(map[string]*rotationState)
+		h.state.Sources[sourceID] = levels
+	}
+	state := levels[aeonLevel]
+	if state == nil {
+		state = &rotationState{}
+		levels[aeonLevel] = state
+	}
+
+	ids := make([]string, len(quotes))
+	current := make(map[string]int, len(quotes))
+	for i := range quotes {
+		ids[i] = quotes[i].ID
+		if ids[i] == "" {
+			ids[i] = QuoteID(sourceID, &quotes[i])
+		}
+		current[ids[i]] = i
+	}
+
+	if mode == RotationCycleDaily && state.Day == day {
+		if i, ok := current[state.Last]; ok {
+			return i, nil
+		}
+	}
+
+	// Forget quotes that were removed from the source
+	seen := make(map[string]bool, len(state.Seen))
+	kept := state.Seen[:0]
+	for _, id := range state.Seen {
+		if _, ok := current[id]; ok && !seen[id] {
+			seen[id] = true
+			kept = append(kept, id)
+		}
+	}
+	state.Seen = kept
+
+	// Identical quotes share an ID, so a cycle covers the distinct IDs
+	if len(seen) >= len(current) {
+		state.Cycle++
+		state.Seen = nil
+		seen = map[string]bool{}
+		if len(current) > 1 {
+			// Don't repeat the last quote across the cycle boundary
+			seen[state.Last] = true
+		}
+	}
+
+	selected := -1
+	var best uint64
+	for i, id := range ids {
+		if seen[id] {
+			continue
+		}
+		hash := fnv.New64a()
+		fmt.Fprintf(hash, "rotation:%s:%s:%s:%d:%s", h.user, sourceID, aeonLevel, state.Cycle, id)
+		if rank := hash.Sum64(); selected < 0 || rank > best {
+			selected, best = i, rank
+		}
+	}
+
+	state.Seen = append(state.Seen, ids[selected])
+	state.Last = ids[selected]
+	state.Day = day
+	return selected, h.save()
+}
@@ -0,0 +1,190 @@
+package wisdom
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+)
+
+func rotationQuotes(n int) []Quote {
+	quotes := make([]Quote, n)
+	for i := range quotes {
+		quotes[i] = Quote{Quote: fmt.Sprintf("Quote %d", i), Source: "Test"}
+		quotes[i].ID = QuoteID("test", &quotes[i])
+	}
+	return quotes
+}
+
+func TestParseRotation(t *testing.T) {
+	for mode, want := range map[string]string{"": RotationDaily, "daily": RotationDaily, "cycle": RotationCycle, "cycle_daily": RotationCycleDaily} {
+		if got, err := ParseRotation(mode); err != nil || got != want {
+			t.Errorf("ParseRotation(%q) = %q, %v; want %q", mode, got, err, want)
+		}
+	}
+	if _, err := ParseRotation("weekly"); err == nil {
+		t.Error("ParseRotation should reject unknown modes")
+	}
+}
+
+func TestEngine_Initialize_InvalidRotation(t *testing.T) {
+	t.Setenv("EXARP_WISDOM_ROTATION", "cycel")
+	if err := NewEngine().Initialize(); err == nil || !strings.Contains(err.Error(), "EXARP_WISDOM_ROTATION") {
+		t.Errorf("Initialize() error = %v, want an invalid rotation error", err)
+	}
+}
+
+func TestQuoteHistory_CyclesBeforeRepeating(t *testing.T) {
+	dir := t.TempDir()
+	quotes := rotationQuotes(5)
+	day := "20260301"
+
+	history := NewQuoteHistory(dir, "alice")
+	var last int
+	for cycle := 0; cycle < 3; cycle++ {
+		seen := make(map[int]bool)
+		for i := 0; i < len(quotes); i++ {
+			index, err := history.Next("test", "chaos", quotes, RotationCycle, day)
+			if err != nil {
+				t.Fatalf("Next failed: %v", err)
+			}
+			if seen[index] {
+				t.Fatalf("cycle %d repeated quote %d before showing all quotes", cycle, index)
+			}
+			if cycle > 0 && i == 0 && index == last {
+				t.Errorf("cycle %d started with the quote that ended the previous cycle", cycle)
+			}
+			seen[index] = true
+			last = index
+		}
+	}
+
+	// The history is persisted: a new history for the same user continues the cycle
+	reloaded := NewQuoteHistory(dir, "alice")
+	index, err := reloaded.Next("test", "chaos", quotes, RotationCycle, day)
+	if err != nil {
+		t.Fatalf("Next after reload failed: %v", err)
+	}
+	if index == last {
+		t.Errorf("reloaded history repeated the last quote %d", index)
+	}
+	if _, err := os.Stat(reloaded.Path()); err != nil {
+		t.Errorf("history file not written: %v", err)
+	}
+}
+
+func TestQuoteHistory_DuplicateQuotes(t *testing.T) {
+	// Identical quotes share an ID; sources with them only get a validation warning
+	quotes := append(rotationQuotes(2), rotationQuotes(2)...)
+	for _, mode := range []string{RotationCycle, RotationCycleDaily} {
+		history := NewQuoteHistory(t.TempDir(), "alice")
+		for i := 0; i < 6; i++ {
+			day := fmt.Sprintf("202603%02d", i+1)
+			if _, err := history.Next("test", "chaos", quotes, mode, day); err != nil {
+				t.Fatalf("%s: Next failed: %v", mode, err)
+			}
+		}
+
+		// A level of one quote repeated
+		single := append(rotationQuotes(1), rotationQuotes(1)...)
+		for i := 0; i < 3; i++ {
+			if index, err := history.Next("test", "upper_aeons", single, mode, fmt.Sprintf("202604%02d", i+1)); err != nil || single[index].Quote != single[0].Quote {
+				t.Fatalf("%s: Next = %d, %v; want the only quote", mode, index, err)
+			}
+		}
+	}
+}
+
+func TestQuoteHistory_PerUserAndSource(t *testing.T) {
+	dir := t.TempDir()
+	quotes := rotationQuotes(3)
+	day := "20260301"
+
+	alice := NewQuoteHistory(dir, "alice")
+	bob := NewQuoteHistory(dir, "bob")
+	if alice.Path() == bob.Path() {
+		t.Fatalf("users share history file %q", alice.Path())
+	}
+
+	for i := 0; i < len(quotes); i++ {
+		if _, err := alice.Next("test", "chaos", quotes, RotationCycle, day); err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+	}
+	// Alice's full cycle doesn't affect Bob or another aeon level
+	for _, h := range []struct {
+		history *QuoteHistory
+		level   string
+	}{{bob, "chaos"}, {alice, "treasury"}} {
+		seen := make(map[int]bool)
+		for i := 0; i < len(quotes); i++ {
+			index, _ := h.history.Next("test", h.level, quotes, RotationCycle, day)
+			if seen[index] {
+				t.Errorf("%s/%s repeated quote %d within its first cycle", h.history.user, h.level, index)
+			}
+			seen[index] = true
+		}
+	}
+}
+
+func TestQuoteHistory_CycleDaily(t *testing.T) {
+	history := NewQuoteHistory(t.TempDir(), "alice")
+	quotes := rotationQuotes(4)
+
+	first, _ := history.Next("test", "chaos", quotes, RotationCycleDaily, "20260301")
+	if again, _ := history.Next("test", "chaos", quotes, RotationCycleDaily, "20260301"); again != first {
+		t.Errorf("cycle_daily changed quote within a day: %d -> %d", first, again)
+	}
+	if next, _ := history.Next("test", "chaos", quotes, RotationCycleDaily, "20260302"); next == first {
+		t.Errorf("cycle_daily repeated quote %d on the next day", first)
+	}
+}
+
+func TestQuoteHistory_RemovedQuotes(t *testing.T) {
+	history := NewQuoteHistory(t.TempDir(), "alice")
+	quotes := rotationQuotes(3)
+	day := "20260301"
+
+	for i := 0; i < 2; i++ {
+		if _, err := history.Next("test", "chaos", quotes, RotationCycle, day); err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+	}
+	// Shrinking the source to one quote must still return a valid index
+	index, err := history.Next("test", "chaos", quotes[2:], RotationCycle, day)
+	if err != nil || index != 0 {
+		t.Errorf("Next with one remaining quote = %d, %v; want 0", index, err)
+	}
+	if _, err := history.Next("test", "chaos", nil, RotationCycle, day); err == nil {
+		t.Error("Next with no quotes should fail")
+	}
+}
+
+func TestEngine_GetWisdom_Rotation(t *testing.T) {
+	engine := newConfiguredEngine(&config.Config{Source: "stoic", Rotation: RotationCycle})
+	engine.sources["stoic"].Quotes["middle_aeons"] = rotationQuotes(4)
+	engine.rebuildIndexes()
+	engine.history = NewQuoteHistory(t.TempDir(), "alice")
+
+	seen := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		quote, err := engine.GetWisdom(50, "stoic")
+		if err != nil {
+			t.Fatalf("GetWisdom failed: %v", err)
+		}
+		if seen[quote.ID] {
+			t.Fatalf("GetWisdom repeated %q before cycling through all quotes", quote.Quote)
+		}
+		seen[quote.ID] = true
+	}
+
+	// Daily mode ignores the history: the same quote on every call
+	daily := newConfiguredEngine(&config.Config{Source: "stoic"})
+	daily.sources["stoic"].Quotes["middle_aeons"] = rotationQuotes(4)
+	first, _ := daily.GetWisdom(50, "stoic")
+	if again, _ := daily.GetWisdom(50, "stoic"); again.Quote != first.Quote {
+		t.Errorf("daily rotation changed quote: %q -> %q", first.Quote, again.Quote)
+	}
+}
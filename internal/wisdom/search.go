@@ -0,0 +1,333 @@
+package wisdom
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultSearchLimit is the number of results returned when no limit is given.
+const DefaultSearchLimit = 10
+
+// Field weights: a match in the quote text counts more than one in its
+// attribution or encouragement.
+const (
+	weightQuote         = 3.0
+	weightSource        = 2.0
+	weightEncouragement = 1.0
+	// prefixDiscount scales matches of prefix expansions below exact term matches.
+	prefixDiscount = 0.8
+)
+
+// defaultLanguage is the language of sources that do not declare one.
+const defaultLanguage = "english"
+
+// Indexed quote fields.
+const (
+	fieldQuote = iota // Quote text and its translation
+	fieldSource
+	fieldEncouragement
+	fieldCount
+)
+
+var fieldWeights = [fieldCount]float64{weightQuote, weightSource, weightEncouragement}
+
+// SearchOptions filters and limits a quote search.
+type SearchOptions struct {
+	Source    string // Source ID; empty searches all sources
+	AeonLevel string // Aeon level (chaos, lower_aeons, ...); empty searches all levels
+	Language  string // Source language (e.g., "english", "hebrew"); empty searches all
+	Limit     int    // Maximum results (default: DefaultSearchLimit)
+}
+
+// SearchResult is a quote matching a search, with its location and relevance.
+type SearchResult struct {
+	SourceID  string  `json:"source_id"`
+	AeonLevel string  `json:"aeon_level"`
+	Language  string  `json:"language"`
+	Score     float64 `json:"score"` // Relevance; higher is better
+	Quote     Quote   `json:"quote"`
+}
+
+// searchDoc is an indexed quote.
+type searchDoc struct {
+	sourceID  string
+	aeonLevel string
+	language  string
+	quote     Quote
+}
+
+// posting records where a term occurs in a document field.
+type posting struct {
+	doc       int
+	field     int
+	positions []int
+}
+
+// SearchIndex is an in-memory inverted index over the quotes of a set of sources.
+// It is immutable once built and safe for concurrent use.
+type SearchIndex struct {
+	docs     []searchDoc
+	postings map[string][]posting
+	terms    []string // Sorted index terms, for prefix expansion
+	docFreq  map[string]int
+}
+
+// NewSearchIndex indexes the quote text, attribution, and encouragement of
+// every quote in sources.
+func NewSearchIndex(sources map[string]*Source) *SearchIndex {
+	idx := &SearchIndex{
+		postings: make(map[string][]posting),
+		docFreq:  make(map[string]int),
+	}
+
+	// Deterministic document order keeps result ties stable
+	ids := make([]string, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		source := sources[id]
+		language := strings.ToLower(source.Language)
+		if language == "" {
+			language = defaultLanguage
+		}
+		levels := make([]string, 0, len(source.Quotes))
+		for level := range source.Quotes {
+			levels = append(levels, level)
+		}
+		sort.Strings(levels)
+
+		for _, level := range levels {
+			for _, quote := range source.Quotes[level] {
+				idx.add(searchDoc{sourceID: id, aeonLevel: level, language: language, quote: quote})
+			}
+		}
+	}
+
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	sort.Strings(idx.terms)
+	return idx
+}
+
+// add indexes a document.
+func (idx *SearchIndex) add(doc searchDoc) {
+	docID := len(idx.docs)
+	idx.docs = append(idx.docs, doc)
+
+	seen := make(map[string]bool)
+	fields := [fieldCount]string{doc.quote.Text(), doc.quote.Source, doc.quote.Encouragement}
+	for field, text := range fields {
+		positions := make(map[string][]int)
+		for pos, token := range tokenize(text) {
+			positions[token] = append(positions[token], pos)
+		}
+		for token, pos := range positions {
+			idx.postings[token] = append(idx.postings[token], posting{doc: docID, field: field, positions: pos})
+			if !seen[token] {
+				seen[token] = true
+				idx.docFreq[token]++
+			}
+		}
+	}
+}
+
+// Len returns the number of indexed quotes.
+func (idx *SearchIndex) Len() int {
+	return len(idx.docs)
+}
+
+// tokenize splits text into lowercase words. Letters and digits of any script
+// form words, so Hebrew text is indexed too.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+}
+
+// queryClause is one part of a parsed query: a term, a prefix ("learn*"), or
+// a phrase ("\"the way\"").
+type queryClause struct {
+	terms  []string
+	prefix bool
+}
+
+// parseQuery splits a query into clauses. Double-quoted text is a phrase and a
+// trailing * makes a term a prefix query.
+func parseQuery(query string) ([]queryClause, error) {
+	var clauses []queryClause
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			// Inside quotes: a phrase
+			if terms := tokenize(part); len(terms) > 0 {
+				clauses = append(clauses, queryClause{terms: terms})
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			terms := tokenize(word)
+			for _, term := range terms {
+				clauses = append(clauses, queryClause{terms: []string{term}})
+			}
+			if strings.HasSuffix(word, "*") && len(terms) > 0 {
+				clauses[len(clauses)-1].prefix = true
+			}
+		}
+	}
+	if strings.Count(query, `"`)%2 == 1 {
+		return nil, fmt.Errorf("%w: unbalanced quote in %q", ErrInvalidQuery, query)
+	}
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("%w: query %q has no search terms", ErrInvalidQuery, query)
+	}
+	return clauses, nil
+}
+
+// Search returns the quotes matching every clause of query, most relevant first.
+//
+// Terms are matched case-insensitively; "term*" matches any word starting with
+// term and "\"a phrase\"" matches consecutive words. Relevance is TF-IDF weighted
+// by field: quote text, then attribution, then encouragement.
+func (idx *SearchIndex) Search(query string, opts SearchOptions) ([]SearchResult, error) {
+	clauses, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	var scores map[int]float64
+	for _, clause := range clauses {
+		clauseScores := idx.scoreClause(clause)
+		if scores == nil {
+			scores = clauseScores
+			continue
+		}
+		// Every clause must match
+		for doc, score := range scores {
+			if clauseScore, ok := clauseScores[doc]; ok {
+				scores[doc] = score + clauseScore
+			} else {
+				delete(scores, doc)
+			}
+		}
+	}
+
+	results := make([]SearchResult, 0, len(scores))
+	for docID, score := range scores {
+		doc := idx.docs[docID]
+		if !opts.matches(doc) {
+			continue
+		}
+		results = append(results, SearchResult{
+			SourceID:  doc.sourceID,
+			AeonLevel: doc.aeonLevel,
+			Language:  doc.language,
+			Score:     math.Round(score*1000) / 1000,
+			Quote:     doc.quote,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].SourceID != results[j].SourceID {
+			return results[i].SourceID < results[j].SourceID
+		}
+		return results[i].Quote.Quote < results[j].Quote.Quote
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// matches reports whether a document passes the search filters.
+func (opts SearchOptions) matches(doc searchDoc) bool {
+	return (opts.Source == "" || opts.Source == doc.sourceID) &&
+		(opts.AeonLevel == "" || opts.AeonLevel == doc.aeonLevel) &&
+		(opts.Language == "" || strings.EqualFold(opts.Language, doc.language))
+}
+
+// scoreClause returns the relevance of each document matching clause.
+func (idx *SearchIndex) scoreClause(clause queryClause) map[int]float64 {
+	scores := make(map[int]float64)
+	switch {
+	case clause.prefix:
+		term := clause.terms[0]
+		start := sort.SearchStrings(idx.terms, term)
+		for i := start; i < len(idx.terms) && strings.HasPrefix(idx.terms[i], term); i++ {
+			weight := 1.0
+			if idx.terms[i] != term {
+				weight = prefixDiscount
+			}
+			idx.scoreTerm(idx.terms[i], weight, scores)
+		}
+	case len(clause.terms) == 1:
+		idx.scoreTerm(clause.terms[0], 1, scores)
+	default:
+		idx.scorePhrase(clause.terms, scores)
+	}
+	return scores
+}
+
+// scoreTerm adds the weighted TF-IDF of term to each document containing it.
+func (idx *SearchIndex) scoreTerm(term string, weight float64, scores map[int]float64) {
+	idf := idx.idf(term)
+	for _, p := range idx.postings[term] {
+		scores[p.doc] += weight * fieldWeights[p.field] * float64(len(p.positions)) * idf
+	}
+}
+
+// scorePhrase adds relevance for each document field containing terms consecutively.
+func (idx *SearchIndex) scorePhrase(terms []string, scores map[int]float64) {
+	idf := 0.0
+	for _, term := range terms {
+		idf += idx.idf(term)
+	}
+
+	type fieldKey struct{ doc, field int }
+	positions := make([]map[fieldKey][]int, len(terms))
+	for i, term := range terms {
+		positions[i] = make(map[fieldKey][]int)
+		for _, p := range idx.postings[term] {
+			positions[i][fieldKey{p.doc, p.field}] = p.positions
+		}
+	}
+
+	for key, starts := range positions[0] {
+		occurrences := 0
+		for _, start := range starts {
+			matched := true
+			for i := 1; i < len(terms) && matched; i++ {
+				matched = containsInt(positions[i][key], start+i)
+			}
+			if matched {
+				occurrences++
+			}
+		}
+		if occurrences > 0 {
+			scores[key.doc] += fieldWeights[key.field] * float64(occurrences) * idf
+		}
+	}
+}
+
+// idf returns the inverse document frequency of term.
+func (idx *SearchIndex) idf(term string) float64 {
+	return math.Log(1 + float64(len(idx.docs))/float64(max(idx.docFreq[term], 1)))
+}
+
+// containsInt reports whether sorted contains v.
+func containsInt(sorted []int, v int) bool {
+	i := sort.SearchInts(sorted, v)
+	return i < len(sorted) && sorted[i] == v
+}
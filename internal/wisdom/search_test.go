@@ -0,0 +1,161 @@
+package wisdom
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+)
+
+func newTestSearchIndex() *SearchIndex {
+	return NewSearchIndex(map[string]*Source{
+		"stoic": {
+			Name: "Stoics",
+			Quotes: map[string][]Quote{
+				"chaos": {
+					{Quote: "What stands in the way becomes the path.", Source: "Marcus Aurelius", Encouragement: "Obstacles teach."},
+					{Quote: "Courage is calmness under fire.", Source: "Seneca", Encouragement: "Breathe."},
+				},
+				"treasury": {
+					{Quote: "Learning never ends.", Source: "Epictetus", Encouragement: "Keep the way."},
+				},
+			},
+		},
+		"tao": {
+			Name: "Tao",
+			Quotes: map[string][]Quote{
+				"chaos": {
+					{Quote: "The way that can be told is not the eternal way.", Source: "Chapter 1", Encouragement: "Stay humble."},
+				},
+			},
+		},
+		"chacham": {
+			Name:     "Chacham",
+			Language: "hebrew",
+			Quotes: map[string][]Quote{
+				"chaos": {
+					{Quote: "אם אין אני לי מי לי", Source: "Hillel", Encouragement: "Take responsibility for the way."},
+				},
+			},
+		},
+	})
+}
+
+func TestSearchIndex_Search(t *testing.T) {
+	idx := newTestSearchIndex()
+
+	tests := []struct {
+		name      string
+		query     string
+		opts      SearchOptions
+		wantCount int
+		wantFirst string // Expected top result quote, if any
+	}{
+		{name: "term", query: "courage", wantCount: 1, wantFirst: "Courage is calmness under fire."},
+		{name: "case insensitive", query: "COURAGE", wantCount: 1},
+		{name: "all terms must match", query: "courage learning", wantCount: 0},
+		{name: "prefix", query: "learn*", wantCount: 1, wantFirst: "Learning never ends."},
+		{name: "phrase", query: `"the eternal way"`, wantCount: 1, wantFirst: "The way that can be told is not the eternal way."},
+		{name: "phrase words out of order", query: `"way eternal"`, wantCount: 0},
+		// More matches in the quote text rank first; encouragement matches rank last
+		{name: "ranking", query: "way", wantCount: 4, wantFirst: "The way that can be told is not the eternal way."},
+		{name: "source field", query: "seneca", wantCount: 1},
+		{name: "source filter", query: "way", opts: SearchOptions{Source: "stoic"}, wantCount: 2},
+		{name: "aeon filter", query: "way", opts: SearchOptions{AeonLevel: "treasury"}, wantCount: 1},
+		{name: "language filter", query: "way", opts: SearchOptions{Language: "hebrew"}, wantCount: 1},
+		{name: "hebrew terms", query: "אני", wantCount: 1},
+		{name: "limit", query: "way", opts: SearchOptions{Limit: 2}, wantCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := idx.Search(tt.query, tt.opts)
+			if err != nil {
+				t.Fatalf("Search(%q) failed: %v", tt.query, err)
+			}
+			if len(results) != tt.wantCount {
+				t.Fatalf("Search(%q) returned %d results, want %d: %+v", tt.query, len(results), tt.wantCount, results)
+			}
+			if tt.wantFirst != "" && results[0].Quote.Quote != tt.wantFirst {
+				t.Errorf("top result = %q, want %q", results[0].Quote.Quote, tt.wantFirst)
+			}
+			for i := 1; i < len(results); i++ {
+				if results[i].Score > results[i-1].Score {
+					t.Errorf("results not sorted by score: %v before %v", results[i-1].Score, results[i].Score)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchIndex_InvalidQuery(t *testing.T) {
+	idx := newTestSearchIndex()
+	for _, query := range []string{"", "   ", "*", `"unbalanced`} {
+		if _, err := idx.Search(query, SearchOptions{}); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Search(%q) error = %v, want ErrInvalidQuery", query, err)
+		}
+	}
+}
+
+func TestEngine_Search_ReloadSources(t *testing.T) {
+	root := t.TempDir()
+	sourcesPath := filepath.Join(root, ".wisdom", "sources.json")
+	writeSources := func(quote string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(sourcesPath), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		content := `{"version": "1.0", "sources": {"custom": {"name": "Custom", "icon": "✨", "quotes": {"chaos": [{"quote": "` + quote + `", "source": "s", "encouragement": "e"}]}}}}`
+		if err := os.WriteFile(sourcesPath, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	writeSources("Patience conquers all")
+	engine := NewEngine().WithLoader(NewSourceLoader().WithProjectRoot(root))
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	results, err := engine.Search("patience", SearchOptions{Source: "custom"})
+	if err != nil || len(results) != 1 {
+		t.Fatalf("Search before reload = %v, %v; want 1 result", results, err)
+	}
+
+	writeSources("Persistence conquers all")
+	if err := engine.ReloadSources(); err != nil {
+		t.Fatalf("ReloadSources failed: %v", err)
+	}
+	if results, _ := engine.Search("patience", SearchOptions{Source: "custom"}); len(results) != 0 {
+		t.Errorf("Search after reload found removed quote: %+v", results)
+	}
+	if results, _ := engine.Search("persistence", SearchOptions{Source: "custom"}); len(results) != 1 {
+		t.Errorf("Search after reload = %+v, want the new quote", results)
+	}
+
+	if _, err := engine.Search("patience", SearchOptions{Source: "nonexistent"}); !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("Search with unknown source error = %v, want ErrUnknownSource", err)
+	}
+}
+
+func TestEngine_Search_HebrewFiltering(t *testing.T) {
+	engine := newConfiguredEngine(&config.Config{Source: "stoic"})
+
+	results, err := engine.Search("wise", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Search returned Hebrew source results with Hebrew disabled: %+v", results)
+	}
+	if _, err := engine.Search("wise", SearchOptions{Source: "rebbe"}); !errors.Is(err, ErrSourceFiltered) {
+		t.Errorf("Search with filtered source error = %v, want ErrSourceFiltered", err)
+	}
+
+	engine = newConfiguredEngine(&config.Config{Source: "stoic", HebrewEnabled: true})
+	if results, _ := engine.Search("wise", SearchOptions{Language: "hebrew"}); len(results) != 1 {
+		t.Errorf("Search with Hebrew enabled = %+v, want 1 result", results)
+	}
+}
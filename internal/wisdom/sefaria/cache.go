@@ -17,6 +17,7 @@ type Cache struct {
 	mu      sync.RWMutex
 	entries map[string]*CacheEntry
 	ttl     time.Duration // Default TTL: 24 hours
+	now     func() time.Time
 }
 
 // NewCache creates a new Sefaria API response cache
@@ -24,6 +25,7 @@ func NewCache() *Cache {
 	return &Cache{
 		entries: make(map[string]*CacheEntry),
 		ttl:     24 * time.Hour, // 24-hour TTL for Hebrew texts
+		now:     time.Now,
 	}
 }
 
@@ -38,7 +40,7 @@ func (c *Cache) Get(key string) (*TextResponse, bool) {
 	}
 
 	// Check if entry is expired
-	if time.Since(entry.Timestamp) > entry.TTL {
+	if c.now().Sub(entry.Timestamp) > entry.TTL {
 		// Entry expired, but don't delete here (cleanup happens elsewhere)
 		return nil, false
 	}
@@ -53,7 +55,7 @@ func (c *Cache) Set(key string, response *TextResponse) {
 
 	c.entries[key] = &CacheEntry{
 		Response:  response,
-		Timestamp: time.Now(),
+		Timestamp: c.now(),
 		TTL:       c.ttl,
 	}
 }
@@ -77,7 +79,7 @@ func (c *Cache) Cleanup() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := time.Now()
+	now := c.now()
 	for key, entry := range c.entries {
 		if now.Sub(entry.Timestamp) > entry.TTL {
 			delete(c.entries, key)
@@ -91,3 +93,19 @@ func (c *Cache) SetTTL(ttl time.Duration) {
 	defer c.mu.Unlock()
 	c.ttl = ttl
 }
+
+// TTL returns the default TTL for cache entries
+func (c *Cache) TTL() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ttl
+}
+
+// SetClock sets the function used to timestamp and expire cache entries
+func (c *Cache) SetClock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now != nil {
+		c.now = now
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -14,6 +15,8 @@ type Client struct {
 	httpClient *http.Client
 	baseURL    string
 	cache      *Cache
+	disk       *DiskCache       // Optional persistent cache; nil keeps responses in memory only
+	now        func() time.Time // Clock for disk cache freshness; nil uses time.Now
 }
 
 // NewClient creates a new Sefaria API client
@@ -31,25 +34,123 @@ func NewClient(httpClient *http.Client) *Client {
 	}
 }
 
+// SetDiskCache enables the persistent disk cache (nil disables it).
+// Disk entries younger than the cache TTL are served without a request; older
+// entries are revalidated with If-None-Match/If-Modified-Since, and are served
+// stale if the API cannot be reached.
+func (c *Client) SetDiskCache(disk *DiskCache) {
+	c.disk = disk
+}
+
+// DiskCache returns the persistent disk cache, or nil if none is set.
+func (c *Client) DiskCache() *DiskCache {
+	return c.disk
+}
+
 // GetText retrieves text from Sefaria API
 // book: Sefaria book ID (e.g., "Pirkei_Avot", "Proverbs")
 // chapter: Chapter number (0 for full book)
 // verse: Verse number (0 for full chapter)
+//
+// With a disk cache, a stale cached response (TextResponse.Stale) is returned
+// instead of an error when the API is unreachable.
 func (c *Client) GetText(ctx context.Context, book string, chapter, verse int) (*TextResponse, error) {
+	return c.getRef(ctx, c.buildRef(book, chapter, verse), false)
+}
+
+// GetRef retrieves text for any Sefaria reference, e.g. "Pirkei_Avot.2",
+// "Proverbs.3.5-6" or "Psalms 23". It caches like GetText.
+func (c *Client) GetRef(ctx context.Context, ref string) (*TextResponse, error) {
+	return c.getRef(ctx, ref, false)
+}
+
+// RefreshRef fetches text from the Sefaria API, bypassing the in-memory cache
+// and revalidating any disk cache entry. Unlike GetRef, it returns an error
+// rather than stale data when the API cannot be reached.
+func (c *Client) RefreshRef(ctx context.Context, ref string) (*TextResponse, error) {
+	return c.getRef(ctx, ref, true)
+}
+
+// CachedRef returns text from the in-memory or disk cache without contacting
+// the API. A disk entry older than the cache TTL is returned with Stale set.
+func (c *Client) CachedRef(ref string) (*TextResponse, bool) {
+	cacheKey := c.buildCacheKey(ref)
+	if cached, found := c.cache.Get(cacheKey); found {
+		return cached, true
+	}
+	if c.disk == nil {
+		return nil, false
+	}
+	entry := c.disk.load(cacheKey)
+	if entry == nil {
+		return nil, false
+	}
+	textResp, err := entry.decode()
+	if err != nil {
+		return nil, false
+	}
+	if c.clock().Sub(entry.FetchedAt) > c.cache.TTL() {
+		textResp.Stale = true
+	} else {
+		c.cache.Set(cacheKey, textResp)
+	}
+	return textResp, true
+}
+
+// getRef implements GetRef and RefreshRef.
+func (c *Client) getRef(ctx context.Context, ref string, force bool) (*TextResponse, error) {
 	// Build cache key
-	cacheKey := c.buildCacheKey(book, chapter, verse)
+	cacheKey := c.buildCacheKey(ref)
 
 	// Check cache first
-	if cached, found := c.cache.Get(cacheKey); found {
-		return cached, nil
+	if !force {
+		if cached, found := c.cache.Get(cacheKey); found {
+			return cached, nil
+		}
+	}
+
+	// Check disk cache: fresh entries are served as is, older ones revalidated
+	var entry *diskEntry
+	if c.disk != nil {
+		entry = c.disk.load(cacheKey)
+		if entry != nil && !force && c.clock().Sub(entry.FetchedAt) <= c.cache.TTL() {
+			if textResp, err := entry.decode(); err == nil {
+				c.cache.Set(cacheKey, textResp)
+				return textResp, nil
+			}
+			entry = nil
+		}
 	}
 
+	textResp, err := c.fetch(ctx, cacheKey, ref, entry)
+	if err != nil {
+		if entry == nil || force {
+			return nil, err
+		}
+		// Serve stale data rather than nothing when the API is unreachable
+		stale, decodeErr := entry.decode()
+		if decodeErr != nil {
+			return nil, err
+		}
+		stale.Stale = true
+		return stale, nil
+	}
+
+	// Cache the response
+	c.cache.Set(cacheKey, textResp)
+
+	return textResp, nil
+}
+
+// fetch requests text from the API, revalidating entry (if any) with a
+// conditional request, and writes the result to the disk cache if it can.
+func (c *Client) fetch(ctx context.Context, cacheKey, ref string, entry *diskEntry) (*TextResponse, error) {
 	// Build API endpoint URL
-	endpoint := c.buildEndpoint(book, chapter, verse)
-	url := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+	endpoint := c.buildEndpoint(ref)
+	requestURL := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 
 	// Create request with context
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Sefaria API request: %w", err)
 	}
@@ -57,6 +158,14 @@ func (c *Client) GetText(ctx context.Context, book string, chapter, verse int) (
 	// Set headers
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "devwisdom-go/1.0")
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
 
 	// Make request
 	resp, err := c.httpClient.Do(req)
@@ -65,6 +174,24 @@ func (c *Client) GetText(ctx context.Context, book string, chapter, verse int) (
 	}
 	defer resp.Body.Close()
 
+	// Cached copy is still current
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		entry.FetchedAt = c.clock()
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			entry.ETag = etag
+		}
+		if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+			entry.LastModified = lastModified
+		}
+		textResp, err := entry.decode()
+		if err != nil {
+			return nil, err
+		}
+		// The disk cache is best-effort: an unwritable cache does not fail the fetch
+		_ = c.disk.store(entry)
+		return textResp, nil
+	}
+
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -87,13 +214,30 @@ func (c *Client) GetText(ctx context.Context, book string, chapter, verse int) (
 	if len(textResp.Text) == 0 && len(textResp.He) == 0 {
 		return nil, fmt.Errorf("Sefaria API response has no text content for %q", endpoint)
 	}
+	textResp.FetchedAt = c.clock()
 
-	// Cache the response
-	c.cache.Set(cacheKey, &textResp)
+	// Persist the response for offline use (best-effort, like the revalidation above)
+	if c.disk != nil {
+		_ = c.disk.store(&diskEntry{
+			Key:          cacheKey,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			FetchedAt:    textResp.FetchedAt,
+			Response:     body,
+		})
+	}
 
 	return &textResp, nil
 }
 
+// clock returns the current time from the client's clock.
+func (c *Client) clock() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+	return c.now()
+}
+
 // GetTextBySourceID retrieves text using our internal source ID
 // Maps source IDs (pirkei_avot, proverbs, etc.) to Sefaria book IDs
 func (c *Client) GetTextBySourceID(ctx context.Context, sourceID string, chapter, verse int) (*TextResponse, error) {
@@ -105,23 +249,38 @@ func (c *Client) GetTextBySourceID(ctx context.Context, sourceID string, chapter
 	return c.GetText(ctx, book, chapter, verse)
 }
 
-// buildEndpoint constructs the API endpoint path
-func (c *Client) buildEndpoint(book string, chapter, verse int) string {
+// RefForSourceID returns the Sefaria reference for a source ID: the mapped
+// book for known IDs (pirkei_avot, proverbs, etc.), otherwise the ID itself,
+// which may be any Sefaria reference.
+func RefForSourceID(sourceID string) string {
+	if book, exists := BookMapping[sourceID]; exists {
+		return book
+	}
+	return sourceID
+}
+
+// buildRef constructs a Sefaria reference for a book, chapter and verse
+func (c *Client) buildRef(book string, chapter, verse int) string {
 	if chapter == 0 {
 		// Full book
-		return fmt.Sprintf("texts/%s", book)
+		return book
 	} else if verse == 0 {
 		// Full chapter
-		return fmt.Sprintf("texts/%s.%d", book, chapter)
+		return fmt.Sprintf("%s.%d", book, chapter)
 	} else {
 		// Specific verse
-		return fmt.Sprintf("texts/%s.%d.%d", book, chapter, verse)
+		return fmt.Sprintf("%s.%d.%d", book, chapter, verse)
 	}
 }
 
+// buildEndpoint constructs the API endpoint path for a reference
+func (c *Client) buildEndpoint(ref string) string {
+	return "texts/" + url.PathEscape(ref)
+}
+
 // buildCacheKey creates a cache key for the request
-func (c *Client) buildCacheKey(book string, chapter, verse int) string {
-	return fmt.Sprintf("sefaria:%s:%d:%d", book, chapter, verse)
+func (c *Client) buildCacheKey(ref string) string {
+	return "sefaria:" + ref
 }
 
 // getBookMappingKeys returns all available book mapping keys
@@ -133,6 +292,14 @@ func getBookMappingKeys() []string {
 	return keys
 }
 
+// SetClock sets the function used to expire cached responses
+func (c *Client) SetClock(now func() time.Time) {
+	c.cache.SetClock(now)
+	if now != nil {
+		c.now = now
+	}
+}
+
 // CleanupCache removes expired entries from the cache
 func (c *Client) CleanupCache() {
 	c.cache.Cleanup()
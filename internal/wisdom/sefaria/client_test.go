@@ -135,3 +135,62 @@ func TestClient_Cache(t *testing.T) {
 		t.Errorf("Expected 1 API call, got %d", callCount)
 	}
 }
+
+func TestCache_SetClock(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewCache()
+	cache.SetClock(func() time.Time { return now })
+	cache.Set("key", &TextResponse{Ref: "Proverbs 1"})
+
+	now = now.Add(23 * time.Hour)
+	if _, found := cache.Get("key"); !found {
+		t.Error("entry expired before its 24-hour TTL")
+	}
+	now = now.Add(2 * time.Hour)
+	if _, found := cache.Get("key"); found {
+		t.Error("entry still cached after its TTL")
+	}
+	cache.Cleanup()
+	if _, exists := cache.entries["key"]; exists {
+		t.Error("Cleanup kept an expired entry")
+	}
+}
+
+func TestClient_GetRef(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/texts/Proverbs 3:5-6" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ref": "Proverbs 3:5-6", "book": "Proverbs", "sections": [3, 5], "toSections": [3, 6], "textDepth": 2,
+			"text": ["Trust in the <b>LORD</b>", "Acknowledge Him"], "he": ["בְּטַח", "דָעֵהוּ"]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+		cache:      NewCache(),
+	}
+
+	resp, err := client.GetRef(context.Background(), "Proverbs 3:5-6")
+	if err != nil {
+		t.Fatalf("GetRef failed: %v", err)
+	}
+	if len(resp.Text) != 2 || resp.Text[0].Text != "Trust in the LORD" {
+		t.Errorf("Text = %+v, want 2 verses without markup", resp.Text)
+	}
+	if got := resp.VerseRef(resp.He[1]); got != "Proverbs 3:6" {
+		t.Errorf("VerseRef = %q, want %q", got, "Proverbs 3:6")
+	}
+}
+
+func TestRefForSourceID(t *testing.T) {
+	if got := RefForSourceID("pirkei_avot"); got != "Pirkei_Avot" {
+		t.Errorf("RefForSourceID(pirkei_avot) = %q", got)
+	}
+	if got := RefForSourceID("Proverbs.3.5-6"); got != "Proverbs.3.5-6" {
+		t.Errorf("RefForSourceID(ref) = %q", got)
+	}
+}
@@ -0,0 +1,119 @@
+package sefaria
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DiskCache persists Sefaria API responses as JSON files so texts remain
+// available across runs and without network access. Each entry keeps the
+// response's ETag and Last-Modified headers for conditional revalidation.
+type DiskCache struct {
+	dir string
+}
+
+// diskEntry is a cached API response stored on disk.
+type diskEntry struct {
+	Key          string          `json:"key"`
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	FetchedAt    time.Time       `json:"fetched_at"` // Last successful fetch or revalidation
+	Response     json.RawMessage `json:"response"`   // Raw API response body
+}
+
+// DefaultCacheDir returns the default disk cache directory:
+// $XDG_CACHE_HOME/devwisdom/sefaria, or the platform user cache directory
+// (e.g. ~/.cache on Linux). It returns "" if neither can be determined.
+func DefaultCacheDir() string {
+	if xdgCache := os.Getenv("XDG_CACHE_HOME"); xdgCache != "" {
+		return filepath.Join(xdgCache, "devwisdom", "sefaria")
+	}
+	if userCache, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(userCache, "devwisdom", "sefaria")
+	}
+	return ""
+}
+
+// NewDiskCache creates a disk cache in dir. The directory is created on first write.
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{dir: dir}
+}
+
+// Dir returns the cache directory.
+func (d *DiskCache) Dir() string {
+	return d.dir
+}
+
+// path returns the file holding the entry for key.
+func (d *DiskCache) path(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	return filepath.Join(d.dir, name+".json")
+}
+
+// load returns the entry for key, or nil if it is missing or unreadable.
+func (d *DiskCache) load(key string) *diskEntry {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil
+	}
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key || len(entry.Response) == 0 {
+		return nil
+	}
+	return &entry
+}
+
+// store writes an entry atomically. Each write goes through its own
+// temporary file, so caches shared by several loaders or processes never
+// see a partly written entry.
+func (d *DiskCache) store(entry *diskEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Sefaria cache entry %q: %w", entry.Key, err)
+	}
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create Sefaria cache directory %q: %w", d.dir, err)
+	}
+	path := d.path(entry.Key)
+	tmp, err := os.CreateTemp(d.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create Sefaria cache file in %q: %w", d.dir, err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write Sefaria cache file %q: %w", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to replace Sefaria cache file %q: %w", path, err)
+	}
+	return nil
+}
+
+// decode parses the cached response.
+func (e *diskEntry) decode() (*TextResponse, error) {
+	var textResp TextResponse
+	if err := json.Unmarshal(e.Response, &textResp); err != nil {
+		return nil, fmt.Errorf("failed to parse cached Sefaria response %q: %w", e.Key, err)
+	}
+	textResp.FetchedAt = e.FetchedAt
+	return &textResp, nil
+}
@@ -0,0 +1,217 @@
+package sefaria
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newDiskClient returns a client for server with a disk cache in dir and a controllable clock.
+func newDiskClient(server *httptest.Server, dir string, now *time.Time) *Client {
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+		cache:      NewCache(),
+	}
+	client.SetDiskCache(NewDiskCache(dir))
+	client.SetClock(func() time.Time { return *now })
+	return client
+}
+
+func TestDefaultCacheDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+	if got, want := DefaultCacheDir(), filepath.Join("/tmp/xdg-cache", "devwisdom", "sefaria"); got != want {
+		t.Errorf("DefaultCacheDir() = %q, want %q", got, want)
+	}
+}
+
+func TestClient_DiskCache_Revalidation(t *testing.T) {
+	calls, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ref": "Proverbs", "text": ["Wisdom"], "he": ["חכמה"]}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	if _, err := newDiskClient(server, dir, &now).GetText(ctx, "Proverbs", 0, 0); err != nil {
+		t.Fatalf("GetText failed: %v", err)
+	}
+
+	// A new client (a new process) is served from disk while the entry is fresh
+	resp, err := newDiskClient(server, dir, &now).GetText(ctx, "Proverbs", 0, 0)
+	if err != nil {
+		t.Fatalf("GetText from disk failed: %v", err)
+	}
+	if calls != 1 || len(resp.He) != 1 || resp.He[0].Text != "חכמה" {
+		t.Errorf("fresh disk entry: calls = %d, he = %v; want 1 call and the cached text", calls, resp.He)
+	}
+
+	// An expired entry is revalidated with its ETag
+	now = now.Add(25 * time.Hour)
+	resp, err = newDiskClient(server, dir, &now).GetText(ctx, "Proverbs", 0, 0)
+	if err != nil {
+		t.Fatalf("GetText revalidation failed: %v", err)
+	}
+	if notModified != 1 || resp.Stale || !resp.FetchedAt.Equal(now) {
+		t.Errorf("revalidation: 304s = %d, stale = %v, fetched at %v; want 1, false, %v", notModified, resp.Stale, resp.FetchedAt, now)
+	}
+}
+
+func TestClient_DiskCache_StaleOnError(t *testing.T) {
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Last-Modified", "Sun, 01 Mar 2026 00:00:00 GMT")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ref": "Pirkei Avot", "text": ["Who is wise?"], "he": ["איזהו חכם"]}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	if _, err := newDiskClient(server, dir, &now).GetText(ctx, "Pirkei_Avot", 0, 0); err != nil {
+		t.Fatalf("GetText failed: %v", err)
+	}
+
+	failing = true
+	now = now.Add(48 * time.Hour)
+	client := newDiskClient(server, dir, &now)
+	resp, err := client.GetText(ctx, "Pirkei_Avot", 0, 0)
+	if err != nil {
+		t.Fatalf("GetText should serve stale data on error, got: %v", err)
+	}
+	if !resp.Stale || len(resp.He) != 1 {
+		t.Errorf("stale response: stale = %v, he = %v", resp.Stale, resp.He)
+	}
+
+	// Refresh reports the failure instead of serving stale data
+	if _, err := client.RefreshRef(ctx, "Pirkei_Avot"); err == nil {
+		t.Error("Refresh should fail when the API is unavailable")
+	}
+
+	// Without a cached copy the error is returned
+	if _, err := client.GetText(ctx, "Psalms", 0, 0); err == nil {
+		t.Error("GetText should fail with no cached copy")
+	}
+}
+
+func TestClient_DiskCache_Unwritable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ref": "Proverbs", "text": ["Wisdom"]}`))
+	}))
+	defer server.Close()
+
+	// The cache directory cannot be created below a regular file
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	resp, err := newDiskClient(server, filepath.Join(file, "sefaria"), &now).GetText(context.Background(), "Proverbs", 0, 0)
+	if err != nil {
+		t.Fatalf("GetText with an unwritable disk cache failed: %v", err)
+	}
+	if len(resp.Text) != 1 {
+		t.Errorf("text = %v, want the fetched text", resp.Text)
+	}
+}
+
+func TestDiskCache_IgnoresCorruptEntry(t *testing.T) {
+	dir := t.TempDir()
+	disk := NewDiskCache(dir)
+	key := "sefaria:Proverbs"
+	if err := os.WriteFile(disk.path(key), []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if entry := disk.load(key); entry != nil {
+		t.Errorf("load() = %+v for a corrupt file, want nil", entry)
+	}
+}
+
+func TestClient_CachedRef(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ref": "Psalms", "text": ["Praise"], "he": ["הלל"]}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	client := newDiskClient(server, dir, &now)
+	if _, found := client.CachedRef("Psalms"); found {
+		t.Fatal("Cached found text before any fetch")
+	}
+	if _, err := client.GetText(context.Background(), "Psalms", 0, 0); err != nil {
+		t.Fatalf("GetText failed: %v", err)
+	}
+	server.Close()
+
+	// A new client sees the disk entry, then marks it stale once expired
+	resp, found := newDiskClient(server, dir, &now).CachedRef("Psalms")
+	if !found || resp.Stale {
+		t.Errorf("Cached() = %+v, %v; want a fresh entry", resp, found)
+	}
+	now = now.Add(25 * time.Hour)
+	resp, found = newDiskClient(server, dir, &now).CachedRef("Psalms")
+	if !found || !resp.Stale {
+		t.Errorf("Cached() = %+v, %v; want a stale entry", resp, found)
+	}
+}
+
+func TestDiskCache_ConcurrentStores(t *testing.T) {
+	dir := t.TempDir()
+	key := "sefaria:Proverbs"
+
+	// Several caches sharing a directory, as with several loaders in one process
+	done := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		go func(i int) {
+			disk := NewDiskCache(dir)
+			for j := 0; j < 20; j++ {
+				response := []byte(`{"ref": "Proverbs", "text": ["` + strings.Repeat("x", 1000*i+j) + `"]}`)
+				if err := disk.store(&diskEntry{Key: key, Response: response}); err != nil {
+					done <- err
+					return
+				}
+				if entry := disk.load(key); entry == nil {
+					done <- fmt.Errorf("load() returned nil after a store")
+					return
+				}
+			}
+			done <- nil
+		}(i)
+	}
+	for i := 0; i < 8; i++ {
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	if len(leftovers) != 0 {
+		t.Errorf("temporary files left behind: %v", leftovers)
+	}
+}
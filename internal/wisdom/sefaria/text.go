@@ -0,0 +1,146 @@
+package sefaria
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Verse is one text segment of a Sefaria response.
+type Verse struct {
+	Path []int  // Position in the response's nested text arrays (empty for a single segment)
+	Text string // Segment text with HTML markup removed
+}
+
+// Verses holds the segments of a Sefaria text field. The API returns a
+// string for a single verse, a list for a chapter, and nested lists for books
+// and ranges spanning chapters; all of these are flattened in order.
+type Verses []Verse
+
+// UnmarshalJSON flattens a string or arbitrarily nested string arrays.
+func (v *Verses) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var verses Verses
+	var walk func(node interface{}, path []int) error
+	walk = func(node interface{}, path []int) error {
+		switch n := node.(type) {
+		case nil:
+			return nil
+		case string:
+			verses = append(verses, Verse{Path: append([]int(nil), path...), Text: CleanText(n)})
+			return nil
+		case []interface{}:
+			for i, child := range n {
+				if err := walk(child, append(path, i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			return fmt.Errorf("unexpected %T in Sefaria text", node)
+		}
+	}
+	if err := walk(raw, nil); err != nil {
+		return err
+	}
+	*v = verses
+	return nil
+}
+
+// Strings returns the verse texts.
+func (v Verses) Strings() []string {
+	texts := make([]string, len(v))
+	for i, verse := range v {
+		texts[i] = verse.Text
+	}
+	return texts
+}
+
+var (
+	// Footnote markers and their inline text, e.g. <sup>1</sup><i class="footnote">...</i>
+	footnotePattern = regexp.MustCompile(`(?s)<sup[^>]*>.*?</sup>|<i[^>]*class="footnote"[^>]*>.*?</i>`)
+	tagPattern      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// CleanText removes Sefaria's HTML markup (formatting tags, footnotes and
+// entities) from a text segment and collapses whitespace.
+func CleanText(text string) string {
+	text = footnotePattern.ReplaceAllString(text, "")
+	text = tagPattern.ReplaceAllString(text, " ")
+	text = html.UnescapeString(text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// VerseRef returns the precise reference of a verse in the response, e.g.
+// "Proverbs 3:5". The verse's path is resolved against the address of the
+// first returned segment (Sections), as in the API's range semantics.
+func (r *TextResponse) VerseRef(verse Verse) string {
+	depth := r.TextDepth
+	if depth == 0 {
+		// Ranged responses address every level in Sections; others return
+		// the levels below the requested ones.
+		depth = len(r.Sections) + len(verse.Path)
+		if len(verse.Path) > 0 && len(r.Sections) > 0 && !equalInts(r.Sections, r.ToSections) {
+			depth = len(r.Sections)
+		}
+	}
+
+	// The outer levels are fixed by the request; the path covers the rest
+	fixed := depth - len(verse.Path)
+	if fixed < 0 {
+		fixed = 0
+	}
+	address := make([]string, 0, depth)
+	for level := 0; level < fixed && level < len(r.Sections); level++ {
+		address = append(address, strconv.Itoa(r.Sections[level]))
+	}
+	first := true // Still within the first returned segment of each level
+	for i, offset := range verse.Path {
+		level := fixed + i
+		start := 1
+		if first && level < len(r.Sections) {
+			start = r.Sections[level]
+		}
+		address = append(address, strconv.Itoa(start+offset))
+		first = first && offset == 0
+	}
+
+	title := r.bookTitle()
+	if len(address) == 0 {
+		return title
+	}
+	return title + " " + strings.Join(address, ":")
+}
+
+// bookTitle returns the response's book title, derived from Ref if the API
+// did not include it.
+func (r *TextResponse) bookTitle() string {
+	if r.Book != "" {
+		return r.Book
+	}
+	title := strings.ReplaceAll(r.Ref, "_", " ")
+	// Drop a trailing address such as " 3:5-6" or ".3.5"
+	if i := strings.LastIndexAny(title, " ."); i > 0 && strings.ContainsAny(title[i+1:], "0123456789") {
+		title = title[:i]
+	}
+	return title
+}
+
+// equalInts reports whether a and b hold the same values.
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package sefaria
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVerses_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		json  string
+		texts []string
+		paths [][]int
+	}{
+		{"single verse", `"Trust in the LORD"`, []string{"Trust in the LORD"}, [][]int{nil}},
+		{"chapter", `["a", "b"]`, []string{"a", "b"}, [][]int{{0}, {1}}},
+		{"book", `[["a", "b"], [], ["c"]]`, []string{"a", "b", "c"}, [][]int{{0, 0}, {0, 1}, {2, 0}}},
+		{"empty", `[]`, []string{}, [][]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verses Verses
+			if err := json.Unmarshal([]byte(tt.json), &verses); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if len(verses) != len(tt.texts) {
+				t.Fatalf("got %d verses, want %d", len(verses), len(tt.texts))
+			}
+			for i, verse := range verses {
+				if verse.Text != tt.texts[i] || !equalInts(verse.Path, tt.paths[i]) {
+					t.Errorf("verse %d = %+v, want %q at %v", i, verse, tt.texts[i], tt.paths[i])
+				}
+			}
+		})
+	}
+
+	var verses Verses
+	if err := json.Unmarshal([]byte(`[1, 2]`), &verses); err == nil {
+		t.Error("Unmarshal of numbers should fail")
+	}
+}
+
+func TestCleanText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"<b>Who</b> is wise?", "Who is wise?"},
+		{"Line one<br>line two", "Line one line two"},
+		{"Fear of the LORD<sup>1</sup><i class=\"footnote\">Or reverence.</i> is wisdom", "Fear of the LORD is wisdom"},
+		{"Torah &amp; mitzvot&nbsp;&nbsp;", "Torah & mitzvot"},
+		{"אִם אֵין אֲנִי לִי", "אִם אֵין אֲנִי לִי"},
+	}
+	for _, tt := range tests {
+		if got := CleanText(tt.in); got != tt.want {
+			t.Errorf("CleanText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTextResponse_VerseRef(t *testing.T) {
+	tests := []struct {
+		name string
+		resp string
+		want []string
+	}{
+		{
+			name: "full book",
+			resp: `{"ref": "Pirkei Avot", "book": "Pirkei Avot", "sections": [], "toSections": [], "textDepth": 2, "text": [["a", "b"], ["c"]]}`,
+			want: []string{"Pirkei Avot 1:1", "Pirkei Avot 1:2", "Pirkei Avot 2:1"},
+		},
+		{
+			name: "chapter",
+			resp: `{"ref": "Pirkei Avot 2", "book": "Pirkei Avot", "sections": [2], "toSections": [2], "textDepth": 2, "text": ["a", "b"]}`,
+			want: []string{"Pirkei Avot 2:1", "Pirkei Avot 2:2"},
+		},
+		{
+			name: "verse range",
+			resp: `{"ref": "Proverbs 3:5-6", "book": "Proverbs", "sections": [3, 5], "toSections": [3, 6], "textDepth": 2, "text": ["a", "b"]}`,
+			want: []string{"Proverbs 3:5", "Proverbs 3:6"},
+		},
+		{
+			name: "range across chapters",
+			resp: `{"ref": "Proverbs 3:34-4:1", "book": "Proverbs", "sections": [3, 34], "toSections": [4, 1], "textDepth": 2, "text": [["a", "b"], ["c"]]}`,
+			want: []string{"Proverbs 3:34", "Proverbs 3:35", "Proverbs 4:1"},
+		},
+		{
+			name: "single verse",
+			resp: `{"ref": "Proverbs 3:5", "book": "Proverbs", "sections": [3, 5], "toSections": [3, 5], "textDepth": 2, "text": "a"}`,
+			want: []string{"Proverbs 3:5"},
+		},
+		{
+			name: "no depth or book",
+			resp: `{"ref": "Proverbs 3:5-6", "sections": [3, 5], "toSections": [3, 6], "text": ["a", "b"]}`,
+			want: []string{"Proverbs 3:5", "Proverbs 3:6"},
+		},
+		{
+			name: "chapter without depth",
+			resp: `{"ref": "Psalms 23", "sections": [23], "toSections": [23], "text": ["a"]}`,
+			want: []string{"Psalms 23:1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp TextResponse
+			if err := json.Unmarshal([]byte(tt.resp), &resp); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if len(resp.Text) != len(tt.want) {
+				t.Fatalf("got %d verses, want %d", len(resp.Text), len(tt.want))
+			}
+			for i, verse := range resp.Text {
+				if got := resp.VerseRef(verse); got != tt.want[i] {
+					t.Errorf("VerseRef(%v) = %q, want %q", verse.Path, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -1,21 +1,30 @@
 // Package sefaria provides Sefaria API client for fetching Hebrew text sources.
 package sefaria
 
+import "time"
+
 // TextResponse represents a Sefaria API text response
 type TextResponse struct {
-	Ref      string    `json:"ref"`
-	HeRef    string    `json:"heRef"`
-	Text     []string  `json:"text"` // English verses
-	He       []string  `json:"he"`   // Hebrew verses
-	Versions []Version `json:"versions"`
-	Metadata *Metadata `json:"-"`
+	Ref        string    `json:"ref"`
+	HeRef      string    `json:"heRef"`
+	Book       string    `json:"book"`       // Book title (e.g., "Pirkei Avot")
+	Text       Verses    `json:"text"`       // English verses
+	He         Verses    `json:"he"`         // Hebrew verses
+	Sections   []int     `json:"sections"`   // Address of the first returned segment (e.g., [3, 5] for Proverbs 3:5)
+	ToSections []int     `json:"toSections"` // Address of the last returned segment
+	TextDepth  int       `json:"textDepth"`  // Address depth of the book (2 for chapter:verse)
+	Versions   []Version `json:"versions"`
+	Metadata   *Metadata `json:"-"`
+
+	FetchedAt time.Time `json:"-"` // When the response was fetched or last revalidated
+	Stale     bool      `json:"-"` // Served from the disk cache because the API was unreachable
 }
 
 // Version represents a translation version in the Sefaria API response
 type Version struct {
-	VersionTitle string   `json:"versionTitle"`
-	Language     string   `json:"language"`
-	Text         []string `json:"text"`
+	VersionTitle string `json:"versionTitle"`
+	Language     string `json:"language"`
+	Text         Verses `json:"text"`
 }
 
 // Metadata represents metadata about the text (extracted from response)
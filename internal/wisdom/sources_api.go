@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +17,22 @@ type APISourceLoader struct {
 	client  *http.Client
 	baseURL string
 	timeout time.Duration
+	headers map[string]string // Request headers; values may reference ${ENV_VARS}
+	mapping *APIMapping       // nil expects a SourceConfig response
+	// Places mapped quotes without an aeon level
+	classifier AeonClassifier
+}
+
+// APIMapping describes where quote fields are found in a JSON API response.
+// Paths are dot-separated object keys and array indexes, e.g. "data.items"
+// or "attribution.name".
+type APIMapping struct {
+	Quotes        string `json:"quotes,omitempty"`        // List of quote items (default: the response root)
+	Quote         string `json:"quote,omitempty"`         // Quote text in each item (default "quote"); string items are the text itself
+	Translation   string `json:"translation,omitempty"`   // Translation of the quote in each item (default "translation")
+	Author        string `json:"author,omitempty"`        // Attribution in each item (default "author")
+	Encouragement string `json:"encouragement,omitempty"` // Encouragement in each item (default "encouragement")
+	AeonLevel     string `json:"aeon_level,omitempty"`    // Aeon level name or 0-100 score in each item (default "aeon_level")
 }
 
 // NewAPISourceLoader creates a new API source loader
@@ -22,14 +41,41 @@ func NewAPISourceLoader(baseURL string, timeout time.Duration) *APISourceLoader
 		client: &http.Client{
 			Timeout: timeout,
 		},
-		baseURL: baseURL,
-		timeout: timeout,
+		baseURL:    baseURL,
+		timeout:    timeout,
+		classifier: NewLexiconClassifier(),
 	}
 }
 
+// WithHeaders sets request headers. ${VAR} and $VAR in values are replaced
+// with environment variables at request time, so secrets such as
+// "Authorization": "Bearer ${WISDOM_API_TOKEN}" stay out of config files.
+func (al *APISourceLoader) WithHeaders(headers map[string]string) *APISourceLoader {
+	al.headers = headers
+	return al
+}
+
+// WithMapping reads quotes from responses using mapping instead of expecting
+// a SourceConfig.
+func (al *APISourceLoader) WithMapping(mapping *APIMapping) *APISourceLoader {
+	al.mapping = mapping
+	return al
+}
+
+// WithClassifier sets the classifier placing mapped quotes that have no aeon
+// level (default: NewLexiconClassifier).
+func (al *APISourceLoader) WithClassifier(classifier AeonClassifier) *APISourceLoader {
+	al.classifier = classifier
+	return al
+}
+
 // LoadSource loads a source from an API endpoint
+// With an empty base URL, endpoint is the full URL.
 func (al *APISourceLoader) LoadSource(ctx context.Context, endpoint string) (*SourceConfig, error) {
-	url := fmt.Sprintf("%s/%s", al.baseURL, endpoint)
+	url := endpoint
+	if al.baseURL != "" {
+		url = fmt.Sprintf("%s/%s", al.baseURL, endpoint)
+	}
 
 	// Create request with context
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -40,6 +86,13 @@ func (al *APISourceLoader) LoadSource(ctx context.Context, endpoint string) (*So
 	// Set headers
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "devwisdom-go/1.0")
+	for name, value := range al.headers {
+		expanded, err := expandEnv(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set header %q for source %q: %w", name, endpoint, err)
+		}
+		req.Header.Set(name, expanded)
+	}
 
 	// Make request with timeout
 	resp, err := al.client.Do(req)
@@ -59,6 +112,15 @@ func (al *APISourceLoader) LoadSource(ctx context.Context, endpoint string) (*So
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
+	// Map quotes from a custom response layout
+	if al.mapping != nil {
+		config, err := al.mapping.apply(body, al.classifier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map response from %q: %w", endpoint, err)
+		}
+		return config, nil
+	}
+
 	// Parse JSON
 	var config SourceConfig
 	if err := json.Unmarshal(body, &config); err != nil {
@@ -101,3 +163,141 @@ func (al *APISourceLoader) LoadSourceWithTimeout(endpoint string, timeout time.D
 
 	return al.LoadSource(ctx, endpoint)
 }
+
+// expandEnv replaces ${VAR} and $VAR in value with environment variables,
+// failing if any is unset.
+func expandEnv(value string) (string, error) {
+	var missing []string
+	expanded := os.Expand(value, func(name string) string {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable(s) not set: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// apply converts an API response to a SourceConfig. Items without a valid
+// aeon level are placed by classifier.
+func (m *APIMapping) apply(body []byte, classifier AeonClassifier) (*SourceConfig, error) {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	list, ok := jsonPath(doc, m.Quotes)
+	items, isList := list.([]interface{})
+	if !ok || !isList {
+		return nil, fmt.Errorf("no list of quotes at %q (check api_mapping.quotes)", m.Quotes)
+	}
+
+	config := &SourceConfig{Quotes: make(map[string][]Quote)}
+	var unassigned []Quote
+	for _, item := range items {
+		quote := Quote{
+			Quote:         jsonString(item, orDefault(m.Quote, "quote")),
+			Translation:   jsonString(item, orDefault(m.Translation, "translation")),
+			Source:        jsonString(item, orDefault(m.Author, "author")),
+			Encouragement: jsonString(item, orDefault(m.Encouragement, "encouragement")),
+		}
+		if text, isString := item.(string); isString {
+			quote.Quote = text
+		}
+		if quote.Quote == "" {
+			continue
+		}
+		level := ""
+		if value, found := jsonPath(item, orDefault(m.AeonLevel, "aeon_level")); found {
+			level = aeonLevelOf(value)
+		}
+		if level == "" {
+			unassigned = append(unassigned, quote)
+		} else {
+			config.Quotes[level] = append(config.Quotes[level], quote)
+		}
+	}
+	if len(unassigned) == 0 && len(config.Quotes) == 0 {
+		return nil, fmt.Errorf("no quotes found in %d items (check api_mapping.quote)", len(items))
+	}
+	if len(unassigned) > 0 {
+		for level, quotes := range classifyQuotes(classifier, unassigned) {
+			config.Quotes[level] = append(config.Quotes[level], quotes...)
+		}
+	}
+	return config, nil
+}
+
+// orDefault returns value, or def if value is empty.
+func orDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+// jsonPath resolves a dot-separated path of object keys and array indexes
+// in a decoded JSON document. An empty path is the document itself.
+func jsonPath(doc interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return doc, true
+	}
+	current := doc
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, exists := node[key]
+			if !exists {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// jsonString returns the string or number at path, or "" if there is none.
+func jsonString(doc interface{}, path string) string {
+	value, found := jsonPath(doc, path)
+	if !found {
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// aeonLevelOf converts an aeon level name or a 0-100 score to an aeon level,
+// returning "" if value is neither.
+func aeonLevelOf(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return GetAeonLevel(v)
+	case string:
+		for _, level := range aeonLevels {
+			if v == level {
+				return level
+			}
+		}
+		if score, err := strconv.ParseFloat(v, 64); err == nil {
+			return GetAeonLevel(score)
+		}
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -161,3 +162,200 @@ func TestAPISourceLoader_LoadSourceWithTimeout(t *testing.T) {
 		t.Fatal("LoadSourceWithTimeout returned nil config")
 	}
 }
+
+func TestAPISourceLoader_Mapping(t *testing.T) {
+	t.Setenv("WISDOM_TEST_TOKEN", "s3cret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"items": [
+			{"text": "Ship small changes.", "en": "Ship small changes, often.", "who": {"name": "Team Lead"}, "level": "upper_aeons"},
+			{"text": "Fix the build first.", "who": {"name": "SRE"}, "level": 10},
+			{"text": "Write it down.", "who": {"name": "Docs"}},
+			{"who": {"name": "No text"}}
+		]}}`))
+	}))
+	defer server.Close()
+
+	loader := NewAPISourceLoader("", 5*time.Second).
+		WithHeaders(map[string]string{"Authorization": "Bearer ${WISDOM_TEST_TOKEN}"}).
+		WithMapping(&APIMapping{Quotes: "data.items", Quote: "text", Translation: "en", Author: "who.name", AeonLevel: "level"})
+
+	config, err := loader.LoadSource(context.Background(), server.URL+"/quotes")
+	if err != nil {
+		t.Fatalf("LoadSource failed: %v", err)
+	}
+	if got := config.Quotes["upper_aeons"]; len(got) != 1 || got[0].Source != "Team Lead" || got[0].Translation != "Ship small changes, often." {
+		t.Errorf("upper_aeons = %+v, want the Team Lead quote", got)
+	}
+	if got := config.Quotes["chaos"]; len(got) != 2 || got[0].Quote != "Fix the build first." {
+		t.Errorf("chaos = %+v, want the scored quote and the first unassigned quote", got)
+	}
+	total := 0
+	for _, quotes := range config.Quotes {
+		total += len(quotes)
+	}
+	if total != 3 {
+		t.Errorf("mapped %d quotes, want 3", total)
+	}
+}
+
+func TestAPISourceLoader_MissingHeaderEnv(t *testing.T) {
+	loader := NewAPISourceLoader("", time.Second).
+		WithHeaders(map[string]string{"X-Api-Key": "$WISDOM_TEST_UNSET_KEY"})
+	_, err := loader.LoadSource(context.Background(), "http://127.0.0.1:1/quotes")
+	if err == nil || !strings.Contains(err.Error(), "WISDOM_TEST_UNSET_KEY") {
+		t.Errorf("LoadSource error = %v, want a missing environment variable error", err)
+	}
+}
+
+func TestAPIMapping_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping APIMapping
+		body    string
+	}{
+		{"not a list", APIMapping{Quotes: "data"}, `{"data": {"quote": "x"}}`},
+		{"missing path", APIMapping{Quotes: "items"}, `{"data": []}`},
+		{"no quote text", APIMapping{}, `[{"text": "x"}]`},
+		{"invalid JSON", APIMapping{}, `[`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.mapping.apply([]byte(tt.body), NewLexiconClassifier()); err == nil {
+				t.Error("apply() succeeded, want an error")
+			}
+		})
+	}
+
+	config, err := (&APIMapping{}).apply([]byte(`["Plain string quote"]`), NewLexiconClassifier())
+	if err != nil {
+		t.Fatalf("apply() with string items failed: %v", err)
+	}
+	if got := config.Quotes["chaos"]; len(got) != 1 || got[0].Quote != "Plain string quote" {
+		t.Errorf("string items mapped to %+v", config.Quotes)
+	}
+}
+
+func TestSourceLoader_APIEndpointSource(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"quote": "Review each other's code.", "author": "Team", "aeon_level": "middle_aeons"}]`))
+	}))
+	defer server.Close()
+
+	loader := NewSourceLoader().WithProjectRoot(t.TempDir())
+	config := &SourceConfig{
+		ID:          "team_api",
+		Name:        "Team Wisdom",
+		APIEndpoint: server.URL + "/quotes",
+		APIMapping:  &APIMapping{},
+		Quotes: map[string][]Quote{
+			"chaos": {{Quote: "Fallback", Source: "Config"}},
+		},
+	}
+	if err := loader.AddSource(config); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	if err := loader.WaitForSources(context.Background()); err != nil {
+		t.Fatalf("WaitForSources failed: %v", err)
+	}
+
+	source, _ := loader.GetSource("team_api")
+	got := source.Quotes["middle_aeons"]
+	if len(got) != 1 || got[0].Quote != "Review each other's code." || got[0].WisdomSource != "team_api" || got[0].ID == "" {
+		t.Errorf("middle_aeons = %+v, want the API quote with source and ID", got)
+	}
+	if status, _ := loader.SourceStatus("team_api"); status.State != SourceReady {
+		t.Errorf("status = %+v, want ready", status)
+	}
+
+	// Re-adding the source is served from the cache without a request
+	if err := loader.AddSource(config); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("API requested %d times, want 1 (cached)", requests)
+	}
+	source, _ = loader.GetSource("team_api")
+	if len(source.Quotes["middle_aeons"]) != 1 {
+		t.Errorf("cached source quotes = %+v", source.Quotes)
+	}
+}
+
+func TestSourceLoader_APIEndpointSource_Fallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	loader := NewSourceLoader().WithProjectRoot(t.TempDir()).WithHTTPTimeout(time.Second)
+	config := &SourceConfig{
+		ID:          "team_api_down",
+		Name:        "Team Wisdom",
+		APIEndpoint: server.URL,
+		Quotes: map[string][]Quote{
+			"chaos": {{Quote: "Fallback", Source: "Config"}},
+		},
+	}
+	if err := loader.AddSource(config); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := loader.WaitForSources(ctx); err != nil {
+		t.Fatalf("WaitForSources failed: %v", err)
+	}
+
+	status, _ := loader.SourceStatus("team_api_down")
+	if status.State != SourceFailed || status.Error == "" {
+		t.Errorf("status = %+v, want failed with an error", status)
+	}
+	source, _ := loader.GetSource("team_api_down")
+	if got := source.Quotes["chaos"]; len(got) != 1 || got[0].Quote != "Fallback" {
+		t.Errorf("quotes = %+v, want the inline fallback quote", source.Quotes)
+	}
+}
+
+func TestEngine_OnSourceUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"quote": "Review each other's code.", "author": "Team", "aeon_level": "middle_aeons"}]`))
+	}))
+	defer server.Close()
+
+	engine := NewEngine().WithLoader(NewSourceLoader().WithProjectRoot(t.TempDir()))
+	updated := make(chan string, 1)
+	engine.OnSourceUpdate(func(sourceID string) {
+		// Called without the engine's lock held
+		engine.ListSources()
+		updated <- sourceID
+	})
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	err := engine.GetLoader().AddSource(&SourceConfig{
+		ID:          "team_api",
+		Name:        "Team Wisdom",
+		APIEndpoint: server.URL,
+		APIMapping:  &APIMapping{},
+		Quotes:      map[string][]Quote{"chaos": {{Quote: "Fallback", Source: "Config"}}},
+	})
+	if err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	select {
+	case id := <-updated:
+		if id != "team_api" {
+			t.Errorf("updated source = %q, want team_api", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnSourceUpdate function not called")
+	}
+}
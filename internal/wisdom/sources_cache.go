@@ -22,6 +22,7 @@ type SourceCache struct {
 	defaultTTL time.Duration
 	maxAge     time.Duration
 	enabled    bool
+	clock      Clock
 }
 
 // NewSourceCache creates a new source cache
@@ -31,6 +32,7 @@ func NewSourceCache() *SourceCache {
 		defaultTTL: 5 * time.Minute, // Default cache TTL
 		maxAge:     1 * time.Hour,   // Maximum cache age
 		enabled:    true,
+		clock:      SystemClock,
 	}
 }
 
@@ -46,6 +48,16 @@ func (sc *SourceCache) WithMaxAge(maxAge time.Duration) *SourceCache {
 	return sc
 }
 
+// WithClock sets the clock used to age cache entries
+func (sc *SourceCache) WithClock(clock Clock) *SourceCache {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if clock != nil {
+		sc.clock = clock
+	}
+	return sc
+}
+
 // Enable enables or disables caching
 func (sc *SourceCache) Enable(enabled bool) *SourceCache {
 	sc.enabled = enabled
@@ -111,7 +123,7 @@ func (sc *SourceCache) Set(key string, config *SourceConfig, filePath string) {
 
 	sc.entries[key] = &CacheEntry{
 		Config:      config,
-		LoadedAt:    time.Now(),
+		LoadedAt:    sc.clock.Now(),
 		FileModTime: modTime,
 		FilePath:    filePath,
 		TTL:         sc.defaultTTL,
@@ -157,7 +169,7 @@ func (sc *SourceCache) Size() int {
 
 // isValid checks if a cache entry is still valid
 func (sc *SourceCache) isValid(entry *CacheEntry) bool {
-	age := time.Since(entry.LoadedAt)
+	age := sc.clock.Now().Sub(entry.LoadedAt)
 	return age < entry.TTL && age < sc.maxAge
 }
 
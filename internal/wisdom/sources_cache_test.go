@@ -200,3 +200,18 @@ func TestSourceCache_Disable(t *testing.T) {
 		t.Error("Get returned true when cache is disabled")
 	}
 }
+
+func TestSourceCache_WithClock(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewSourceCache().WithTTL(time.Minute).WithClock(ClockFunc(func() time.Time { return now }))
+	cache.Set("test_key", &SourceConfig{ID: "test"}, "")
+
+	now = now.Add(59 * time.Second)
+	if _, found := cache.Get("test_key"); !found {
+		t.Error("entry expired before its TTL")
+	}
+	now = now.Add(2 * time.Second)
+	if _, found := cache.Get("test_key"); found {
+		t.Error("entry still cached after its TTL")
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -23,14 +25,20 @@ type SourceConfig struct {
 	Language    string             `json:"language,omitempty"` // "hebrew", "english", etc.
 	Quotes      map[string][]Quote `json:"quotes"`             // Key: aeon level
 	// Optional fields for API-based sources
-	SefariaSource string `json:"sefaria_source,omitempty"` // For Sefaria API sources
-	APIEndpoint   string `json:"api_endpoint,omitempty"`   // For future API sources
+	SefariaSource string            `json:"sefaria_source,omitempty"` // Sefaria book ID (e.g. "proverbs") or reference (e.g. "Proverbs.3.5-6")
+	SefariaRefs   []string          `json:"sefaria_refs,omitempty"`   // Sefaria references, used instead of sefaria_source
+	APIEndpoint   string            `json:"api_endpoint,omitempty"`   // URL of a JSON API serving quotes
+	APIMapping    *APIMapping       `json:"api_mapping,omitempty"`    // Where quote fields are in api_endpoint responses
+	APIHeaders    map[string]string `json:"api_headers,omitempty"`    // Request headers; ${VAR} expands environment variables
+	// How this definition combines with one from an earlier file: "replace" (default) or "append"
+	Merge string `json:"merge,omitempty"`
 }
 
 // SourcesConfig represents the complete sources configuration
 type SourcesConfig struct {
 	Version string                   `json:"version"`
 	Sources map[string]*SourceConfig `json:"sources"`
+	Merge   string                   `json:"merge,omitempty"` // Default merge strategy of the file's sources
 	// Metadata
 	LastUpdated string `json:"last_updated,omitempty"`
 	Author      string `json:"author,omitempty"`
@@ -40,6 +48,7 @@ type SourcesConfig struct {
 type SourceLoader struct {
 	mu            sync.RWMutex
 	sources       map[string]*Source
+	configs       map[string]*SourceConfig // Merged definitions, before conversion to sources
 	configPaths   []string
 	embeddedFS    *embed.FS
 	embeddedPath  string
@@ -48,6 +57,22 @@ type SourceLoader struct {
 	cache         *SourceCache
 	httpClient    *http.Client    // For API-based sources with timeout
 	sefariaClient *sefaria.Client // Sefaria API client
+	clock         Clock
+	// Aeon level assignment of fetched quotes
+	classifier   AeonClassifier
+	overrides    map[string]string // From aeon_overrides.json: normalized reference to aeon level
+	overrideErrs []error
+	// Background loading of API-backed sources
+	status     map[string]*SourceStatus
+	generation int           // Incremented by Load; stale fetch results are discarded
+	inflight   int           // Background fetches in progress
+	idle       chan struct{} // Closed when inflight drops to zero; nil when idle
+	onUpdate   func(sourceID string)
+	// Where sources were defined
+	provenance    map[string]*SourceProvenance
+	fileSources   map[string][]string // Source IDs of each loaded file, for cached loads
+	loadedFiles   map[string]bool     // Absolute paths of the files read by the current Load
+	mergeStrategy string              // Default merge strategy (see WithMergeStrategy)
 }
 
 // NewSourceLoader creates a new source loader
@@ -58,12 +83,24 @@ func NewSourceLoader() *SourceLoader {
 
 	loader := &SourceLoader{
 		sources:       make(map[string]*Source),
+		configs:       make(map[string]*SourceConfig),
 		configPaths:   []string{},
 		reloadEnabled: true,
 		projectRoot:   findProjectRoot(),
 		cache:         NewSourceCache(),
 		httpClient:    httpClient,
 		sefariaClient: sefaria.NewClient(httpClient),
+		clock:         SystemClock,
+		classifier:    NewLexiconClassifier(),
+		status:        make(map[string]*SourceStatus),
+		provenance:    make(map[string]*SourceProvenance),
+		fileSources:   make(map[string][]string),
+		loadedFiles:   make(map[string]bool),
+	}
+
+	// Persist Sefaria texts so Hebrew sources work offline
+	if dir := sefaria.DefaultCacheDir(); dir != "" {
+		loader.sefariaClient.SetDiskCache(sefaria.NewDiskCache(dir))
 	}
 
 	// Start cache cleanup every 5 minutes
@@ -121,6 +158,36 @@ func (sl *SourceLoader) WithHTTPTimeout(timeout time.Duration) *SourceLoader {
 	return sl
 }
 
+// WithClock sets the clock used for cache expiry of source files and API responses
+func (sl *SourceLoader) WithClock(clock Clock) *SourceLoader {
+	sl.cache.WithClock(clock)
+	if clock != nil {
+		sl.clock = clock
+		sl.sefariaClient.SetClock(clock.Now)
+	}
+	return sl
+}
+
+// WithSefariaCacheDir sets the directory of the persistent Sefaria text cache
+// (default: $XDG_CACHE_HOME/devwisdom/sefaria). An empty dir disables it.
+func (sl *SourceLoader) WithSefariaCacheDir(dir string) *SourceLoader {
+	if dir == "" {
+		sl.sefariaClient.SetDiskCache(nil)
+	} else {
+		sl.sefariaClient.SetDiskCache(sefaria.NewDiskCache(dir))
+	}
+	return sl
+}
+
+// SefariaCacheDir returns the directory of the persistent Sefaria text cache,
+// or "" if it is disabled.
+func (sl *SourceLoader) SefariaCacheDir() string {
+	if disk := sl.sefariaClient.DiskCache(); disk != nil {
+		return disk.Dir()
+	}
+	return ""
+}
+
 // InvalidateCache clears the cache
 func (sl *SourceLoader) InvalidateCache() {
 	sl.cache.InvalidateAll()
@@ -133,11 +200,14 @@ func (sl *SourceLoader) Load() error {
 
 	// Start with empty sources
 	sl.sources = make(map[string]*Source)
+	sl.status = make(map[string]*SourceStatus)
+	sl.provenance = make(map[string]*SourceProvenance)
+	sl.loadedFiles = make(map[string]bool)
+	sl.generation++
+	sl.loadAeonOverrides()
 
 	// Clear existing configs
-	configsMu.Lock()
-	configs = make(map[string]*SourceConfig)
-	configsMu.Unlock()
+	sl.configs = make(map[string]*SourceConfig)
 
 	// 1. Load embedded default sources (if available)
 	if sl.embeddedFS != nil && sl.embeddedPath != "" {
@@ -224,12 +294,10 @@ func (sl *SourceLoader) AddSource(config *SourceConfig) error {
 	defer sl.mu.Unlock()
 
 	// Add to configs
-	configsMu.Lock()
-	configs[config.ID] = config
-	configsMu.Unlock()
+	merged := sl.addConfig(config, OriginRuntime, sl.mergeStrategyOf(config, ""))
 
 	// Convert and add to sources
-	source := sl.configToSource(config.ID, config)
+	source := sl.configToSource(config.ID, merged)
 	sl.sources[config.ID] = source
 
 	return nil
@@ -260,27 +328,23 @@ func (sl *SourceLoader) GetProjectSourcesPath() string {
 	return filepath.Join(sl.projectRoot, ".wisdom", "sources.json")
 }
 
-// Internal state for configs (before conversion to Sources)
-var (
-	configsMu sync.Mutex
-	configs   = make(map[string]*SourceConfig)
-)
-
+// getConfigs returns a copy of the merged source definitions. The caller
+// must hold sl.mu.
 func (sl *SourceLoader) getConfigs() map[string]*SourceConfig {
-	configsMu.Lock()
-	defer configsMu.Unlock()
-
-	result := make(map[string]*SourceConfig)
-	for id, config := range configs {
+	result := make(map[string]*SourceConfig, len(sl.configs))
+	for id, config := range sl.configs {
 		result[id] = config
 	}
 	return result
 }
 
-func (sl *SourceLoader) addConfig(config *SourceConfig) {
-	configsMu.Lock()
-	defer configsMu.Unlock()
-	configs[config.ID] = config
+// addConfig adds a source definition loaded from origin, combining it with
+// an earlier definition under strategy, and returns the resulting
+// configuration. The caller must hold sl.mu.
+func (sl *SourceLoader) addConfig(config *SourceConfig, origin, strategy string) *SourceConfig {
+	merged := sl.mergeConfig(config, sl.configs[config.ID], origin, strategy)
+	sl.configs[config.ID] = merged
+	return merged
 }
 
 // findProjectRoot finds the project root directory by looking for common markers
@@ -336,21 +400,28 @@ func (sl *SourceLoader) loadFromEmbedded() error {
 	}
 
 	// Add all sources from embedded config
-	for id, config := range sourcesConfig.Sources {
+	for _, id := range sortedSourceIDs(sourcesConfig.Sources) {
+		config := sourcesConfig.Sources[id]
 		config.ID = id // Ensure ID is set
-		sl.addConfig(config)
+		sl.addConfig(config, OriginEmbedded+":"+sl.embeddedPath, sl.mergeStrategyOf(config, sourcesConfig.Merge))
 	}
 
 	return nil
 }
 
-// loadFromFile loads sources from a JSON file (with caching)
+// loadFromFile loads sources from a JSON file (with caching). A file reached
+// by several paths is loaded once per Load, so appended quotes are not
+// repeated; sources are recorded with the file's absolute path.
 func (sl *SourceLoader) loadFromFile(path string) error {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if sl.loadedFiles[path] {
+		return nil
+	}
+
 	// Check cache first
-	cacheKey := fmt.Sprintf("file:%s", path)
-	if cached, found := sl.cache.Get(cacheKey); found {
-		// Use cached config
-		sl.addConfig(cached)
+	if sl.loadFromCache(path) {
 		return nil
 	}
 
@@ -366,56 +437,121 @@ func (sl *SourceLoader) loadFromFile(path string) error {
 	}
 
 	// Add/override sources from file
-	for id, config := range sourcesConfig.Sources {
+	ids := sortedSourceIDs(sourcesConfig.Sources)
+	for _, id := range ids {
+		config := sourcesConfig.Sources[id]
 		config.ID = id // Ensure ID is set
+		if config.Merge == "" {
+			config.Merge = sourcesConfig.Merge // Kept with the cached config
+		}
 
 		// Cache individual source configs
 		sourceCacheKey := fmt.Sprintf("source:%s:%s", path, id)
 		sl.cache.Set(sourceCacheKey, config, path)
 
-		sl.addConfig(config)
+		sl.addConfig(config, path, sl.mergeStrategyOf(config, ""))
 	}
 
 	// Cache the entire file config (for quick lookup)
-	sl.cache.Set(cacheKey, nil, path) // nil means "file loaded successfully"
+	sl.cache.Set(fmt.Sprintf("file:%s", path), nil, path) // nil means "file loaded successfully"
+	sl.fileSources[path] = ids
+	sl.loadedFiles[path] = true
 
 	return nil
 }
 
+// loadFromCache adds the sources of a file from the cache. It returns false
+// if the file or any of its sources is not cached (or is stale), in which
+// case nothing is added.
+func (sl *SourceLoader) loadFromCache(path string) bool {
+	ids, loaded := sl.fileSources[path]
+	if _, found := sl.cache.Get(fmt.Sprintf("file:%s", path)); !found || !loaded {
+		return false
+	}
+	cached := make([]*SourceConfig, 0, len(ids))
+	for _, id := range ids {
+		config, found := sl.cache.Get(fmt.Sprintf("source:%s:%s", path, id))
+		if !found || config == nil {
+			return false
+		}
+		cached = append(cached, config)
+	}
+	for _, config := range cached {
+		sl.addConfig(config, path, sl.mergeStrategyOf(config, ""))
+	}
+	sl.loadedFiles[path] = true
+	return true
+}
+
+// sortedSourceIDs returns the keys of sources in order, so sources of a file
+// are always added in the same order.
+func sortedSourceIDs(sources map[string]*SourceConfig) []string {
+	ids := make([]string, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // loadFromDefaultLocations loads from standard config locations
 // Priority: Project-specific sources override global sources
 func (sl *SourceLoader) loadFromDefaultLocations() {
+	for _, path := range sl.defaultSourcePaths() {
+		sl.tryLoadPath(path)
+	}
+}
+
+// SourceFilePaths returns the sources.json locations the loader reads, in
+// load order (later files override earlier ones): explicit config paths, then
+// the default locations. Files that do not exist are skipped when loading.
+func (sl *SourceLoader) SourceFilePaths() []string {
+	paths := append([]string(nil), sl.configPaths...)
+	return append(paths, sl.defaultSourcePaths()...)
+}
+
+// defaultSourcePaths returns the default sources.json locations in load order.
+func (sl *SourceLoader) defaultSourcePaths() []string {
+	var paths []string
+
 	// PROJECT-SPECIFIC SOURCES (highest priority)
 	// These are loaded first but can be overridden by explicit paths
 	if sl.projectRoot != "" {
-		// Project root .wisdom directory
-		sl.tryLoadPath(filepath.Join(sl.projectRoot, ".wisdom", "sources.json"))
-		// Project root directly
-		sl.tryLoadPath(filepath.Join(sl.projectRoot, "sources.json"))
-		sl.tryLoadPath(filepath.Join(sl.projectRoot, "wisdom", "sources.json"))
+		paths = append(paths,
+			// Project root .wisdom directory
+			filepath.Join(sl.projectRoot, ".wisdom", "sources.json"),
+			// Project root directly
+			filepath.Join(sl.projectRoot, "sources.json"),
+			filepath.Join(sl.projectRoot, "wisdom", "sources.json"),
+		)
 	}
 
 	// Current working directory (if different from project root)
 	cwd, _ := os.Getwd()
 	if cwd != sl.projectRoot {
-		sl.tryLoadPath(filepath.Join(cwd, "sources.json"))
-		sl.tryLoadPath(filepath.Join(cwd, "wisdom", "sources.json"))
-		sl.tryLoadPath(filepath.Join(cwd, ".wisdom", "sources.json"))
+		paths = append(paths,
+			filepath.Join(cwd, "sources.json"),
+			filepath.Join(cwd, "wisdom", "sources.json"),
+			filepath.Join(cwd, ".wisdom", "sources.json"),
+		)
 	}
 
 	// GLOBAL SOURCES (lower priority)
 	// Home directory
 	if home, err := os.UserHomeDir(); err == nil {
-		sl.tryLoadPath(filepath.Join(home, ".wisdom", "sources.json"))
-		sl.tryLoadPath(filepath.Join(home, ".exarp_wisdom", "sources.json"))
+		paths = append(paths,
+			filepath.Join(home, ".wisdom", "sources.json"),
+			filepath.Join(home, ".exarp_wisdom", "sources.json"),
+		)
 	}
 
 	// XDG config directory
 	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
-		sl.tryLoadPath(filepath.Join(xdgConfig, "wisdom", "sources.json"))
+		paths = append(paths, filepath.Join(xdgConfig, "wisdom", "sources.json"))
 	} else if home, err := os.UserHomeDir(); err == nil {
-		sl.tryLoadPath(filepath.Join(home, ".config", "wisdom", "sources.json"))
+		paths = append(paths, filepath.Join(home, ".config", "wisdom", "sources.json"))
 	}
+	return paths
 }
 
 func (sl *SourceLoader) tryLoadPath(path string) {
@@ -425,113 +561,201 @@ func (sl *SourceLoader) tryLoadPath(path string) {
 	}
 }
 
-// configToSource converts SourceConfig to Source
-func (sl *SourceLoader) configToSource(id string, config *SourceConfig) *Source {
-	source := &Source{
-		Name:   config.Name,
-		Icon:   config.Icon,
-		Quotes: make(map[string][]Quote),
+// newSource returns a source with config's metadata and no quotes.
+func (sl *SourceLoader) newSource(config *SourceConfig) *Source {
+	return &Source{
+		Name:        config.Name,
+		Icon:        config.Icon,
+		Description: config.Description,
+		Quotes:      make(map[string][]Quote),
+		Language:    config.Language,
 	}
+}
 
-	if config.Description != "" {
-		source.Description = config.Description
+// configToSource converts SourceConfig to Source and records its load status.
+// API-backed sources are built from cached texts when available; otherwise
+// their config quotes serve as a fallback while the texts are fetched in the
+// background. The caller must hold sl.mu.
+func (sl *SourceLoader) configToSource(id string, config *SourceConfig) *Source {
+	source := sl.newSource(config)
+
+	// Copy quotes by aeon level from config
+	for level, quotes := range config.Quotes {
+		source.Quotes[level] = make([]Quote, len(quotes))
+		copy(source.Quotes[level], quotes)
 	}
 
 	// Check if this is a Sefaria API source
-	if config.SefariaSource != "" {
-		// Load quotes from Sefaria API
-		quotes := sl.loadSefariaQuotes(id, config)
-		if len(quotes) > 0 {
-			// Distribute quotes across aeon levels
-			source.Quotes = sl.distributeQuotesByAeonLevel(quotes)
+	if refs := sefariaRefs(config); len(refs) > 0 {
+		state := SourceReady
+		var quotes []Quote
+		for _, ref := range refs {
+			textResp, found := sl.sefariaClient.CachedRef(ref)
+			if !found {
+				state = SourcePending
+				break
+			}
+			if textResp.Stale {
+				state = SourceStale
+			}
+			quotes = append(quotes, sl.sefariaQuotes(id, config, textResp)...)
 		}
-	} else {
-		// Copy quotes by aeon level from config
-		for level, quotes := range config.Quotes {
-			source.Quotes[level] = make([]Quote, len(quotes))
-			copy(source.Quotes[level], quotes)
+		if state != SourcePending && len(quotes) > 0 {
+			// Place quotes on aeon levels by their content
+			source.Quotes = classifyQuotes(sl.aeonClassifier(), quotes)
+		} else {
+			state = SourcePending
+		}
+		assignQuoteIDs(id, source)
+		sl.setStatus(id, state, true, source, nil)
+		if state != SourceReady {
+			sl.startFetch(id, config, sl.fetchSefaria(id, config))
+		}
+		return source
+	}
+
+	// Check if this is a generic HTTP JSON source
+	if config.APIEndpoint != "" {
+		state := SourcePending
+		if fetched, found := sl.cache.Get(apiCacheKey(id, config)); found && fetched != nil {
+			source.Quotes = apiQuotes(id, config, fetched)
+			state = SourceReady
+		}
+		assignQuoteIDs(id, source)
+		sl.setStatus(id, state, true, source, nil)
+		if state != SourceReady {
+			sl.startFetch(id, config, sl.fetchAPI(id, config))
 		}
+		return source
 	}
 
+	assignQuoteIDs(id, source)
+	sl.setStatus(id, SourceReady, false, source, nil)
 	return source
 }
 
-// loadSefariaQuotes fetches quotes from Sefaria API
-func (sl *SourceLoader) loadSefariaQuotes(sourceID string, config *SourceConfig) []Quote {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Fetch full book (chapter 0, verse 0 means full book)
-	textResp, err := sl.sefariaClient.GetTextBySourceID(ctx, config.SefariaSource, 0, 0)
-	if err != nil {
-		// Silently fail - API might be unavailable, use fallback if available
-		// Log error would go here in production, but we avoid stdout/stderr in MCP mode
-		return nil
+// sefariaRefs returns the Sefaria references of a source: sefaria_refs, or
+// the book or reference named by sefaria_source.
+func sefariaRefs(config *SourceConfig) []string {
+	if len(config.SefariaRefs) > 0 {
+		return config.SefariaRefs
+	}
+	if config.SefariaSource != "" {
+		return []string{sefaria.RefForSourceID(config.SefariaSource)}
 	}
+	return nil
+}
 
-	// Convert Sefaria response to quotes
+// sefariaQuotes converts a Sefaria response to quotes, one per verse, each
+// attributed to its precise reference (e.g. "Pirkei Avot 2:5"). Hebrew verses
+// are the quote text and are paired with the English verse at the same
+// position as their translation; responses without Hebrew use the English.
+func (sl *SourceLoader) sefariaQuotes(sourceID string, config *SourceConfig, textResp *sefaria.TextResponse) []Quote {
 	quotes := make([]Quote, 0)
-	sourceName := config.Name
 
 	// Use Hebrew text if available, otherwise English
-	texts := textResp.He
-	if len(texts) == 0 {
-		texts = textResp.Text
+	verses := textResp.He
+	var translations map[string]string
+	if len(verses) == 0 {
+		verses = textResp.Text
+	} else {
+		translations = make(map[string]string, len(textResp.Text))
+		for _, verse := range textResp.Text {
+			translations[fmt.Sprint(verse.Path)] = verse.Text
+		}
+	}
+
+	// Generate a simple encouragement based on source
+	encouragement := "Reflect on this wisdom."
+	if config.Language == "hebrew" {
+		encouragement = "התבונן בחכמה זו." // "Reflect on this wisdom" in Hebrew
 	}
 
 	// Create quotes from verses
-	for i, verseText := range texts {
-		if verseText == "" {
+	for _, verse := range verses {
+		if verse.Text == "" {
 			continue
 		}
 
-		// Build source reference (e.g., "Pirkei Avot 1:1")
-		ref := textResp.Ref
+		ref := textResp.VerseRef(verse)
 		if ref == "" {
-			ref = sourceName
-		}
-
-		// Generate a simple encouragement based on source
-		encouragement := "Reflect on this wisdom."
-		if config.Language == "hebrew" {
-			encouragement = "התבונן בחכמה זו." // "Reflect on this wisdom" in Hebrew
+			ref = config.Name
 		}
 
-		quote := Quote{
-			Quote:         verseText,
+		quotes = append(quotes, Quote{
+			Quote:         verse.Text,
+			Translation:   translations[fmt.Sprint(verse.Path)],
 			Source:        ref,
 			Encouragement: encouragement,
 			WisdomSource:  sourceID,
 			WisdomIcon:    config.Icon,
-		}
-
-		quotes = append(quotes, quote)
-		_ = i // Verse number could be used for more specific references
+		})
 	}
 
 	return quotes
 }
 
-// distributeQuotesByAeonLevel distributes quotes across aeon levels
-// Uses round-robin distribution to ensure all levels have quotes
-func (sl *SourceLoader) distributeQuotesByAeonLevel(quotes []Quote) map[string][]Quote {
-	levels := []string{"chaos", "lower_aeons", "middle_aeons", "upper_aeons", "treasury"}
-	distributed := make(map[string][]Quote)
+// SefariaFetchResult reports the outcome of fetching one Sefaria source.
+type SefariaFetchResult struct {
+	SourceID string   // Wisdom source ID (e.g. "rebbe")
+	Refs     []string // Sefaria references (e.g. "Pirkei_Avot")
+	Verses   int      // Number of verses fetched
+	Err      error    // Non-nil if the fetch failed
+	// Distribution reports how the fetched verses are placed on aeon levels
+	Distribution *AeonDistribution
+}
 
-	// Initialize all levels
-	for _, level := range levels {
-		distributed[level] = make([]Quote, 0)
+// FetchSefariaSources downloads the texts of all loaded Sefaria sources into
+// the persistent cache, revalidating cached copies, and rebuilds those
+// sources from the fresh texts. Results are ordered by source ID.
+func (sl *SourceLoader) FetchSefariaSources(ctx context.Context) []SefariaFetchResult {
+	sl.mu.RLock()
+	configs := sl.getConfigs()
+	sl.mu.RUnlock()
+	ids := make([]string, 0, len(configs))
+	for id, config := range configs {
+		if len(sefariaRefs(config)) > 0 {
+			ids = append(ids, id)
+		}
 	}
-
-	// Round-robin distribution
-	for i, quote := range quotes {
-		level := levels[i%len(levels)]
-		distributed[level] = append(distributed[level], quote)
+	sort.Strings(ids)
+
+	results := make([]SefariaFetchResult, 0, len(ids))
+	for _, id := range ids {
+		config := configs[id]
+		result := SefariaFetchResult{SourceID: id, Refs: sefariaRefs(config)}
+		for _, ref := range result.Refs {
+			textResp, err := sl.sefariaClient.RefreshRef(ctx, ref)
+			if err != nil {
+				result.Err = fmt.Errorf("failed to fetch Sefaria reference %q for %q: %w", ref, id, err)
+				break
+			}
+			verses := len(textResp.He)
+			if verses == 0 {
+				verses = len(textResp.Text)
+			}
+			result.Verses += verses
+		}
+		if result.Err == nil {
+			// Rebuild the source from the now-cached texts
+			sl.mu.Lock()
+			source := sl.configToSource(id, config)
+			sl.sources[id] = source
+			result.Distribution = source.Distribution()
+			onUpdate := sl.onUpdate
+			sl.mu.Unlock()
+			if onUpdate != nil {
+				onUpdate(id)
+			}
+		}
+		results = append(results, result)
 	}
-
-	return distributed
+	return results
 }
 
+// aeonLevels lists the aeon levels from lowest to highest.
+var aeonLevels = []string{string(AeonChaos), string(AeonLower), string(AeonMiddle), string(AeonUpper), string(AeonTreasury)}
+
 // ValidateConfig validates a source configuration
 func ValidateConfig(config *SourceConfig) error {
 	if config.ID == "" {
@@ -540,9 +764,18 @@ func ValidateConfig(config *SourceConfig) error {
 	if config.Name == "" {
 		return fmt.Errorf("source configuration validation failed: Name field is required for source %q", config.ID)
 	}
-	if len(config.Quotes) == 0 {
+	// API-backed sources may omit fallback quotes
+	if len(config.Quotes) == 0 && len(sefariaRefs(config)) == 0 && config.APIEndpoint == "" {
 		return fmt.Errorf("source configuration validation failed: source %q must have at least one quote", config.ID)
 	}
+	if err := validateMerge(config.Merge, config.ID); err != nil {
+		return err
+	}
+	if config.APIEndpoint != "" {
+		if u, err := url.Parse(config.APIEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("source configuration validation failed: api_endpoint %q for source %q must be an http or https URL", config.APIEndpoint, config.ID)
+		}
+	}
 
 	// Validate aeon levels
 	validLevels := []string{"chaos", "lower_aeons", "middle_aeons", "upper_aeons", "treasury"}
@@ -2,8 +2,10 @@ package wisdom
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -232,6 +234,24 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "API source without fallback quotes",
+			config: &SourceConfig{
+				ID:          "test",
+				Name:        "Test",
+				APIEndpoint: "https://quotes.example.com/v1/quotes",
+			},
+			wantErr: false,
+		},
+		{
+			name: "API source with invalid endpoint",
+			config: &SourceConfig{
+				ID:          "test",
+				Name:        "Test",
+				APIEndpoint: "quotes.example.com",
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid aeon level",
 			config: &SourceConfig{
@@ -322,3 +342,106 @@ func TestSourceLoader_GetAllSources(t *testing.T) {
 		t.Errorf("GetAllSources returned %d sources, want 2", len(allSources))
 	}
 }
+
+func TestSourceLoader_WithSefariaCacheDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	loader := NewSourceLoader()
+	if got, want := loader.SefariaCacheDir(), filepath.Join(os.Getenv("XDG_CACHE_HOME"), "devwisdom", "sefaria"); got != want {
+		t.Errorf("default SefariaCacheDir() = %q, want %q", got, want)
+	}
+
+	dir := t.TempDir()
+	if got := loader.WithSefariaCacheDir(dir).SefariaCacheDir(); got != dir {
+		t.Errorf("SefariaCacheDir() = %q, want %q", got, dir)
+	}
+	if got := loader.WithSefariaCacheDir("").SefariaCacheDir(); got != "" {
+		t.Errorf("SefariaCacheDir() = %q after disabling, want empty", got)
+	}
+}
+
+// newProjectLoader returns a loader for a new project whose
+// .wisdom/sources.json defines the source id.
+func newProjectLoader(t *testing.T, id string) *SourceLoader {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".wisdom"), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	writeSourcesFile(t, filepath.Join(root, ".wisdom"), "sources.json",
+		`{"sources": {"`+id+`": {"name": "`+id+`", "quotes": {"chaos": [{"quote": "From `+id+`", "source": "S"}]}}}}`)
+	return NewSourceLoader().WithProjectRoot(root)
+}
+
+func TestSourceLoader_IndependentLoaders(t *testing.T) {
+	first := newProjectLoader(t, "first_project")
+	second := newProjectLoader(t, "second_project")
+
+	if err := first.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := first.AddSource(&SourceConfig{ID: "first_runtime", Name: "Runtime", Quotes: map[string][]Quote{"chaos": {{Quote: "Q"}}}}); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	if err := second.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// Loading the second loader must not clear or leak into the first
+	if err := first.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if _, exists := first.GetSource("first_project"); !exists {
+		t.Error("first loader lost its project source")
+	}
+	for _, id := range []string{"first_project", "first_runtime"} {
+		if _, exists := second.GetSource(id); exists {
+			t.Errorf("second loader has %q from the first", id)
+		}
+	}
+	if _, exists := first.GetSource("second_project"); exists {
+		t.Error("first loader has the second loader's project source")
+	}
+}
+
+func TestSourceLoader_ConcurrentLoaders(t *testing.T) {
+	const loaders = 8
+	ids := make([]string, loaders)
+	instances := make([]*SourceLoader, loaders)
+	for i := range instances {
+		ids[i] = "project_" + string(rune('a'+i))
+		instances[i] = newProjectLoader(t, ids[i])
+	}
+
+	errs := make(chan error, loaders)
+	for i, loader := range instances {
+		go func(id string, loader *SourceLoader) {
+			for round := 0; round < 20; round++ {
+				if err := loader.Reload(); err != nil {
+					errs <- err
+					return
+				}
+				runtimeID := id + "_runtime"
+				if err := loader.AddSource(&SourceConfig{ID: runtimeID, Name: runtimeID, Quotes: map[string][]Quote{"chaos": {{Quote: "Q"}}}}); err != nil {
+					errs <- err
+					return
+				}
+				for _, other := range loader.ListSourceIDs() {
+					if other != id && other != runtimeID && strings.HasPrefix(other, "project_") {
+						errs <- fmt.Errorf("loader for %s sees %s", id, other)
+						return
+					}
+				}
+				if _, exists := loader.GetSource(id); !exists {
+					errs <- fmt.Errorf("loader for %s lost its project source", id)
+					return
+				}
+			}
+			errs <- nil
+		}(ids[i], loader)
+	}
+	for range instances {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package wisdom
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Merge strategies for a source defined in several files.
+const (
+	MergeReplace = "replace" // The later definition replaces the earlier one (default)
+	MergeAppend  = "append"  // The later definition's quotes are added to the earlier one's
+)
+
+// Origins of sources not loaded from a file.
+const (
+	OriginEmbedded = "embedded" // Prefix of sources from the embedded filesystem ("embedded:<path>")
+	OriginRuntime  = "runtime"  // Sources added with AddSource
+)
+
+// SourceLayer is one definition of a source: a file (or AddSource call)
+// defining it, in the order sources are loaded.
+type SourceLayer struct {
+	Path     string `json:"path"`               // File path, "embedded:<path>", or "runtime"
+	Merge    string `json:"merge"`              // MergeReplace or MergeAppend
+	Quotes   int    `json:"quotes"`             // Quotes the definition contributes
+	Shadowed bool   `json:"shadowed,omitempty"` // Replaced by a later definition
+}
+
+// SourceProvenance records where a source's definition in effect comes from
+// and the definitions it shadows or extends.
+type SourceProvenance struct {
+	ID     string        `json:"id"`
+	Origin string        `json:"origin"` // Path of the last definition
+	Layers []SourceLayer `json:"layers"` // All definitions, most recent first
+}
+
+// Provenance returns where a loaded source was defined.
+func (sl *SourceLoader) Provenance(id string) (*SourceProvenance, bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	provenance, exists := sl.provenance[id]
+	if !exists {
+		return nil, false
+	}
+	return provenance.clone(), true
+}
+
+// Provenances returns the provenance of all loaded sources, sorted by ID.
+func (sl *SourceLoader) Provenances() []SourceProvenance {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	result := make([]SourceProvenance, 0, len(sl.provenance))
+	for _, provenance := range sl.provenance {
+		result = append(result, *provenance.clone())
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// WithMergeStrategy sets how sources defined in several files are combined
+// when neither the source nor its file sets "merge" (default: MergeReplace).
+func (sl *SourceLoader) WithMergeStrategy(strategy string) *SourceLoader {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.mergeStrategy = strategy
+	return sl
+}
+
+// clone returns a copy of p that shares nothing with it.
+func (p *SourceProvenance) clone() *SourceProvenance {
+	clone := *p
+	clone.Layers = append([]SourceLayer(nil), p.Layers...)
+	return &clone
+}
+
+// mergeStrategyOf returns the merge strategy of a source definition: its own,
+// its file's (fileDefault), or the loader's.
+func (sl *SourceLoader) mergeStrategyOf(config *SourceConfig, fileDefault string) string {
+	for _, strategy := range []string{config.Merge, fileDefault, sl.mergeStrategy} {
+		if strategy != "" {
+			return strategy
+		}
+	}
+	return MergeReplace
+}
+
+// mergeConfig combines a source definition with the one it follows
+// (previous, nil if none) under strategy and records its provenance. The
+// caller must hold sl.mu.
+func (sl *SourceLoader) mergeConfig(config, previous *SourceConfig, origin, strategy string) *SourceConfig {
+	layer := SourceLayer{Path: origin, Merge: strategy, Quotes: countConfigQuotes(config)}
+
+	provenance, exists := sl.provenance[config.ID]
+	if !exists {
+		provenance = &SourceProvenance{ID: config.ID}
+		sl.provenance[config.ID] = provenance
+	}
+	provenance.Origin = origin
+
+	appended := strategy == MergeAppend && previous != nil
+	if !appended {
+		for i := range provenance.Layers {
+			provenance.Layers[i].Shadowed = true
+		}
+	}
+	provenance.Layers = append([]SourceLayer{layer}, provenance.Layers...)
+	if appended {
+		return appendConfig(previous, config)
+	}
+	return config
+}
+
+// appendConfig returns base extended by next: next's quotes are added to
+// base's, and the fields next sets override base's. Neither is modified.
+func appendConfig(base, next *SourceConfig) *SourceConfig {
+	merged := *base
+	merged.Merge = next.Merge
+	merged.Quotes = make(map[string][]Quote, len(base.Quotes))
+	for level, quotes := range base.Quotes {
+		merged.Quotes[level] = append([]Quote(nil), quotes...)
+	}
+	for level, quotes := range next.Quotes {
+		merged.Quotes[level] = append(merged.Quotes[level], quotes...)
+	}
+
+	for _, field := range []struct{ dst, src *string }{
+		{&merged.Name, &next.Name},
+		{&merged.Icon, &next.Icon},
+		{&merged.Description, &next.Description},
+		{&merged.Language, &next.Language},
+		{&merged.SefariaSource, &next.SefariaSource},
+		{&merged.APIEndpoint, &next.APIEndpoint},
+	} {
+		if *field.src != "" {
+			*field.dst = *field.src
+		}
+	}
+	if len(next.SefariaRefs) > 0 {
+		merged.SefariaRefs = next.SefariaRefs
+	}
+	if next.APIMapping != nil {
+		merged.APIMapping = next.APIMapping
+	}
+	if len(next.APIHeaders) > 0 {
+		merged.APIHeaders = next.APIHeaders
+	}
+	return &merged
+}
+
+// countConfigQuotes returns the number of quotes in a source configuration.
+func countConfigQuotes(config *SourceConfig) int {
+	count := 0
+	for _, quotes := range config.Quotes {
+		count += len(quotes)
+	}
+	return count
+}
+
+// validateMerge checks a merge strategy name.
+func validateMerge(strategy, sourceID string) error {
+	switch strategy {
+	case "", MergeReplace, MergeAppend:
+		return nil
+	}
+	return fmt.Errorf("source configuration validation failed: merge %q for source %q must be %q or %q", strategy, sourceID, MergeReplace, MergeAppend)
+}
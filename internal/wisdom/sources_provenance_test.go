@@ -0,0 +1,158 @@
+package wisdom
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newLayeredLoader returns a loader reading the given sources files, in order.
+func newLayeredLoader(t *testing.T, contents ...string) (*SourceLoader, []string) {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, len(contents))
+	for i, content := range contents {
+		paths[i] = writeSourcesFile(t, dir, string(rune('a'+i))+".json", content)
+	}
+	loader := NewSourceLoader().WithProjectRoot(t.TempDir()).WithConfigPaths(paths...)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return loader, paths
+}
+
+const baseTeamSource = `{"sources": {"team": {"name": "Team", "icon": "🧪", "quotes": {
+  "chaos": [{"quote": "Base chaos", "source": "S"}],
+  "treasury": [{"quote": "Base treasury", "source": "S"}]
+}}}}`
+
+func TestSourceLoader_Provenance_Replace(t *testing.T) {
+	loader, paths := newLayeredLoader(t, baseTeamSource,
+		`{"sources": {"team": {"name": "Project Team", "quotes": {"chaos": [{"quote": "Project chaos", "source": "S"}]}}}}`)
+
+	provenance, ok := loader.Provenance("team")
+	if !ok {
+		t.Fatal("no provenance for team")
+	}
+	want := []SourceLayer{
+		{Path: paths[1], Merge: MergeReplace, Quotes: 1},
+		{Path: paths[0], Merge: MergeReplace, Quotes: 2, Shadowed: true},
+	}
+	if provenance.Origin != paths[1] || len(provenance.Layers) != 2 || provenance.Layers[0] != want[0] || provenance.Layers[1] != want[1] {
+		t.Errorf("provenance = %+v, want origin %s and layers %+v", provenance, paths[1], want)
+	}
+
+	source, _ := loader.GetSource("team")
+	if source.Name != "Project Team" || len(source.Quotes["treasury"]) != 0 {
+		t.Errorf("replaced source = %+v, want only the project definition", source)
+	}
+}
+
+func TestSourceLoader_Provenance_Append(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		loader  func(*SourceLoader)
+	}{
+		{
+			name:    "source merge",
+			overlay: `{"sources": {"team": {"merge": "append", "icon": "🔬", "quotes": {"chaos": [{"quote": "Project chaos", "source": "S"}]}}}}`,
+		},
+		{
+			name:    "file merge",
+			overlay: `{"merge": "append", "sources": {"team": {"icon": "🔬", "quotes": {"chaos": [{"quote": "Project chaos", "source": "S"}]}}}}`,
+		},
+		{
+			name:    "loader merge",
+			overlay: `{"sources": {"team": {"icon": "🔬", "quotes": {"chaos": [{"quote": "Project chaos", "source": "S"}]}}}}`,
+			loader:  func(sl *SourceLoader) { sl.WithMergeStrategy(MergeAppend) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader, paths := newLayeredLoader(t, baseTeamSource, tt.overlay)
+			if tt.loader != nil {
+				tt.loader(loader)
+				if err := loader.Load(); err != nil {
+					t.Fatalf("Load failed: %v", err)
+				}
+			}
+
+			source, _ := loader.GetSource("team")
+			if source.Name != "Team" || source.Icon != "🔬" {
+				t.Errorf("merged metadata = %q %q, want the base name and the overlay icon", source.Name, source.Icon)
+			}
+			var chaos []string
+			for _, quote := range source.Quotes["chaos"] {
+				chaos = append(chaos, quote.Quote)
+			}
+			if strings.Join(chaos, ",") != "Base chaos,Project chaos" || len(source.Quotes["treasury"]) != 1 {
+				t.Errorf("merged quotes = %v, want base and project quotes", source.Quotes)
+			}
+
+			provenance, _ := loader.Provenance("team")
+			if len(provenance.Layers) != 2 || provenance.Layers[0].Merge != MergeAppend || provenance.Layers[1].Shadowed {
+				t.Errorf("provenance = %+v, want an append layer over an unshadowed base", provenance)
+			}
+			if provenance.Origin != paths[1] {
+				t.Errorf("origin = %q, want %q", provenance.Origin, paths[1])
+			}
+		})
+	}
+}
+
+func TestSourceLoader_Provenance_FileLoadedOnce(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSourcesFile(t, dir, "sources.json",
+		`{"merge": "append", "sources": {"team": {"name": "Team", "quotes": {"chaos": [{"quote": "Q", "source": "S"}]}}}}`)
+	relative, err := filepath.Rel(mustGetwd(t), path)
+	if err != nil {
+		t.Fatalf("Rel failed: %v", err)
+	}
+
+	loader := NewSourceLoader().WithProjectRoot(t.TempDir()).WithConfigPaths(path, relative)
+	for i := 0; i < 2; i++ { // The second load is served from the cache
+		if err := loader.Load(); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		source, _ := loader.GetSource("team")
+		provenance, _ := loader.Provenance("team")
+		if len(source.Quotes["chaos"]) != 1 || len(provenance.Layers) != 1 || provenance.Origin != path {
+			t.Errorf("load %d: quotes %v, provenance %+v; want the file loaded once", i+1, source.Quotes, provenance)
+		}
+	}
+}
+
+func TestSourceLoader_Provenance_Runtime(t *testing.T) {
+	loader, paths := newLayeredLoader(t, baseTeamSource)
+	err := loader.AddSource(&SourceConfig{
+		ID:     "team",
+		Name:   "Team",
+		Merge:  MergeAppend,
+		Quotes: map[string][]Quote{"upper_aeons": {{Quote: "Runtime", Source: "S"}}},
+	})
+	if err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+
+	provenance, _ := loader.Provenance("team")
+	if provenance.Origin != OriginRuntime || len(provenance.Layers) != 2 || provenance.Layers[1].Path != paths[0] {
+		t.Errorf("provenance = %+v, want a runtime layer over %s", provenance, paths[0])
+	}
+	if source, _ := loader.GetSource("team"); len(source.Quotes["upper_aeons"]) != 1 || len(source.Quotes["chaos"]) != 1 {
+		t.Errorf("quotes = %v, want runtime quotes appended", source.Quotes)
+	}
+
+	if err := loader.AddSource(&SourceConfig{ID: "bad", Name: "Bad", Merge: "union", Quotes: map[string][]Quote{"chaos": {{Quote: "Q"}}}}); err == nil {
+		t.Error("AddSource accepted an unknown merge strategy")
+	}
+}
+
+func mustGetwd(t *testing.T) string {
+	t.Helper()
+	wd, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatalf("Abs failed: %v", err)
+	}
+	return wd
+}
@@ -0,0 +1,238 @@
+package wisdom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SourceState is the load state of a wisdom source.
+type SourceState string
+
+// Source load states. Sources defined entirely in configuration files are
+// always SourceReady; API-backed sources move through the other states as
+// their texts are fetched in the background.
+const (
+	// SourcePending means the API fetch is in progress; the source's
+	// config-defined fallback quotes are served meanwhile.
+	SourcePending SourceState = "pending"
+	// SourceReady means the source's quotes are loaded and current.
+	SourceReady SourceState = "ready"
+	// SourceFailed means the API fetch failed with nothing cached; only the
+	// fallback quotes (if any) are available.
+	SourceFailed SourceState = "failed"
+	// SourceStale means quotes come from an expired cached copy because the
+	// API could not be reached (or revalidation is still in progress).
+	SourceStale SourceState = "stale"
+)
+
+// SourceStatus reports the load status of a wisdom source.
+type SourceStatus struct {
+	ID        string      `json:"id"`
+	State     SourceState `json:"state"`
+	Remote    bool        `json:"remote"`          // Quotes are fetched from an API
+	Quotes    int         `json:"quotes"`          // Quotes currently served
+	Error     string      `json:"error,omitempty"` // Last fetch error
+	UpdatedAt time.Time   `json:"updated_at"`      // Last state change
+	// Distribution reports how the quotes served are placed on aeon levels
+	Distribution *AeonDistribution `json:"distribution,omitempty"`
+}
+
+// SourceStatus returns the load status of a source.
+func (sl *SourceLoader) SourceStatus(id string) (SourceStatus, bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	status, exists := sl.status[id]
+	if !exists {
+		return SourceStatus{}, false
+	}
+	return *status, true
+}
+
+// SourceStatuses returns the load status of all sources, ordered by ID.
+func (sl *SourceLoader) SourceStatuses() []SourceStatus {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	statuses := make([]SourceStatus, 0, len(sl.status))
+	for _, status := range sl.status {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
+
+// OnSourceUpdate registers a function called with a source's ID whenever a
+// background fetch replaces its quotes. It is called without loader locks held.
+func (sl *SourceLoader) OnSourceUpdate(fn func(sourceID string)) *SourceLoader {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.onUpdate = fn
+	return sl
+}
+
+// WaitForSources blocks until all background source fetches have finished or
+// ctx is done.
+func (sl *SourceLoader) WaitForSources(ctx context.Context) error {
+	sl.mu.RLock()
+	idle := sl.idle
+	sl.mu.RUnlock()
+
+	if idle == nil {
+		return nil
+	}
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for sources to load: %w", ctx.Err())
+	}
+}
+
+// setStatus records a source's load status. The caller must hold sl.mu.
+func (sl *SourceLoader) setStatus(id string, state SourceState, remote bool, source *Source, err error) {
+	status := &SourceStatus{
+		ID:        id,
+		State:     state,
+		Remote:    remote,
+		UpdatedAt: sl.clock.Now(),
+	}
+	if source != nil {
+		for _, quotes := range source.Quotes {
+			status.Quotes += len(quotes)
+		}
+		status.Distribution = source.Distribution()
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	sl.status[id] = status
+}
+
+// sourceFetcher fetches the quotes of an API-backed source, reporting whether
+// they come from an expired cached copy.
+type sourceFetcher func() (quotes map[string][]Quote, stale bool, err error)
+
+// startFetch runs fetch in the background and replaces the source's quotes
+// when it succeeds. The caller must hold sl.mu.
+func (sl *SourceLoader) startFetch(id string, config *SourceConfig, fetch sourceFetcher) {
+	if sl.inflight == 0 {
+		sl.idle = make(chan struct{})
+	}
+	sl.inflight++
+	generation := sl.generation
+
+	go func() {
+		quotes, stale, err := fetch()
+
+		sl.mu.Lock()
+		onUpdate := sl.onUpdate
+		updated := false
+		// Results of a fetch started before the last Load are discarded
+		if generation == sl.generation {
+			if err != nil {
+				source := sl.sources[id]
+				state := SourceFailed
+				if previous := sl.status[id]; previous != nil && previous.State == SourceStale {
+					state = SourceStale // Keep serving the expired cached copy
+				}
+				sl.setStatus(id, state, true, source, err)
+			} else {
+				source := sl.newSource(config)
+				source.Quotes = quotes
+				assignQuoteIDs(id, source)
+				sl.sources[id] = source
+				state := SourceReady
+				if stale {
+					state = SourceStale
+				}
+				sl.setStatus(id, state, true, source, nil)
+				updated = true
+			}
+		}
+		sl.mu.Unlock()
+
+		// Notify before reporting idle so WaitForSources callers see the update
+		if updated && onUpdate != nil {
+			onUpdate(id)
+		}
+
+		sl.mu.Lock()
+		sl.inflight--
+		if sl.inflight == 0 {
+			close(sl.idle)
+			sl.idle = nil
+		}
+		sl.mu.Unlock()
+	}()
+}
+
+// fetchSefaria returns a fetcher for a Sefaria source.
+func (sl *SourceLoader) fetchSefaria(id string, config *SourceConfig) sourceFetcher {
+	classifier := sl.aeonClassifier()
+	return func() (map[string][]Quote, bool, error) {
+		var quotes []Quote
+		stale := false
+		for _, ref := range sefariaRefs(config) {
+			ctx, cancel := context.WithTimeout(context.Background(), sl.httpClient.Timeout)
+			textResp, err := sl.sefariaClient.GetRef(ctx, ref)
+			cancel()
+			if err != nil {
+				return nil, false, err
+			}
+			stale = stale || textResp.Stale
+			quotes = append(quotes, sl.sefariaQuotes(id, config, textResp)...)
+		}
+		if len(quotes) == 0 {
+			return nil, false, fmt.Errorf("Sefaria references %v have no text", sefariaRefs(config))
+		}
+		// Place quotes on aeon levels by their content
+		return classifyQuotes(classifier, quotes), stale, nil
+	}
+}
+
+// fetchAPI returns a fetcher for an api_endpoint source. Fetched quotes are
+// cached in the loader's source cache.
+func (sl *SourceLoader) fetchAPI(id string, config *SourceConfig) sourceFetcher {
+	classifier := sl.aeonClassifier()
+	return func() (map[string][]Quote, bool, error) {
+		timeout := sl.httpClient.Timeout
+		ctx, cancel := context.WithTimeout(context.Background(), apiMaxRetries*(timeout+time.Second))
+		defer cancel()
+
+		apiLoader := NewAPISourceLoader("", timeout).
+			WithHeaders(config.APIHeaders).
+			WithMapping(config.APIMapping).
+			WithClassifier(classifier)
+		fetched, err := apiLoader.LoadSourceWithRetry(ctx, config.APIEndpoint, apiMaxRetries)
+		if err != nil {
+			return nil, false, err
+		}
+		sl.cache.Set(apiCacheKey(id, config), fetched, "")
+		return apiQuotes(id, config, fetched), false, nil
+	}
+}
+
+// apiMaxRetries is the number of attempts to fetch an api_endpoint source.
+const apiMaxRetries = 3
+
+// apiCacheKey is the source cache key of an api_endpoint source's quotes.
+func apiCacheKey(id string, config *SourceConfig) string {
+	return fmt.Sprintf("api:%s:%s", id, config.APIEndpoint)
+}
+
+// apiQuotes returns the quotes of a fetched api_endpoint source, attributed to
+// the configured source.
+func apiQuotes(id string, config, fetched *SourceConfig) map[string][]Quote {
+	quotes := make(map[string][]Quote, len(fetched.Quotes))
+	for level, levelQuotes := range fetched.Quotes {
+		quotes[level] = make([]Quote, len(levelQuotes))
+		for i, quote := range levelQuotes {
+			quote.WisdomSource = id
+			quote.WisdomIcon = config.Icon
+			quotes[level][i] = quote
+		}
+	}
+	return quotes
+}
@@ -0,0 +1,238 @@
+package wisdom
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+	"github.com/davidl71/devwisdom-go/internal/wisdom/sefaria"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newSefariaTestLoader returns a loader whose Sefaria requests are answered by
+// respond once release is closed.
+func newSefariaTestLoader(t *testing.T, release <-chan struct{}, respond func(*http.Request) (*http.Response, error)) *SourceLoader {
+	t.Helper()
+	loader := NewSourceLoader().WithProjectRoot(t.TempDir())
+	loader.sefariaClient = sefaria.NewClient(&http.Client{
+		Timeout: 5 * time.Second,
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			<-release
+			return respond(req)
+		}),
+	})
+	loader.WithSefariaCacheDir(t.TempDir())
+	return loader
+}
+
+// sefariaTestConfig is an API-backed source with one fallback quote.
+func sefariaTestConfig() *SourceConfig {
+	return &SourceConfig{
+		ID:            "async_test",
+		Name:          "Async Test",
+		Language:      "hebrew",
+		SefariaSource: "psalms",
+		Quotes: map[string][]Quote{
+			"chaos": {{Quote: "Fallback quote", Source: "Config", Encouragement: "Wait."}},
+		},
+	}
+}
+
+func TestSourceLoader_AsyncSefariaLoad(t *testing.T) {
+	release := make(chan struct{})
+	loader := newSefariaTestLoader(t, release, func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"ref": "Psalms", "text": ["a", "b"], "he": ["א", "ב", "ג", "ד", "ה", "ו"]}`)),
+		}, nil
+	})
+	updates := make(chan string, 1)
+	loader.OnSourceUpdate(func(id string) { updates <- id })
+
+	if err := loader.AddSource(sefariaTestConfig()); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+
+	// Fallback quotes are served while the fetch is pending
+	status, _ := loader.SourceStatus("async_test")
+	if status.State != SourcePending || !status.Remote || status.Quotes != 1 {
+		t.Errorf("status while fetching = %+v, want pending remote source with 1 quote", status)
+	}
+	source, _ := loader.GetSource("async_test")
+	if quotes := source.Quotes["chaos"]; len(quotes) != 1 || quotes[0].Quote != "Fallback quote" {
+		t.Errorf("quotes while fetching = %v, want the fallback quote", source.Quotes)
+	}
+
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := loader.WaitForSources(ctx); err != nil {
+		t.Fatalf("WaitForSources failed: %v", err)
+	}
+
+	if id := <-updates; id != "async_test" {
+		t.Errorf("OnSourceUpdate called with %q", id)
+	}
+	status, _ = loader.SourceStatus("async_test")
+	if status.State != SourceReady || status.Quotes != 6 || status.Error != "" {
+		t.Errorf("status after fetch = %+v, want ready with 6 quotes", status)
+	}
+	source, _ = loader.GetSource("async_test")
+	if quotes := source.Quotes["chaos"]; len(quotes) == 0 || quotes[0].Quote != "א" {
+		t.Errorf("quotes after fetch = %v, want Sefaria verses", source.Quotes)
+	}
+}
+
+func TestSourceLoader_AsyncSefariaLoad_Failed(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	loader := newSefariaTestLoader(t, release, func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("network unreachable")
+	})
+
+	if err := loader.AddSource(sefariaTestConfig()); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	if err := loader.WaitForSources(context.Background()); err != nil {
+		t.Fatalf("WaitForSources failed: %v", err)
+	}
+
+	status, _ := loader.SourceStatus("async_test")
+	if status.State != SourceFailed || !strings.Contains(status.Error, "network unreachable") {
+		t.Errorf("status = %+v, want failed with the fetch error", status)
+	}
+	source, _ := loader.GetSource("async_test")
+	if len(source.Quotes["chaos"]) != 1 {
+		t.Errorf("fallback quotes not kept after a failed fetch: %v", source.Quotes)
+	}
+}
+
+func TestSourceLoader_SourceStatuses_LocalSource(t *testing.T) {
+	loader := NewSourceLoader()
+	config := &SourceConfig{
+		ID:     "local_status",
+		Name:   "Local",
+		Quotes: map[string][]Quote{"chaos": {{Quote: "Q", Source: "S"}}},
+	}
+	if err := loader.AddSource(config); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	statuses := loader.SourceStatuses()
+	if len(statuses) != 1 || statuses[0].ID != "local_status" || statuses[0].State != SourceReady || statuses[0].Remote {
+		t.Errorf("SourceStatuses() = %+v, want one ready local source", statuses)
+	}
+}
+
+func TestEngine_AsyncSourceUpdate(t *testing.T) {
+	release := make(chan struct{})
+	loader := newSefariaTestLoader(t, release, func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"ref": "Psalms", "he": ["שירו לה׳ שיר חדש"]}`)),
+		}, nil
+	})
+	engine := NewEngine().WithConfig(&config.Config{HebrewEnabled: true}).WithLoader(loader)
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := loader.AddSource(sefariaTestConfig()); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	close(release)
+	if err := engine.WaitForSources(context.Background()); err != nil {
+		t.Fatalf("WaitForSources failed: %v", err)
+	}
+
+	source, _ := loader.GetSource("async_test")
+	quote := source.Quotes["treasury"][0] // A song of praise
+	if _, err := engine.GetQuoteByID(quote.ID); err != nil {
+		t.Errorf("engine index not rebuilt after the background fetch: %v", err)
+	}
+
+	found := false
+	for _, status := range engine.SourceStatuses() {
+		if status.ID == "async_test" {
+			found = status.State == SourceReady
+		}
+	}
+	if !found {
+		t.Errorf("engine SourceStatuses() = %+v, want async_test ready", engine.SourceStatuses())
+	}
+}
+
+func TestSourceLoader_SefariaRefs(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	responses := map[string]string{
+		"/api/texts/Pirkei_Avot.2": `{"ref": "Pirkei Avot 2", "book": "Pirkei Avot", "sections": [2], "toSections": [2], "textDepth": 2,
+			"he": ["<b>רַבִּי</b> אוֹמֵר", "רַבָּן גַּמְלִיאֵל"]}`,
+		"/api/texts/Proverbs.3.5-6": `{"ref": "Proverbs 3:5-6", "book": "Proverbs", "sections": [3, 5], "toSections": [3, 6], "textDepth": 2,
+			"he": ["בְּטַח אֶל־יְהוָה", "בְּכָל־דְּרָכֶיךָ דָעֵהוּ"],
+			"text": ["Trust in the <b>LORD</b>", "In all thy ways acknowledge Him"]}`,
+	}
+	loader := newSefariaTestLoader(t, release, func(req *http.Request) (*http.Response, error) {
+		body, exists := responses[req.URL.Path]
+		if !exists {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	config := sefariaTestConfig()
+	config.SefariaSource = ""
+	config.SefariaRefs = []string{"Pirkei_Avot.2", "Proverbs.3.5-6"}
+	if err := loader.AddSource(config); err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	if err := loader.WaitForSources(context.Background()); err != nil {
+		t.Fatalf("WaitForSources failed: %v", err)
+	}
+	if status, _ := loader.SourceStatus("async_test"); status.State != SourceReady || status.Quotes != 4 {
+		t.Fatalf("status = %+v, want ready with 4 quotes", status)
+	}
+
+	refs := map[string]string{}
+	translations := map[string]string{}
+	source, _ := loader.GetSource("async_test")
+	for _, quotes := range source.Quotes {
+		for _, quote := range quotes {
+			refs[quote.Source] = quote.Quote
+			translations[quote.Source] = quote.Translation
+		}
+	}
+	want := map[string]string{
+		"Pirkei Avot 2:1": "רַבִּי אוֹמֵר",
+		"Pirkei Avot 2:2": "רַבָּן גַּמְלִיאֵל",
+		"Proverbs 3:5":    "בְּטַח אֶל־יְהוָה",
+		"Proverbs 3:6":    "בְּכָל־דְּרָכֶיךָ דָעֵהוּ",
+	}
+	for ref, text := range want {
+		if refs[ref] != text {
+			t.Errorf("quote for %q = %q, want %q", ref, refs[ref], text)
+		}
+	}
+
+	// Hebrew verses are paired with the English verse at the same position
+	wantTranslations := map[string]string{
+		"Pirkei Avot 2:1": "",
+		"Proverbs 3:5":    "Trust in the LORD",
+		"Proverbs 3:6":    "In all thy ways acknowledge Him",
+	}
+	for ref, translation := range wantTranslations {
+		if translations[ref] != translation {
+			t.Errorf("translation for %q = %q, want %q", ref, translations[ref], translation)
+		}
+	}
+}
@@ -0,0 +1,740 @@
+package wisdom
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Severity is the severity of a validation diagnostic.
+type Severity string
+
+// Diagnostic severities. Errors make a file or source unusable (the loader
+// skips it or serves it incompletely); warnings point at likely mistakes;
+// infos describe how files combine.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Diagnostic codes reported by ValidateSourceFiles.
+const (
+	DiagRead                 = "read"                  // A file that cannot be read
+	DiagSyntax               = "syntax"                // Invalid JSON
+	DiagType                 = "type"                  // A value of the wrong JSON type
+	DiagUnknownField         = "unknown_field"         // A field the loader ignores (often a typo)
+	DiagDuplicateKey         = "duplicate_key"         // A key repeated in one object; the last one wins
+	DiagInvalidSource        = "invalid_source"        // A source that fails ValidateConfig
+	DiagIDMismatch           = "id_mismatch"           // An "id" field that differs from the source's key
+	DiagEmptyQuote           = "empty_quote"           // A quote without text
+	DiagMissingEncouragement = "missing_encouragement" // A quote without encouragement
+	DiagEmptyLevel           = "empty_level"           // An aeon level without quotes
+	DiagDuplicateQuote       = "duplicate_quote"       // The same quote text twice
+	DiagDuplicateQuoteID     = "duplicate_quote_id"    // The same explicit quote ID twice
+	DiagIconConflict         = "icon_conflict"         // Two sources sharing an icon
+	DiagBuiltInOverride      = "builtin_override"      // A file redefining a built-in source
+	DiagSourceOverride       = "source_override"       // A file redefining a source from an earlier file
+)
+
+// Diagnostic is a problem found in a sources.json file.
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`   // 1-based; 0 if the problem concerns the whole file
+	Column   int      `json:"column,omitempty"` // 1-based, in characters
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Source   string   `json:"source,omitempty"` // Source ID, if the problem concerns a source
+	Message  string   `json:"message"`
+}
+
+// String formats the diagnostic as "file:line:column: severity: message (code)".
+func (d Diagnostic) String() string {
+	location := d.File
+	if d.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", location, d.Severity, d.Message, d.Code)
+}
+
+// BuiltInSourceIDs lists the sources shipped in devwisdom's default
+// sources.json. A file defining all of them is taken to be a copy of it;
+// other files defining any of them override a built-in source.
+var BuiltInSourceIDs = []string{
+	"art_of_war", "bible", "bofh", "chacham", "confucius", "enochian", "gracian", "kybalion",
+	"murphy", "pistis_sophia", "rebbe", "shakespeare", "stoic", "tao", "tao_of_programming", "tzaddik",
+}
+
+// ValidateSources validates the sources.json files the loader reads (see
+// SourceFilePaths) that exist. A file reachable by several paths is
+// validated once.
+func (sl *SourceLoader) ValidateSources() []Diagnostic {
+	return ValidateSourceFiles(sl.ExistingSourceFiles()...)
+}
+
+// ExistingSourceFiles returns the files among SourceFilePaths that exist, in
+// load order and without repeats.
+func (sl *SourceLoader) ExistingSourceFiles() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, path := range sl.SourceFilePaths() {
+		abs, err := filepath.Abs(path)
+		if err != nil || seen[abs] {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			seen[abs] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// ValidateSourceFiles checks sources.json files, given in load order (later
+// files override sources of earlier ones). Besides JSON syntax and types, it
+// reports unknown fields, invalid sources, empty quotes, missing
+// encouragements, empty aeon levels, duplicate quotes and quote IDs, icon
+// conflicts, overridden built-in sources, and sources overridden by later
+// files. Diagnostics are ordered by
+// file, then position.
+func ValidateSourceFiles(paths ...string) []Diagnostic {
+	v := &sourcesValidator{
+		definitions: make(map[string][]sourceDefinition),
+		quoteTexts:  make(map[string]quoteLocation),
+		quoteIDs:    make(map[string]quoteLocation),
+	}
+	for _, path := range paths {
+		v.validateFile(path)
+	}
+	v.validateCombined()
+
+	order := make(map[string]int, len(paths))
+	for i, path := range paths {
+		if _, exists := order[path]; !exists {
+			order[path] = i
+		}
+	}
+	sort.SliceStable(v.diagnostics, func(i, j int) bool {
+		a, b := v.diagnostics[i], v.diagnostics[j]
+		if a.File != b.File {
+			return order[a.File] < order[b.File]
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.diagnostics
+}
+
+// sourcesValidator accumulates diagnostics across files.
+type sourcesValidator struct {
+	diagnostics []Diagnostic
+	definitions map[string][]sourceDefinition // By source ID, in load order
+	quoteTexts  map[string]quoteLocation      // By normalized text: first occurrence
+	quoteIDs    map[string]quoteLocation      // By explicit quote ID: first occurrence
+}
+
+// sourceDefinition is a source defined in a file.
+type sourceDefinition struct {
+	file   *sourceFile
+	config *SourceConfig
+	node   *jsonNode
+}
+
+// quoteLocation is where a quote was first seen.
+type quoteLocation struct {
+	sourceID string
+	file     *sourceFile
+	node     *jsonNode
+}
+
+// sourceFile is a file being validated.
+type sourceFile struct {
+	path string
+	data []byte
+}
+
+// position returns the 1-based line and column of a byte offset.
+func (f *sourceFile) position(offset int64) (int, int) {
+	if offset > int64(len(f.data)) {
+		offset = int64(len(f.data))
+	}
+	before := f.data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	return line, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+// where formats a node's location for messages, e.g. "sources.json:12:5".
+func (f *sourceFile) where(node *jsonNode) string {
+	line, column := f.position(node.offset)
+	return fmt.Sprintf("%s:%d:%d", f.path, line, column)
+}
+
+// report adds a diagnostic at node (or for the whole file if node is nil).
+func (v *sourcesValidator) report(file *sourceFile, node *jsonNode, severity Severity, code, sourceID, format string, args ...interface{}) {
+	offset := int64(-1)
+	if node != nil {
+		offset = node.offset
+	}
+	v.reportAt(file, offset, severity, code, sourceID, format, args...)
+}
+
+// reportAt adds a diagnostic at a byte offset; a negative offset concerns the whole file.
+func (v *sourcesValidator) reportAt(file *sourceFile, offset int64, severity Severity, code, sourceID, format string, args ...interface{}) {
+	d := Diagnostic{
+		File:     file.path,
+		Severity: severity,
+		Code:     code,
+		Source:   sourceID,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if offset >= 0 {
+		d.Line, d.Column = file.position(offset)
+	}
+	v.diagnostics = append(v.diagnostics, d)
+}
+
+// validateFile checks one file and records its sources for validateCombined.
+func (v *sourcesValidator) validateFile(path string) {
+	data, err := os.ReadFile(path)
+	file := &sourceFile{path: path, data: data}
+	if err != nil {
+		v.report(file, nil, SeverityError, DiagRead, "", "cannot read file: %v", err)
+		return
+	}
+
+	// The decoder's scanner positions syntax errors precisely; the token walk
+	// below then only sees valid JSON
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		offset, message := int64(len(data)), err.Error()
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			offset = syntaxErr.Offset
+			// The offset points after the offending character, or at the end
+			// of a truncated file
+			if offset > 0 && !strings.HasPrefix(message, "unexpected end") {
+				offset--
+			}
+		}
+		v.reportAt(file, offset, SeverityError, DiagSyntax, "", "invalid JSON: %s", message)
+		return
+	}
+
+	parser := &jsonParser{dec: json.NewDecoder(bytes.NewReader(data)), data: data}
+	parser.dec.UseNumber()
+	root, err := parser.parse()
+	if err != nil {
+		v.report(file, nil, SeverityError, DiagSyntax, "", "invalid JSON: %v", err)
+		return
+	}
+	for _, dup := range parser.duplicates {
+		v.reportAt(file, dup.offset, SeverityError, DiagDuplicateKey, dup.sourceID,
+			"duplicate key %q: only the last definition is used", dup.key)
+	}
+
+	// Types and unknown fields, by the loader's own structs
+	typesValid := v.checkSchema(file, root, reflect.TypeOf(SourcesConfig{}), "")
+	if !typesValid {
+		return
+	}
+	var sourcesConfig SourcesConfig
+	if err := json.Unmarshal(data, &sourcesConfig); err != nil {
+		v.report(file, root, SeverityError, DiagType, "", "invalid sources file: %v", err)
+		return
+	}
+	if len(sourcesConfig.Sources) == 0 {
+		v.report(file, root, SeverityWarning, DiagInvalidSource, "", "file defines no sources (expected a \"sources\" object)")
+		return
+	}
+
+	ids := make([]string, 0, len(sourcesConfig.Sources))
+	for id := range sourcesConfig.Sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	sourcesNode := root.field("sources")
+	for _, id := range ids {
+		config := sourcesConfig.Sources[id]
+		if config != nil && config.Merge == "" {
+			config.Merge = sourcesConfig.Merge
+		}
+		v.validateSource(file, id, config, sourcesNode.field(id))
+	}
+}
+
+// validateSource checks a source definition.
+func (v *sourcesValidator) validateSource(file *sourceFile, id string, config *SourceConfig, node *jsonNode) {
+	if config == nil {
+		v.report(file, node, SeverityError, DiagInvalidSource, id, "source %q is null", id)
+		return
+	}
+	if config.ID != "" && config.ID != id {
+		v.report(file, node.field("id"), SeverityError, DiagIDMismatch, id,
+			"id %q differs from the source's key %q; the key is used", config.ID, id)
+	}
+
+	checked := *config
+	checked.ID = id
+	if checked.Merge == MergeAppend && checked.Name == "" {
+		checked.Name = id // Kept from the definition it extends
+	}
+	if err := ValidateConfig(&checked); err != nil {
+		message := strings.TrimPrefix(err.Error(), "source configuration validation failed: ")
+		v.report(file, node, SeverityError, DiagInvalidSource, id, "%s", message)
+	}
+	v.definitions[id] = append(v.definitions[id], sourceDefinition{file: file, config: &checked, node: node})
+
+	// Quotes
+	quotesNode := node.field("quotes")
+	levels := make([]string, 0, len(config.Quotes))
+	for level := range config.Quotes {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	for _, level := range levels {
+		levelNode := quotesNode.field(level)
+		for i, quote := range config.Quotes[level] {
+			quoteNode := levelNode.item(i)
+			if strings.TrimSpace(quote.Quote) == "" {
+				v.report(file, quoteNode, SeverityError, DiagEmptyQuote, id, "quote %d of %s in source %q has no text", i+1, level, id)
+				continue
+			}
+			if strings.TrimSpace(quote.Encouragement) == "" {
+				v.report(file, quoteNode, SeverityWarning, DiagMissingEncouragement, id, "quote %d of %s in source %q has no encouragement", i+1, level, id)
+			}
+		}
+	}
+
+	// Sources fetched from an API fill all levels themselves, and appended
+	// definitions add to an earlier one's levels
+	if len(sefariaRefs(config)) == 0 && config.APIEndpoint == "" && len(config.Quotes) > 0 && config.Merge != MergeAppend {
+		for _, level := range aeonLevels {
+			if len(config.Quotes[level]) == 0 {
+				at := quotesNode.field(level)
+				if at == nil {
+					at = quotesNode
+				}
+				v.report(file, at, SeverityWarning, DiagEmptyLevel, id,
+					"source %q has no %s quotes; quotes of another level are shown instead", id, level)
+			}
+		}
+	}
+}
+
+// validateCombined checks the sources of all files together: overrides,
+// and quote and icon conflicts among the sources in effect.
+func (v *sourcesValidator) validateCombined() {
+	builtIn := make(map[string]bool, len(BuiltInSourceIDs))
+	for _, id := range BuiltInSourceIDs {
+		builtIn[id] = true
+	}
+
+	// A file defining every built-in source is a copy of the default sources.json
+	defaults := make(map[*sourceFile]int)
+	for _, id := range BuiltInSourceIDs {
+		seen := make(map[*sourceFile]bool)
+		for _, def := range v.definitions[id] {
+			if !seen[def.file] {
+				seen[def.file] = true
+				defaults[def.file]++
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(v.definitions))
+	for id := range v.definitions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	icons := make(map[string]sourceDefinition)
+	var effective []sourceDefinition
+	for _, id := range ids {
+		definitions := v.definitions[id]
+		for i, current := range definitions {
+			var previous *sourceDefinition
+			if i > 0 {
+				if definitions[i-1].file == current.file {
+					continue // Reported as a duplicate key
+				}
+				previous = &definitions[i-1]
+			}
+			switch {
+			case builtIn[id] && defaults[current.file] < len(BuiltInSourceIDs):
+				message := fmt.Sprintf("overrides built-in source %q", id)
+				if current.config.Merge == MergeAppend {
+					message = fmt.Sprintf("adds quotes to built-in source %q", id)
+				}
+				if previous != nil {
+					message += fmt.Sprintf(" (defined at %s)", previous.file.where(previous.node))
+				}
+				v.report(current.file, current.node, SeverityWarning, DiagBuiltInOverride, id, "%s", message)
+			case previous != nil && current.config.Merge == MergeAppend:
+				v.report(current.file, current.node, SeverityInfo, DiagSourceOverride, id,
+					"adds quotes to source %q (defined at %s)", id, previous.file.where(previous.node))
+			case previous != nil:
+				v.report(current.file, current.node, SeverityInfo, DiagSourceOverride, id,
+					"overrides source %q (defined at %s)", id, previous.file.where(previous.node))
+			}
+		}
+		effective = append(effective, definitions[len(definitions)-1])
+	}
+
+	for _, def := range effective {
+		id := def.config.ID
+		if icon := def.config.Icon; icon != "" {
+			if other, exists := icons[icon]; exists {
+				v.report(def.file, def.node.field("icon"), SeverityWarning, DiagIconConflict, id,
+					"icon %s is also used by source %q (%s)", icon, other.config.ID, other.file.where(other.node))
+			} else {
+				icons[icon] = def
+			}
+		}
+
+		levels := make([]string, 0, len(def.config.Quotes))
+		for level := range def.config.Quotes {
+			levels = append(levels, level)
+		}
+		sort.Strings(levels)
+		for _, level := range levels {
+			for i, quote := range def.config.Quotes[level] {
+				node := def.node.field("quotes").field(level).item(i)
+				here := quoteLocation{sourceID: id, file: def.file, node: node}
+				if quote.ID != "" {
+					if first, exists := v.quoteIDs[quote.ID]; exists {
+						v.report(def.file, node, SeverityError, DiagDuplicateQuoteID, id,
+							"quote ID %q is already used in source %q (%s)", quote.ID, first.sourceID, first.file.where(first.node))
+					} else {
+						v.quoteIDs[quote.ID] = here
+					}
+				}
+				text := normalizeQuoteText(quote.Quote)
+				if text == "" {
+					continue
+				}
+				if first, exists := v.quoteTexts[text]; exists {
+					v.report(def.file, node, SeverityWarning, DiagDuplicateQuote, id,
+						"quote is a duplicate of one in source %q (%s)", first.sourceID, first.file.where(first.node))
+				} else {
+					v.quoteTexts[text] = here
+				}
+			}
+		}
+	}
+}
+
+// normalizeQuoteText reduces quote text to lowercase letters and digits, so
+// quotes differing only in case, punctuation or spacing compare equal.
+func normalizeQuoteText(text string) string {
+	return strings.Join(tokenize(text), " ")
+}
+
+// checkSchema reports values of the wrong JSON type and object fields that t
+// does not declare. It returns false if any type is wrong.
+func (v *sourcesValidator) checkSchema(file *sourceFile, node *jsonNode, t reflect.Type, path string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.kind == 'n' && node.null {
+		switch t.Kind() {
+		case reflect.Map, reflect.Slice, reflect.Interface:
+			return true
+		}
+	}
+	want := ""
+	valid := true
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		if node.kind != '{' {
+			want = "an object"
+			break
+		}
+		fields := jsonFields(t)
+		for _, key := range node.keys {
+			field, known := fields[key]
+			if !known {
+				message := fmt.Sprintf("unknown field %q in %s", key, describePath(path))
+				if suggestion := closestField(key, fields); suggestion != "" {
+					message += fmt.Sprintf("; did you mean %q?", suggestion)
+				}
+				v.reportAt(file, node.keyOffsets[key], SeverityWarning, DiagUnknownField, sourceIDOf(path), "%s", message)
+				continue
+			}
+			valid = v.checkSchema(file, node.fields[key], field, joinPath(path, key)) && valid
+		}
+		return valid
+	case reflect.Map:
+		if node.kind != '{' {
+			want = "an object"
+			break
+		}
+		for _, key := range node.keys {
+			valid = v.checkSchema(file, node.fields[key], t.Elem(), joinPath(path, key)) && valid
+		}
+		return valid
+	case reflect.Slice:
+		if node.kind != '[' {
+			want = "an array"
+			break
+		}
+		for i, item := range node.items {
+			valid = v.checkSchema(file, item, t.Elem(), fmt.Sprintf("%s[%d]", path, i)) && valid
+		}
+		return valid
+	case reflect.String:
+		if node.kind != 's' {
+			want = "a string"
+		}
+	case reflect.Bool:
+		if node.kind != 'b' {
+			want = "true or false"
+		}
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		if node.kind != 'n' || node.null {
+			want = "a number"
+		}
+	}
+	if want == "" {
+		return true
+	}
+	v.report(file, node, SeverityError, DiagType, sourceIDOf(path), "%s must be %s, not %s", describePath(path), want, node.describe())
+	return false
+}
+
+// jsonFields maps the JSON field names of struct type t to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // Unexported
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// closestField returns the known field closest to key if it is a likely typo.
+func closestField(key string, fields map[string]reflect.Type) string {
+	best, bestDistance := "", 3
+	for name := range fields {
+		if d := editDistance(strings.ToLower(key), name); d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	if bestDistance > 2 {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
+
+// joinPath appends a key to a dotted path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// describePath names a schema path for messages.
+func describePath(path string) string {
+	if path == "" {
+		return "the top level"
+	}
+	return path
+}
+
+// sourceIDOf returns the source ID of a "sources.<id>..." path.
+func sourceIDOf(path string) string {
+	if !strings.HasPrefix(path, "sources.") {
+		return ""
+	}
+	id := strings.TrimPrefix(path, "sources.")
+	if i := strings.IndexByte(id, '.'); i >= 0 {
+		id = id[:i]
+	}
+	return id
+}
+
+// jsonNode is a parsed JSON value with its position in the file.
+type jsonNode struct {
+	offset     int64 // Byte offset of the value
+	kind       byte  // '{' object, '[' array, 's' string, 'n' number or null, 'b' bool
+	null       bool
+	keys       []string // Object keys in order, without duplicates
+	keyOffsets map[string]int64
+	fields     map[string]*jsonNode // Object members; the last of duplicate keys wins
+	items      []*jsonNode
+}
+
+// field returns the member key of an object node, or nil.
+func (n *jsonNode) field(key string) *jsonNode {
+	if n == nil || n.fields == nil {
+		return nil
+	}
+	return n.fields[key]
+}
+
+// item returns element i of an array node, or nil.
+func (n *jsonNode) item(i int) *jsonNode {
+	if n == nil || i >= len(n.items) {
+		return nil
+	}
+	return n.items[i]
+}
+
+// describe names the node's JSON type for messages.
+func (n *jsonNode) describe() string {
+	switch {
+	case n.null:
+		return "null"
+	case n.kind == '{':
+		return "an object"
+	case n.kind == '[':
+		return "an array"
+	case n.kind == 's':
+		return "a string"
+	case n.kind == 'b':
+		return "a boolean"
+	default:
+		return "a number"
+	}
+}
+
+// jsonParser builds a jsonNode tree, recording duplicate object keys.
+type jsonParser struct {
+	dec        *json.Decoder
+	data       []byte
+	path       []string
+	duplicates []duplicateKey
+}
+
+// duplicateKey is an object key repeated in the same object.
+type duplicateKey struct {
+	key      string
+	offset   int64
+	sourceID string
+}
+
+// parse parses the whole document, which must be valid JSON.
+func (p *jsonParser) parse() (*jsonNode, error) {
+	return p.value()
+}
+
+// start returns the offset of the next token, skipping whitespace and the
+// separators the decoder has not consumed yet.
+func (p *jsonParser) start() int64 {
+	offset := p.dec.InputOffset()
+	for offset < int64(len(p.data)) {
+		c := p.data[offset]
+		if c != ',' && c != ':' && !unicode.IsSpace(rune(c)) {
+			break
+		}
+		offset++
+	}
+	return offset
+}
+
+// value parses one JSON value.
+func (p *jsonParser) value() (*jsonNode, error) {
+	node := &jsonNode{offset: p.start()}
+	token, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '{' {
+			node.kind = '{'
+			node.fields = make(map[string]*jsonNode)
+			node.keyOffsets = make(map[string]int64)
+			for p.dec.More() {
+				keyOffset := p.start()
+				keyToken, err := p.dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyToken.(string)
+				p.path = append(p.path, key)
+				child, err := p.value()
+				p.path = p.path[:len(p.path)-1]
+				if err != nil {
+					return nil, err
+				}
+				if _, exists := node.fields[key]; exists {
+					p.duplicates = append(p.duplicates, duplicateKey{key: key, offset: keyOffset, sourceID: sourceIDOf(strings.Join(append(p.path, key), "."))})
+				} else {
+					node.keys = append(node.keys, key)
+				}
+				node.fields[key] = child
+				node.keyOffsets[key] = keyOffset
+			}
+		} else {
+			node.kind = '['
+			for p.dec.More() {
+				p.path = append(p.path, "[]")
+				child, err := p.value()
+				p.path = p.path[:len(p.path)-1]
+				if err != nil {
+					return nil, err
+				}
+				node.items = append(node.items, child)
+			}
+		}
+		// Closing delimiter
+		if _, err := p.dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		node.kind = 's'
+	case bool:
+		node.kind = 'b'
+	case nil:
+		node.kind, node.null = 'n', true
+	default:
+		node.kind = 'n'
+	}
+	return node, nil
+}
@@ -0,0 +1,268 @@
+package wisdom
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// writeSourcesFile writes content to name in dir and returns its path.
+func writeSourcesFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// findDiagnostic returns the first diagnostic with code, or fails.
+func findDiagnostic(t *testing.T, diagnostics []Diagnostic, code string) Diagnostic {
+	t.Helper()
+	for _, d := range diagnostics {
+		if d.Code == code {
+			return d
+		}
+	}
+	t.Fatalf("no %s diagnostic in %v", code, diagnostics)
+	return Diagnostic{}
+}
+
+// fullLevels returns quotes for every aeon level.
+const fullLevels = `{
+      "chaos": [{"quote": "Q1", "source": "S", "encouragement": "E"}],
+      "lower_aeons": [{"quote": "Q2", "source": "S", "encouragement": "E"}],
+      "middle_aeons": [{"quote": "Q3", "source": "S", "encouragement": "E"}],
+      "upper_aeons": [{"quote": "Q4", "source": "S", "encouragement": "E"}],
+      "treasury": [{"quote": "Q5", "source": "S", "encouragement": "E"}]
+    }`
+
+func TestValidateSourceFiles_Valid(t *testing.T) {
+	path := writeSourcesFile(t, t.TempDir(), "sources.json", `{
+  "version": "1.0",
+  "sources": {
+    "team": {"name": "Team", "icon": "🧪", "quotes": `+fullLevels+`}
+  }
+}`)
+	if diagnostics := ValidateSourceFiles(path); len(diagnostics) != 0 {
+		t.Errorf("ValidateSourceFiles() = %v, want no diagnostics", diagnostics)
+	}
+}
+
+func TestValidateSourceFiles_SyntaxError(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		line, column int
+	}{
+		{"missing comma", "{\n  \"sources\": {\n    \"a\": {\"name\": \"A\"}\n    \"b\": {}\n  }\n}", 4, 5},
+		{"trailing comma", "{\n  \"sources\": {},\n}", 3, 1},
+		{"unexpected end", "{\n  \"sources\": {", 2, 15},
+		{"column counts characters", "{\"name\": \"שלום\" x}", 1, 17},
+		{"trailing data", "{}\n{}", 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSourcesFile(t, t.TempDir(), "sources.json", tt.content)
+			d := findDiagnostic(t, ValidateSourceFiles(path), DiagSyntax)
+			if d.Severity != SeverityError || d.Line != tt.line || d.Column != tt.column {
+				t.Errorf("diagnostic = %v, want an error at %d:%d", d, tt.line, tt.column)
+			}
+		})
+	}
+}
+
+func TestValidateSourceFiles_Schema(t *testing.T) {
+	path := writeSourcesFile(t, t.TempDir(), "sources.json", `{
+  "sources": {
+    "team": {
+      "name": "Team",
+      "langauge": "english",
+      "quotes": `+fullLevels+`
+    }
+  }
+}`)
+	d := findDiagnostic(t, ValidateSourceFiles(path), DiagUnknownField)
+	if d.Line != 5 || d.Column != 7 || d.Source != "team" || !strings.Contains(d.Message, `did you mean "language"?`) {
+		t.Errorf("unknown field diagnostic = %+v", d)
+	}
+
+	path = writeSourcesFile(t, t.TempDir(), "sources.json", `{
+  "sources": {
+    "team": {"name": "Team", "quotes": {"chaos": {"quote": "Q"}}}
+  }
+}`)
+	diagnostics := ValidateSourceFiles(path)
+	d = findDiagnostic(t, diagnostics, DiagType)
+	if d.Severity != SeverityError || d.Line != 3 || d.Column != 50 || !strings.Contains(d.Message, "must be an array, not an object") {
+		t.Errorf("type diagnostic = %+v", d)
+	}
+}
+
+func TestValidateSourceFiles_Quotes(t *testing.T) {
+	path := writeSourcesFile(t, t.TempDir(), "sources.json", `{
+  "sources": {
+    "team": {
+      "name": "Team",
+      "id": "other",
+      "quotes": {
+        "chaos": [
+          {"quote": "  ", "source": "S", "encouragement": "E"},
+          {"quote": "Q", "source": "S"}
+        ]
+      }
+    }
+  }
+}`)
+	diagnostics := ValidateSourceFiles(path)
+
+	if d := findDiagnostic(t, diagnostics, DiagIDMismatch); d.Line != 5 {
+		t.Errorf("id mismatch diagnostic = %v, want line 5", d)
+	}
+	if d := findDiagnostic(t, diagnostics, DiagEmptyQuote); d.Severity != SeverityError || d.Line != 8 || d.Column != 11 {
+		t.Errorf("empty quote diagnostic = %v, want an error at 8:11", d)
+	}
+	if d := findDiagnostic(t, diagnostics, DiagMissingEncouragement); d.Severity != SeverityWarning || d.Line != 9 {
+		t.Errorf("missing encouragement diagnostic = %v, want a warning at line 9", d)
+	}
+
+	var empty []string
+	for _, d := range diagnostics {
+		if d.Code == DiagEmptyLevel {
+			empty = append(empty, d.Message)
+		}
+	}
+	if len(empty) != 4 {
+		t.Errorf("empty level diagnostics = %v, want 4", empty)
+	}
+}
+
+func TestValidateSourceFiles_DuplicateKey(t *testing.T) {
+	path := writeSourcesFile(t, t.TempDir(), "sources.json", `{
+  "sources": {
+    "team": {"name": "Team", "quotes": `+fullLevels+`},
+    "team": {"name": "Team again", "quotes": `+fullLevels+`}
+  }
+}`)
+	d := findDiagnostic(t, ValidateSourceFiles(path), DiagDuplicateKey)
+	if d.Severity != SeverityError || d.Line != 10 || d.Column != 5 || d.Source != "team" {
+		t.Errorf("duplicate key diagnostic = %+v, want an error for team at 10:5", d)
+	}
+}
+
+func TestValidateSourceFiles_AcrossFiles(t *testing.T) {
+	dir := t.TempDir()
+	global := writeSourcesFile(t, dir, "global.json", `{
+  "sources": {
+    "team": {"name": "Team", "icon": "🧪", "quotes": `+fullLevels+`},
+    "stoic": {"name": "My Stoics", "quotes": {"chaos": [{"id": "q-1", "quote": "Keep calm.", "source": "S", "encouragement": "E"}]}}
+  }
+}`)
+	project := writeSourcesFile(t, dir, "project.json", `{
+  "sources": {
+    "team": {"name": "Project Team", "icon": "🧪", "quotes": `+fullLevels+`},
+    "lab": {"name": "Lab", "icon": "🧪", "quotes": {"chaos": [
+      {"id": "q-1", "quote": "keep CALM!", "source": "S", "encouragement": "E"}
+    ]}}
+  }
+}`)
+	diagnostics := ValidateSourceFiles(global, project)
+
+	if d := findDiagnostic(t, diagnostics, DiagBuiltInOverride); d.File != global || d.Source != "stoic" {
+		t.Errorf("built-in override diagnostic = %v", d)
+	}
+	if d := findDiagnostic(t, diagnostics, DiagSourceOverride); d.File != project || d.Source != "team" || !strings.Contains(d.Message, global+":3:13") {
+		t.Errorf("source override diagnostic = %v", d)
+	}
+	if d := findDiagnostic(t, diagnostics, DiagIconConflict); d.Source != "team" || !strings.Contains(d.Message, `"lab"`) {
+		t.Errorf("icon conflict diagnostic = %v", d)
+	}
+	if d := findDiagnostic(t, diagnostics, DiagDuplicateQuote); !strings.Contains(d.Message, `source "lab"`) {
+		t.Errorf("duplicate quote diagnostic = %v", d)
+	}
+	if d := findDiagnostic(t, diagnostics, DiagDuplicateQuoteID); d.Severity != SeverityError {
+		t.Errorf("duplicate quote ID diagnostic = %v", d)
+	}
+
+	// Overridden definitions are not compared: team's quotes appear in both files
+	for _, d := range diagnostics {
+		if d.Code == DiagDuplicateQuote && d.Source == "team" {
+			t.Errorf("quotes of an overridden definition reported as duplicates: %v", d)
+		}
+	}
+
+	// Diagnostics are ordered by file, then position
+	if !sort.SliceIsSorted(diagnostics, func(i, j int) bool {
+		if diagnostics[i].File != diagnostics[j].File {
+			return diagnostics[i].File == global
+		}
+		return diagnostics[i].Line < diagnostics[j].Line
+	}) {
+		t.Errorf("diagnostics not ordered: %v", diagnostics)
+	}
+}
+
+func TestValidateSourceFiles_MissingFile(t *testing.T) {
+	diagnostics := ValidateSourceFiles(filepath.Join(t.TempDir(), "missing.json"))
+	if len(diagnostics) != 1 || diagnostics[0].Severity != SeverityError || diagnostics[0].Line != 0 {
+		t.Errorf("ValidateSourceFiles() = %v, want one file-level error", diagnostics)
+	}
+}
+
+func TestValidateSourceFiles_DefaultSources(t *testing.T) {
+	for _, d := range ValidateSourceFiles("../../sources.json") {
+		if d.Severity == SeverityError || d.Code == DiagBuiltInOverride {
+			t.Errorf("default sources.json: %v", d)
+		}
+	}
+}
+
+func TestBuiltInSourceIDs(t *testing.T) {
+	data, err := os.ReadFile("../../sources.json")
+	if err != nil {
+		t.Fatalf("failed to read default sources.json: %v", err)
+	}
+	var sourcesConfig SourcesConfig
+	if err := json.Unmarshal(data, &sourcesConfig); err != nil {
+		t.Fatalf("failed to parse default sources.json: %v", err)
+	}
+	ids := make([]string, 0, len(sourcesConfig.Sources))
+	for id := range sourcesConfig.Sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	if strings.Join(ids, ",") != strings.Join(BuiltInSourceIDs, ",") {
+		t.Errorf("BuiltInSourceIDs = %v, default sources.json defines %v", BuiltInSourceIDs, ids)
+	}
+}
+
+func TestSourceLoader_ValidateSources(t *testing.T) {
+	root := t.TempDir()
+	explicit := writeSourcesFile(t, t.TempDir(), "extra.json", `{"sources": {"extra": {"name": "Extra", "quotes": `+fullLevels+`}}}`)
+	writeSourcesFile(t, root, "sources.json", `{"sources": {"extra": {"name": "Extra", "quotes": `+fullLevels+`}}}`)
+
+	loader := NewSourceLoader().WithProjectRoot(root).WithConfigPaths(explicit)
+	paths := loader.SourceFilePaths()
+	if len(paths) < 2 || paths[0] != explicit || paths[2] != filepath.Join(root, "sources.json") {
+		t.Errorf("SourceFilePaths() = %v, want the explicit path first, then the project root", paths)
+	}
+
+	d := findDiagnostic(t, loader.ValidateSources(), DiagSourceOverride)
+	if d.File != filepath.Join(root, "sources.json") {
+		t.Errorf("override diagnostic = %v, want it in the project file", d)
+	}
+}
+
+func TestValidateSourceFiles_Append(t *testing.T) {
+	dir := t.TempDir()
+	base := writeSourcesFile(t, dir, "base.json", `{"sources": {"team": {"name": "Team", "quotes": `+fullLevels+`}}}`)
+	overlay := writeSourcesFile(t, dir, "overlay.json", `{"merge": "append", "sources": {"team": {"quotes": {"chaos": [{"quote": "More", "source": "S", "encouragement": "E"}]}}}}`)
+
+	diagnostics := ValidateSourceFiles(base, overlay)
+	if len(diagnostics) != 1 || diagnostics[0].Code != DiagSourceOverride || !strings.Contains(diagnostics[0].Message, "adds quotes") {
+		t.Errorf("ValidateSourceFiles() = %v, want one source_override info for the appended quotes", diagnostics)
+	}
+}
@@ -4,20 +4,24 @@
 package wisdom
 
 import (
-	"fmt"
 	"hash/fnv"
-	"math/rand"
+	"sort"
 	"time"
 )
 
 // Quote represents a wisdom quote with metadata.
 // It contains the quote text, source information, and optional encouragement message.
 type Quote struct {
+	ID            string `json:"id,omitempty"` // Explicit in sources.json, or derived from the content (see QuoteID)
 	Quote         string `json:"quote"`
+	Translation   string `json:"translation,omitempty"` // Translation of Quote (e.g. English for a Hebrew original)
 	Source        string `json:"source"`
 	Encouragement string `json:"encouragement"`
 	WisdomSource  string `json:"wisdom_source,omitempty"`
 	WisdomIcon    string `json:"wisdom_icon,omitempty"`
+	// Classification is set on quotes placed on their aeon level by an
+	// AeonClassifier rather than the source configuration.
+	Classification *Classification `json:"classification,omitempty"`
 }
 
 // Source represents a wisdom source with quotes organized by aeon level.
@@ -27,23 +31,30 @@ type Source struct {
 	Icon        string             `json:"icon"`
 	Quotes      map[string][]Quote `json:"quotes"` // Key: aeon level (chaos, lower_aeons, etc.)
 	Description string             `json:"description,omitempty"`
+	Language    string             `json:"language,omitempty"` // "hebrew", "english", etc.
 }
 
-// GetQuote retrieves a quote for the given aeon level.
-// If no quotes exist for the specified level, it falls back to any available quotes.
-// Returns a default quote if no quotes are available in the source.
-func (s *Source) GetQuote(aeonLevel string) *Quote {
-	quotes, exists := s.Quotes[aeonLevel]
-	if !exists || len(quotes) == 0 {
-		// Fallback to any available quotes
-		for _, qs := range s.Quotes {
-			if len(qs) > 0 {
-				quotes = qs
-				break
-			}
-		}
+// Text returns the quote for display: the quote text followed by its
+// translation in parentheses, if it has one.
+func (q *Quote) Text() string {
+	if q.Translation == "" {
+		return q.Quote
 	}
+	return q.Quote + " (" + q.Translation + ")"
+}
+
+// GetQuote retrieves today's quote for the given aeon level, where today is
+// the local calendar date. See GetQuoteForDay.
+func (s *Source) GetQuote(aeonLevel string) *Quote {
+	return s.GetQuoteForDay(aeonLevel, DayBoundary{}.Key(time.Now()))
+}
 
+// GetQuoteForDay retrieves the quote for the given aeon level on day (YYYYMMDD,
+// see DayBoundary.Key). The same day always selects the same quote.
+// If no quotes exist for the specified level, it falls back to any available quotes.
+// Returns a default quote if no quotes are available in the source.
+func (s *Source) GetQuoteForDay(aeonLevel, day string) *Quote {
+	_, quotes := s.quotesForLevel(aeonLevel)
 	if len(quotes) == 0 {
 		return &Quote{
 			Quote:         "Silence is also wisdom.",
@@ -57,33 +68,45 @@ func (s *Source) GetQuote(aeonLevel string) *Quote {
 		return &quotes[0]
 	}
 
-	// Date-seeded random selection for daily consistency
-	// Uses same pattern as getRandomSourceLocked() in engine.go
-	now := time.Now()
-	dateStr := now.Format("20060102") // YYYYMMDD format
-
-	// Convert date string to int and add hash offset
-	var dateInt int64
-	if _, err := fmt.Sscanf(dateStr, "%d", &dateInt); err != nil {
-		// This should never fail since we just formatted the date, but handle it gracefully
-		dateInt = int64(now.Unix())
+	// Date-seeded selection for daily consistency. Each quote is ranked by a
+	// hash of the date and its ID, so adding, removing, or reordering other
+	// quotes only changes today's pick if a new quote ranks first.
+	selectedIndex := 0
+	var best uint64
+	for i := range quotes {
+		key := quotes[i].ID
+		if key == "" {
+			key = quoteContentHash(&quotes[i])
+		}
+		h := fnv.New64a()
+		h.Write([]byte("random_quote:" + day + ":" + key))
+		if rank := h.Sum64(); i == 0 || rank > best {
+			selectedIndex, best = i, rank
+		}
 	}
-
-	// Hash "random_quote" string for offset (different from "random_source" for source selection)
-	h := fnv.New32a()
-	h.Write([]byte("random_quote"))
-	hashOffset := int64(h.Sum32())
-
-	seed := dateInt + hashOffset
-
-	// Create seeded random generator
-	rng := rand.New(rand.NewSource(seed))
-
-	// Select random quote index
-	selectedIndex := rng.Intn(len(quotes))
 	return &quotes[selectedIndex]
 }
 
+// quotesForLevel returns the quotes for aeonLevel and the level they belong to.
+// If the level has no quotes, the first non-empty level (in sorted order) is
+// used instead. It returns no quotes if the source is empty.
+func (s *Source) quotesForLevel(aeonLevel string) (string, []Quote) {
+	if quotes := s.Quotes[aeonLevel]; len(quotes) > 0 {
+		return aeonLevel, quotes
+	}
+	levels := make([]string, 0, len(s.Quotes))
+	for level := range s.Quotes {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	for _, level := range levels {
+		if quotes := s.Quotes[level]; len(quotes) > 0 {
+			return level, quotes
+		}
+	}
+	return aeonLevel, nil
+}
+
 // Consultation represents an advisor consultation with full metadata.
 // It includes advisor information, quote, rationale, score context, and mode guidance.
 type Consultation struct {
@@ -100,7 +123,9 @@ type Consultation struct {
 	ConsultationMode string  `json:"consultation_mode"`
 	ModeIcon         string  `json:"mode_icon"`
 	ModeFrequency    string  `json:"mode_frequency"`
+	QuoteID          string  `json:"quote_id,omitempty"`
 	Quote            string  `json:"quote"`
+	QuoteTranslation string  `json:"quote_translation,omitempty"`
 	QuoteSource      string  `json:"quote_source"`
 	Encouragement    string  `json:"encouragement"`
 	Context          string  `json:"context,omitempty"`
@@ -0,0 +1,178 @@
+package wisdom
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Default polling settings of a Watcher.
+const (
+	DefaultWatchInterval = 2 * time.Second        // How often watched files are checked
+	DefaultWatchDebounce = 500 * time.Millisecond // How long files must stay unchanged before reloading
+)
+
+// ReloadEvent reports an automatic reload by a Watcher.
+type ReloadEvent struct {
+	Changed     []string     // Files created, modified or removed since the last reload, sorted
+	Err         error        // Non-nil if the reload was rejected; the engine keeps its previous state
+	Diagnostics []Diagnostic // Errors in sources files that caused the rejection
+}
+
+// Watcher reloads an engine when its sources, advisors, aeon overrides or
+// config files change. It polls modification times and sizes, which works on
+// every platform and filesystem, and waits for changes to settle before
+// reloading with Engine.ReloadIfValid.
+type Watcher struct {
+	engine   *Engine
+	interval time.Duration
+	debounce time.Duration
+	onReload func(ReloadEvent)
+}
+
+// fileState is what a Watcher compares to detect a change to a file.
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher creates a watcher for an initialized engine.
+func NewWatcher(engine *Engine) *Watcher {
+	return &Watcher{
+		engine:   engine,
+		interval: DefaultWatchInterval,
+		debounce: DefaultWatchDebounce,
+	}
+}
+
+// WithInterval sets how often watched files are checked.
+func (w *Watcher) WithInterval(interval time.Duration) *Watcher {
+	if interval > 0 {
+		w.interval = interval
+	}
+	return w
+}
+
+// WithDebounce sets how long watched files must stay unchanged before the
+// engine is reloaded, so that editors writing a file in several steps cause
+// a single reload.
+func (w *Watcher) WithDebounce(debounce time.Duration) *Watcher {
+	if debounce >= 0 {
+		w.debounce = debounce
+	}
+	return w
+}
+
+// OnReload sets a function called after each reload attempt.
+func (w *Watcher) OnReload(fn func(ReloadEvent)) *Watcher {
+	w.onReload = fn
+	return w
+}
+
+// Run watches the engine's files until ctx is done, then returns ctx.Err().
+func (w *Watcher) Run(ctx context.Context) error {
+	states := snapshotFiles(w.engine.WatchedPaths())
+	changed := make(map[string]bool)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	settle := time.NewTimer(w.debounce)
+	settle.Stop()
+	defer settle.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-ticker.C:
+			current := snapshotFiles(w.engine.WatchedPaths())
+			paths := changedFiles(states, current)
+			states = current
+			if len(paths) == 0 {
+				continue
+			}
+			for _, path := range paths {
+				changed[path] = true
+			}
+			// Restart the debounce period on every change
+			settle.Stop()
+			select {
+			case <-settle.C:
+			default:
+			}
+			settle.Reset(w.debounce)
+
+		case <-settle.C:
+			event := ReloadEvent{Changed: make([]string, 0, len(changed))}
+			for path := range changed {
+				event.Changed = append(event.Changed, path)
+			}
+			sort.Strings(event.Changed)
+			changed = make(map[string]bool)
+
+			event.Diagnostics, event.Err = w.engine.ReloadIfValid()
+			if w.onReload != nil {
+				w.onReload(event)
+			}
+		}
+	}
+}
+
+// WatchedPaths returns the absolute paths of the files the engine is loaded
+// from, whether or not they exist: sources.json, advisors.json and
+// aeon_overrides.json locations, and the config file unless the config was
+// provided with WithConfig.
+func (e *Engine) WatchedPaths() []string {
+	e.mu.RLock()
+	var paths []string
+	if e.loader != nil {
+		paths = append(paths, e.loader.SourceFilePaths()...)
+		paths = append(paths, e.loader.AdvisorConfigPaths()...)
+		paths = append(paths, e.loader.AeonOverridePaths()...)
+	}
+	if !e.configSet && e.config != nil {
+		paths = append(paths, e.config.Path())
+	}
+	e.mu.RUnlock()
+
+	seen := make(map[string]bool, len(paths))
+	result := make([]string, 0, len(paths))
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil || seen[abs] {
+			continue
+		}
+		seen[abs] = true
+		result = append(result, abs)
+	}
+	return result
+}
+
+// snapshotFiles records the state of each file.
+func snapshotFiles(paths []string) map[string]fileState {
+	states := make(map[string]fileState, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			states[path] = fileState{exists: true, modTime: info.ModTime(), size: info.Size()}
+		} else {
+			states[path] = fileState{}
+		}
+	}
+	return states
+}
+
+// changedFiles returns the files whose state differs between two snapshots.
+// Files only in current (newly watched paths) are reported if they exist.
+func changedFiles(previous, current map[string]fileState) []string {
+	var paths []string
+	for path, state := range current {
+		if state != previous[path] {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,130 @@
+package wisdom
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+)
+
+// startWatcher initializes an engine for a project and watches it, returning
+// the project's sources file and the reload events.
+func startWatcher(t *testing.T, debounce time.Duration) (*Engine, string, <-chan ReloadEvent) {
+	t.Helper()
+	loader := newProjectLoader(t, "watched")
+	engine := NewEngine().WithLoader(loader).WithConfig(config.NewConfig())
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	events := make(chan ReloadEvent, 10)
+	watcher := NewWatcher(engine).
+		WithInterval(10 * time.Millisecond).
+		WithDebounce(debounce).
+		OnReload(func(event ReloadEvent) { events <- event })
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- watcher.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	time.Sleep(100 * time.Millisecond) // Let Run record the files' initial state
+
+	return engine, filepath.Join(loader.projectRoot, ".wisdom", "sources.json"), events
+}
+
+// nextEvent waits for a reload event.
+func nextEvent(t *testing.T, events <-chan ReloadEvent) ReloadEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload event")
+		return ReloadEvent{}
+	}
+}
+
+func TestWatcher_ReloadsChangedSources(t *testing.T) {
+	engine, path, events := startWatcher(t, 0)
+
+	writeSourcesFile(t, filepath.Dir(path), "sources.json",
+		`{"sources": {"watched": {"name": "Watched", "quotes": {"chaos": [{"quote": "Edited", "source": "S"}]}}}}`)
+	event := nextEvent(t, events)
+	if event.Err != nil || len(event.Changed) != 1 || event.Changed[0] != path {
+		t.Fatalf("event = %+v, want a successful reload of %s", event, path)
+	}
+	if quote, err := engine.GetWisdom(10, "watched"); err != nil || quote.Quote != "Edited" {
+		t.Errorf("GetWisdom() = %v, %v; want the edited quote", quote, err)
+	}
+}
+
+func TestWatcher_KeepsSourcesOnInvalidChange(t *testing.T) {
+	engine, path, events := startWatcher(t, 0)
+
+	writeSourcesFile(t, filepath.Dir(path), "sources.json", `{"sources": {"watched": {"name": "Watched", "quotes": {`)
+	event := nextEvent(t, events)
+	if event.Err == nil || len(event.Diagnostics) == 0 || event.Diagnostics[0].Code != DiagSyntax {
+		t.Fatalf("event = %+v, want a rejected reload with a syntax error", event)
+	}
+	if quote, err := engine.GetWisdom(10, "watched"); err != nil || quote.Quote != "From watched" {
+		t.Errorf("GetWisdom() = %v, %v; want the previous quote", quote, err)
+	}
+
+	// Fixing the file reloads it
+	writeSourcesFile(t, filepath.Dir(path), "sources.json",
+		`{"sources": {"watched": {"name": "Watched", "quotes": {"chaos": [{"quote": "Fixed", "source": "S"}]}}}}`)
+	if event := nextEvent(t, events); event.Err != nil {
+		t.Fatalf("event = %+v, want a successful reload", event)
+	}
+	if quote, _ := engine.GetWisdom(10, "watched"); quote == nil || quote.Quote != "Fixed" {
+		t.Errorf("GetWisdom() = %v, want the fixed quote", quote)
+	}
+}
+
+func TestWatcher_Debounce(t *testing.T) {
+	engine, path, events := startWatcher(t, 300*time.Millisecond)
+
+	// Each write restarts the debounce period
+	for i := 1; i <= 3; i++ {
+		writeSourcesFile(t, filepath.Dir(path), "sources.json",
+			`{"sources": {"watched": {"name": "Watched", "quotes": {"chaos": [{"quote": "Edit `+strings.Repeat("!", i)+`", "source": "S"}]}}}}`)
+		time.Sleep(50 * time.Millisecond)
+	}
+	if event := nextEvent(t, events); event.Err != nil {
+		t.Fatalf("event = %+v, want a successful reload", event)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected second reload: %+v", event)
+	case <-time.After(500 * time.Millisecond):
+	}
+	if quote, _ := engine.GetWisdom(10, "watched"); quote == nil || quote.Quote != "Edit !!!" {
+		t.Errorf("GetWisdom() = %v, want the last edit", quote)
+	}
+}
+
+func TestEngine_WatchedPaths(t *testing.T) {
+	loader := newProjectLoader(t, "watched")
+	engine := NewEngine().WithLoader(loader)
+	paths := strings.Join(engine.WatchedPaths(), "\n")
+	for _, want := range []string{
+		filepath.Join(loader.projectRoot, ".wisdom", "sources.json"),
+		filepath.Join(loader.projectRoot, ".wisdom", "advisors.json"),
+		filepath.Join(loader.projectRoot, ".wisdom", "aeon_overrides.json"),
+		engine.GetConfig().Path(),
+	} {
+		if abs, _ := filepath.Abs(want); !strings.Contains(paths, abs) {
+			t.Errorf("WatchedPaths() = %s, missing %s", paths, abs)
+		}
+	}
+
+	engine = NewEngine().WithLoader(loader).WithConfig(config.NewConfig())
+	if abs, _ := filepath.Abs(engine.GetConfig().Path()); strings.Contains(strings.Join(engine.WatchedPaths(), "\n"), abs) {
+		t.Error("WatchedPaths() includes the config file of a config provided with WithConfig")
+	}
+}
@@ -0,0 +1,365 @@
+package devwisdom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/config"
+	"github.com/davidl71/devwisdom-go/internal/logging"
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// defaultBriefingDays is the consultation log history compared for briefing trends.
+const defaultBriefingDays = 7
+
+// Client is the entry point to devwisdom for other Go programs.
+// It is safe for concurrent use by multiple goroutines.
+type Client struct {
+	engine     *wisdom.Engine
+	consultLog *logging.ConsultationLogger
+	clock      Clock
+	logger     Logger
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// New creates a Client, loading wisdom sources according to opts.
+func New(opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	loader := wisdom.NewSourceLoader().
+		WithConfigPaths(
+			"sources.json",
+			"wisdom/sources.json",
+			".wisdom/sources.json",
+		).
+		WithConfigPaths(o.configPaths...)
+	if o.projectRoot != "" {
+		loader.WithProjectRoot(o.projectRoot)
+	}
+	if o.cacheTTL > 0 {
+		loader.WithCacheTTL(o.cacheTTL)
+	}
+	if o.cacheMaxAge > 0 {
+		loader.WithCacheMaxAge(o.cacheMaxAge)
+	}
+	if o.cacheDisabled {
+		loader.WithCacheEnabled(false)
+	}
+	if o.httpTimeout > 0 {
+		loader.WithHTTPTimeout(o.httpTimeout)
+	}
+
+	engine := wisdom.NewEngine().WithLoader(loader).WithClock(o.clock).
+		WithHistoryDir(o.historyDir, o.historyUser)
+	if o.rotation != "" || o.daySet {
+		cfg := config.NewConfig()
+		if err := cfg.Load(); err != nil {
+			return nil, wrapErr("new", err)
+		}
+		if o.rotation != "" {
+			mode, err := wisdom.ParseRotation(o.rotation)
+			if err != nil {
+				return nil, wrapErr("new", fmt.Errorf("%w: %v", ErrInvalidRequest, err))
+			}
+			cfg.Rotation = mode
+		}
+		if o.daySet {
+			if _, err := wisdom.NewDayBoundary(o.timezone, o.dayStartHour); err != nil {
+				return nil, wrapErr("new", fmt.Errorf("%w: %v", ErrInvalidRequest, err))
+			}
+			cfg.Timezone, cfg.DayStartHour = o.timezone, o.dayStartHour
+		}
+		engine.WithConfig(cfg)
+	}
+
+	// Open the log before initializing the engine, which starts background
+	// source fetches that cannot be stopped if the log then fails to open
+	var consultLog *logging.ConsultationLogger
+	if o.consultLogDir != "" {
+		var err error
+		if consultLog, err = logging.NewConsultationLogger(o.consultLogDir); err != nil {
+			return nil, wrapErr("new", err)
+		}
+	}
+	if err := engine.Initialize(); err != nil {
+		if consultLog != nil {
+			consultLog.Close()
+		}
+		return nil, wrapErr("new", err)
+	}
+
+	client := &Client{
+		engine: engine,
+		clock:  o.clock,
+		logger: o.logger,
+	}
+	if consultLog != nil {
+		client.consultLog = consultLog.WithClock(o.clock, engine.DayBoundary())
+	}
+	return client, nil
+}
+
+// Close releases resources held by the client, such as the consultation log file.
+// Calling Close more than once is safe.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	if c.consultLog != nil {
+		return wrapErr("close", c.consultLog.Close())
+	}
+	return nil
+}
+
+// Quote returns a quote from source for the given project health score (0-100).
+// If source is empty, the configured default source (EXARP_WISDOM_SOURCE or
+// .exarp_wisdom_config) is used. If source is "random", or the default source is
+// unavailable, a date-seeded random source is used, so the same source is
+// returned for the whole day.
+func (c *Client) Quote(ctx context.Context, score float64, source string) (*Quote, error) {
+	if err := c.begin(ctx); err != nil {
+		return nil, wrapErr("quote", err)
+	}
+	defer c.mu.RUnlock()
+
+	if err := validateScore(score); err != nil {
+		return nil, wrapErr("quote", err)
+	}
+	quote, err := c.engine.GetWisdom(score, source)
+	if err != nil {
+		return nil, wrapErr("quote", err)
+	}
+	return quoteFromInternal(quote), nil
+}
+
+// QuoteByID returns the quote with the given stable ID (see Quote.ID).
+// It returns an error wrapping ErrUnknownQuote if no loaded quote has the ID.
+func (c *Client) QuoteByID(ctx context.Context, id string) (*Quote, error) {
+	if err := c.begin(ctx); err != nil {
+		return nil, wrapErr("quote", err)
+	}
+	defer c.mu.RUnlock()
+
+	quote, err := c.engine.GetQuoteByID(id)
+	if err != nil {
+		return nil, wrapErr("quote", err)
+	}
+	return quoteFromInternal(quote), nil
+}
+
+// Consult consults the advisor for req.Metric, req.Tool, or req.Stage and
+// returns the advisor's quote for req.Score. If consultation logging is
+// enabled, the consultation is appended to the log.
+func (c *Client) Consult(ctx context.Context, req ConsultRequest) (*Consultation, error) {
+	if err := c.begin(ctx); err != nil {
+		return nil, wrapErr("consult", err)
+	}
+	defer c.mu.RUnlock()
+
+	if err := validateScore(req.Score); err != nil {
+		return nil, wrapErr("consult", err)
+	}
+
+	advisorInfo, err := c.advisorFor(req.Metric, req.Tool, req.Stage)
+	if err != nil {
+		return nil, wrapErr("consult", err)
+	}
+
+	// Fall back to the configured default source if the advisor's source is
+	// unavailable (e.g. excluded by the Hebrew settings), as the server does
+	quote, err := c.engine.GetWisdom(req.Score, advisorInfo.Advisor)
+	if err != nil {
+		quote, err = c.engine.GetWisdom(req.Score, "")
+	}
+	if err != nil {
+		return nil, wrapErr("consult", err)
+	}
+
+	mode := wisdom.GetConsultationMode(req.Score)
+	consultation := &wisdom.Consultation{
+		Timestamp:        c.clock.Now().Format(time.RFC3339),
+		ConsultationType: "advisor",
+		Advisor:          advisorInfo.Advisor,
+		AdvisorIcon:      advisorInfo.Icon,
+		AdvisorName:      advisorInfo.Advisor,
+		Rationale:        advisorInfo.Rationale,
+		Metric:           req.Metric,
+		Tool:             req.Tool,
+		Stage:            req.Stage,
+		ScoreAtTime:      req.Score,
+		ConsultationMode: mode.Name,
+		ModeIcon:         mode.Icon,
+		ModeFrequency:    mode.Frequency,
+		ModeGuidance:     mode.Description,
+		QuoteID:          quote.ID,
+		Quote:            quote.Quote,
+		QuoteTranslation: quote.Translation,
+		QuoteSource:      quote.Source,
+		Encouragement:    quote.Encouragement,
+		Context:          req.Context,
+	}
+
+	if c.consultLog != nil {
+		if err := c.consultLog.Log(consultation); err != nil {
+			// Logging failure is non-fatal
+			c.logger.Printf("devwisdom: failed to log consultation: %v", err)
+		}
+	}
+
+	return consultationFromInternal(consultation), nil
+}
+
+// Briefing builds a daily briefing with advice for the weakest metrics in req.MetricScores.
+// If consultation logging is enabled, each entry reports the trend against the
+// metric's previous advisor consultation in the last req.Days days.
+// Briefings themselves are not logged.
+func (c *Client) Briefing(ctx context.Context, req BriefingRequest) (*Briefing, error) {
+	if err := c.begin(ctx); err != nil {
+		return nil, wrapErr("briefing", err)
+	}
+	defer c.mu.RUnlock()
+
+	if err := validateScore(req.Score); err != nil {
+		return nil, wrapErr("briefing", err)
+	}
+	if len(req.MetricScores) == 0 {
+		return nil, wrapErr("briefing", fmt.Errorf("%w: at least one metric score is required", ErrInvalidRequest))
+	}
+	for metric, score := range req.MetricScores {
+		if err := validateScore(score); err != nil {
+			return nil, wrapErr("briefing", fmt.Errorf("metric %q: %w", metric, err))
+		}
+	}
+
+	days := req.Days
+	if days <= 0 {
+		days = defaultBriefingDays
+	}
+
+	// Trends compare against previous consultations; an unreadable log only means no trends
+	var history []*wisdom.Consultation
+	if c.consultLog != nil {
+		var err error
+		if history, err = c.consultLog.GetLogs(days); err != nil {
+			c.logger.Printf("devwisdom: failed to read consultation log: %v", err)
+		}
+	}
+
+	entries := c.engine.BuildBriefing(req.MetricScores, req.Limit, history)
+	if err := ctx.Err(); err != nil {
+		return nil, wrapErr("briefing", err)
+	}
+	now := c.clock.Now()
+
+	briefing := &Briefing{
+		Date:    c.engine.DayBoundary().Date(now).Format("2006-01-02"),
+		Score:   req.Score,
+		Mode:    ConsultationMode(req.Score),
+		Entries: make([]BriefingEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		briefing.Entries = append(briefing.Entries, briefingEntryFromInternal(entry))
+	}
+	return briefing, nil
+}
+
+// Sources returns the loaded wisdom sources sorted by ID.
+func (c *Client) Sources(ctx context.Context) ([]SourceInfo, error) {
+	if err := c.begin(ctx); err != nil {
+		return nil, wrapErr("sources", err)
+	}
+	defer c.mu.RUnlock()
+
+	ids := c.engine.ListSources()
+	sort.Strings(ids)
+
+	sources := make([]SourceInfo, 0, len(ids))
+	for _, id := range ids {
+		source, found := c.engine.GetSource(id)
+		if !found {
+			continue
+		}
+		sources = append(sources, SourceInfo{
+			ID:          id,
+			Name:        source.Name,
+			Icon:        source.Icon,
+			Description: source.Description,
+		})
+	}
+	return sources, nil
+}
+
+// ValidateSources checks the sources.json files the client loads from (its
+// config paths and the default locations) and returns the problems found,
+// ordered by file and position.
+func (c *Client) ValidateSources(ctx context.Context) ([]Diagnostic, error) {
+	if err := c.begin(ctx); err != nil {
+		return nil, wrapErr("validate sources", err)
+	}
+	defer c.mu.RUnlock()
+
+	return diagnosticsFromInternal(c.engine.GetLoader().ValidateSources()), nil
+}
+
+// ValidateSourceFiles checks sources.json files, given in load order (later
+// files override sources of earlier ones), without loading them: JSON syntax,
+// unknown fields, empty quotes, missing encouragements, empty aeon levels,
+// duplicate quotes, icon and ID conflicts, and overridden built-in sources.
+func ValidateSourceFiles(paths ...string) []Diagnostic {
+	return diagnosticsFromInternal(wisdom.ValidateSourceFiles(paths...))
+}
+
+// begin checks ctx and the closed state. On success it returns with c.mu
+// read-locked; the caller must call c.mu.RUnlock.
+func (c *Client) begin(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	if c.closed {
+		c.mu.RUnlock()
+		return ErrClosed
+	}
+	return nil
+}
+
+// advisorFor resolves the advisor for a metric, tool, or stage (checked in that order).
+func (c *Client) advisorFor(metric, tool, stage string) (*wisdom.AdvisorInfo, error) {
+	advisors := c.engine.GetAdvisors()
+	switch {
+	case metric != "":
+		return advisors.GetAdvisorForMetric(metric)
+	case tool != "":
+		return advisors.GetAdvisorForTool(tool)
+	case stage != "":
+		return advisors.GetAdvisorForStage(stage)
+	default:
+		return &wisdom.AdvisorInfo{
+			Advisor:   "pistis_sophia",
+			Icon:      "📜",
+			Rationale: "Default wisdom advisor",
+		}, nil
+	}
+}
+
+// validateScore reports ErrInvalidRequest for scores outside 0-100.
+func validateScore(score float64) error {
+	if score < 0 || score > 100 {
+		return fmt.Errorf("%w: score %.1f is outside the range 0-100", ErrInvalidRequest, score)
+	}
+	return nil
+}
@@ -0,0 +1,362 @@
+package devwisdom
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSourcesJSON = `{
+  "version": "1.0",
+  "sources": {
+    "bofh": {
+      "name": "BOFH",
+      "icon": "😈",
+      "quotes": {
+        "chaos": [{"quote": "It's not a bug.", "source": "BOFH", "encouragement": "Ship it."}],
+        "middle_aeons": [{"quote": "Have you tried turning it off?", "source": "BOFH", "encouragement": "Reboot."}]
+      }
+    },
+    "stoic": {
+      "name": "Stoics",
+      "icon": "🏛️",
+      "quotes": {
+        "lower_aeons": [{"quote": "The obstacle is the way.", "source": "Marcus Aurelius", "encouragement": "Persist."}]
+      }
+    }
+  }
+}`
+
+func newTestClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+
+	root := t.TempDir()
+	wisdomDir := filepath.Join(root, ".wisdom")
+	if err := os.MkdirAll(wisdomDir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(wisdomDir, "sources.json"), []byte(testSourcesJSON), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	client, err := New(append([]Option{WithProjectRoot(root)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestClient_Quote(t *testing.T) {
+	client := newTestClient(t)
+
+	quote, err := client.Quote(context.Background(), 10, "bofh")
+	if err != nil {
+		t.Fatalf("Quote failed: %v", err)
+	}
+	if quote.Text != "It's not a bug." {
+		t.Errorf("Quote.Text = %q, want chaos-level quote", quote.Text)
+	}
+}
+
+func TestClient_Quote_Errors(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := client.Quote(ctx, 50, "nonexistent"); !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("Quote(unknown source) error = %v, want ErrUnknownSource", err)
+	}
+	if _, err := client.Quote(ctx, 150, "bofh"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Quote(score 150) error = %v, want ErrInvalidRequest", err)
+	}
+
+	var opErr *Error
+	if _, err := client.Quote(ctx, -1, "bofh"); !errors.As(err, &opErr) || opErr.Op != "quote" {
+		t.Errorf("Quote error = %v, want *Error with Op \"quote\"", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := client.Quote(cancelled, 50, "bofh"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Quote(cancelled ctx) error = %v, want context.Canceled", err)
+	}
+}
+
+func TestNew_ConsultationLogError(t *testing.T) {
+	// A file where the log directory should be
+	logDir := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(logDir, nil, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	var opErr *Error
+	if _, err := New(WithConsultationLog(logDir)); !errors.As(err, &opErr) || opErr.Op != "new" {
+		t.Errorf("New error = %v, want *Error with Op \"new\"", err)
+	}
+}
+
+func TestClient_Closed(t *testing.T) {
+	client := newTestClient(t)
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("second Close failed: %v", err)
+	}
+
+	if _, err := client.Quote(context.Background(), 50, "bofh"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Quote after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestClient_Consult(t *testing.T) {
+	logDir := t.TempDir()
+	fixed := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	client := newTestClient(t,
+		WithClock(ClockFunc(func() time.Time { return fixed })),
+		WithConsultationLog(logDir),
+	)
+
+	consultation, err := client.Consult(context.Background(), ConsultRequest{
+		Metric:  "security",
+		Score:   20,
+		Context: "pre-release audit",
+	})
+	if err != nil {
+		t.Fatalf("Consult failed: %v", err)
+	}
+
+	if consultation.Advisor != "bofh" {
+		t.Errorf("Advisor = %q, want bofh", consultation.Advisor)
+	}
+	if consultation.Timestamp != fixed.Format(time.RFC3339) {
+		t.Errorf("Timestamp = %q, want %q", consultation.Timestamp, fixed.Format(time.RFC3339))
+	}
+	if consultation.ConsultationMode != "chaos" {
+		t.Errorf("ConsultationMode = %q, want chaos", consultation.ConsultationMode)
+	}
+
+	info, err := os.Stat(filepath.Join(logDir, "consultations.jsonl"))
+	if err != nil {
+		t.Fatalf("consultation log not written: %v", err)
+	}
+	if info.Size() == 0 {
+		t.Error("consultation log is empty")
+	}
+}
+
+func TestClient_Consult_FilteredSource(t *testing.T) {
+	t.Setenv("EXARP_WISDOM_HEBREW", "0")
+	t.Setenv("EXARP_WISDOM_HEBREW_ONLY", "0")
+	client := newTestClient(t)
+
+	// The ethics advisor's source is Hebrew, so another source is used
+	consultation, err := client.Consult(context.Background(), ConsultRequest{Metric: "ethics", Score: 50})
+	if err != nil {
+		t.Fatalf("Consult with a filtered advisor source failed: %v", err)
+	}
+	if consultation.Quote == "" {
+		t.Error("Consult returned no quote")
+	}
+}
+
+func TestClient_Consult_UnknownAdvisor(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.Consult(context.Background(), ConsultRequest{Metric: "nonexistent", Score: 50})
+	if !errors.Is(err, ErrUnknownAdvisor) {
+		t.Errorf("Consult error = %v, want ErrUnknownAdvisor", err)
+	}
+}
+
+func TestClient_Briefing(t *testing.T) {
+	fixed := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	client := newTestClient(t, WithClock(ClockFunc(func() time.Time { return fixed })))
+
+	briefing, err := client.Briefing(context.Background(), BriefingRequest{
+		Score: 45,
+		MetricScores: map[string]float64{
+			"security":    20,
+			"testing":     35,
+			"nonexistent": 5,
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		t.Fatalf("Briefing failed: %v", err)
+	}
+
+	if briefing.Date != "2025-03-14" {
+		t.Errorf("Date = %q, want 2025-03-14", briefing.Date)
+	}
+	if briefing.Mode.Name != "building" {
+		t.Errorf("Mode = %q, want building", briefing.Mode.Name)
+	}
+	if len(briefing.Entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(briefing.Entries))
+	}
+	if briefing.Entries[0].Metric != "security" {
+		t.Errorf("weakest metric = %q, want security (unmapped metrics are skipped)", briefing.Entries[0].Metric)
+	}
+
+	if _, err := client.Briefing(context.Background(), BriefingRequest{Score: 50}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Briefing without metrics error = %v, want ErrInvalidRequest", err)
+	}
+}
+
+func TestClient_Briefing_Trends(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	client := newTestClient(t,
+		WithClock(ClockFunc(func() time.Time { return now })),
+		WithConsultationLog(t.TempDir()),
+	)
+	req := BriefingRequest{Score: 45, MetricScores: map[string]float64{"security": 20}}
+
+	briefing, err := client.Briefing(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Briefing failed: %v", err)
+	}
+	if len(briefing.Entries) != 1 || briefing.Entries[0].Trend != nil || briefing.Entries[0].Quote.WisdomSource == "" {
+		t.Fatalf("first briefing = %+v, want one entry with its source and no trend", briefing.Entries)
+	}
+
+	// Briefings are not logged; the next one compares against a consultation
+	if _, err := client.Consult(context.Background(), ConsultRequest{Metric: "security", Score: 20}); err != nil {
+		t.Fatalf("Consult failed: %v", err)
+	}
+	now = now.Add(24 * time.Hour)
+	req.MetricScores["security"] = 35
+	briefing, err = client.Briefing(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Briefing failed: %v", err)
+	}
+	trend := briefing.Entries[0].Trend
+	if trend == nil || trend.PreviousScore != 20 || trend.Change != 15 || trend.Direction != "improving" {
+		t.Errorf("trend = %+v, want improving by 15 from 20", trend)
+	}
+}
+
+func TestClient_Sources(t *testing.T) {
+	client := newTestClient(t)
+
+	sources, err := client.Sources(context.Background())
+	if err != nil {
+		t.Fatalf("Sources failed: %v", err)
+	}
+
+	found := false
+	for i, src := range sources {
+		if i > 0 && sources[i-1].ID > src.ID {
+			t.Errorf("sources not sorted: %q before %q", sources[i-1].ID, src.ID)
+		}
+		if src.ID == "stoic" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Sources did not include project source \"stoic\"")
+	}
+}
+
+func TestClient_ValidateSources(t *testing.T) {
+	client := newTestClient(t)
+
+	diagnostics, err := client.ValidateSources(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateSources failed: %v", err)
+	}
+
+	// The test sources redefine built-in sources, some without all aeon levels
+	codes := map[string]bool{}
+	for _, d := range diagnostics {
+		if strings.HasSuffix(d.File, filepath.Join(".wisdom", "sources.json")) {
+			codes[d.Code] = true
+		}
+		if d.Severity == "error" {
+			t.Errorf("unexpected error: %v", d)
+		}
+	}
+	if !codes["builtin_override"] || !codes["empty_level"] {
+		t.Errorf("ValidateSources() = %v, want builtin_override and empty_level warnings", diagnostics)
+	}
+}
+
+func TestValidateSourceFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sources.json")
+	if err := os.WriteFile(path, []byte("{\n  \"sources\": {\"team\": {\"name\": \"Team\", \"qoutes\": {}}}\n}"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	// The misspelled quotes leave the source without quotes
+	diagnostics := ValidateSourceFiles(path)
+	if len(diagnostics) != 2 || diagnostics[0].Code != "invalid_source" {
+		t.Fatalf("ValidateSourceFiles() = %v, want invalid_source and unknown_field", diagnostics)
+	}
+	want := path + `:2:40: warning: unknown field "qoutes" in sources.team; did you mean "quotes"? (unknown_field)`
+	if got := diagnostics[1].String(); got != want {
+		t.Errorf("diagnostic = %q, want %q", got, want)
+	}
+}
+
+func TestClient_QuoteRotation(t *testing.T) {
+	historyDir := t.TempDir()
+	client := newTestClient(t, WithQuoteRotation(RotationCycle), WithHistoryDir(historyDir), WithHistoryUser("tester"))
+
+	// bofh has one middle_aeons quote; rotation still returns it on every call
+	for i := 0; i < 2; i++ {
+		quote, err := client.Quote(context.Background(), 50, "bofh")
+		if err != nil {
+			t.Fatalf("Quote failed: %v", err)
+		}
+		if quote.Text != "Have you tried turning it off?" {
+			t.Errorf("Quote.Text = %q, want middle_aeons quote", quote.Text)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(historyDir, "quote_history_tester.json")); err != nil {
+		t.Errorf("rotation history not written: %v", err)
+	}
+
+	if _, err := New(WithQuoteRotation("weekly")); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("New with unknown rotation error = %v, want ErrInvalidRequest", err)
+	}
+
+	// Daily rotation keeps no history
+	dailyDir := t.TempDir()
+	client = newTestClient(t, WithQuoteRotation(RotationDaily), WithHistoryDir(dailyDir))
+	if _, err := client.Quote(context.Background(), 50, "bofh"); err != nil {
+		t.Fatalf("Quote failed: %v", err)
+	}
+	if entries, err := os.ReadDir(dailyDir); err != nil || len(entries) != 0 {
+		t.Errorf("history dir = %v, %v; want it untouched by daily rotation", entries, err)
+	}
+}
+
+func TestClient_DayBoundary(t *testing.T) {
+	// 03:00 UTC is still the previous day when days start at 06:00 UTC
+	fixed := time.Date(2025, 3, 14, 3, 0, 0, 0, time.UTC)
+	client := newTestClient(t,
+		WithClock(ClockFunc(func() time.Time { return fixed })),
+		WithDayBoundary("UTC", 6),
+	)
+
+	briefing, err := client.Briefing(context.Background(), BriefingRequest{
+		Score:        45,
+		MetricScores: map[string]float64{"security": 20},
+	})
+	if err != nil {
+		t.Fatalf("Briefing failed: %v", err)
+	}
+	if briefing.Date != "2025-03-13" {
+		t.Errorf("Date = %q, want 2025-03-13", briefing.Date)
+	}
+
+	if _, err := New(WithDayBoundary("Not/AZone", 0)); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("New with invalid time zone error = %v, want ErrInvalidRequest", err)
+	}
+	if _, err := New(WithDayBoundary("UTC", 24)); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("New with start hour 24 error = %v, want ErrInvalidRequest", err)
+	}
+}
@@ -0,0 +1,30 @@
+// Package devwisdom is the public, importable API for the devwisdom wisdom engine.
+//
+// It wraps the engine, advisor registry, source loader, and consultation logger
+// behind a single Client so other Go tools can embed devwisdom without
+// vendoring or forking it:
+//
+//	client, err := devwisdom.New(
+//		devwisdom.WithProjectRoot("."),
+//		devwisdom.WithConsultationLog(".devwisdom"),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer client.Close()
+//
+//	quote, err := client.Quote(ctx, 75, "stoic")
+//
+// # Compatibility
+//
+// This package follows semantic versioning. Within a major version, exported
+// identifiers in this package are not removed or changed incompatibly:
+// functions keep their signatures, struct fields keep their names, types and
+// JSON tags, and sentinel errors keep their identity for errors.Is. New
+// options, methods, and struct fields may be added in minor releases, so do
+// not rely on positional struct literals or on implementing interfaces
+// declared here beyond Clock and Logger.
+//
+// Everything under internal/ is an implementation detail and may change at
+// any time.
+package devwisdom
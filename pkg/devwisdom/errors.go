@@ -0,0 +1,58 @@
+package devwisdom
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// Sentinel errors returned by Client methods. Errors returned by the client
+// wrap one of these, so callers should test for them with errors.Is.
+var (
+	// ErrUnknownSource is returned when the requested wisdom source does not exist.
+	ErrUnknownSource = wisdom.ErrUnknownSource
+	// ErrNoSources is returned when no wisdom sources could be loaded.
+	ErrNoSources = wisdom.ErrNoSources
+	// ErrUnknownAdvisor is returned when no advisor is mapped to the requested metric, tool, or stage.
+	ErrUnknownAdvisor = wisdom.ErrUnknownAdvisor
+	// ErrDisabled is returned when wisdom output is disabled by configuration
+	// (EXARP_DISABLE_WISDOM=1, a .exarp_no_wisdom marker file, or the config file).
+	// Callers should treat it as "produce no output" rather than as a failure.
+	ErrDisabled = wisdom.ErrDisabled
+	// ErrSourceFiltered is returned when a source is excluded by the Hebrew
+	// language settings (EXARP_WISDOM_HEBREW, EXARP_WISDOM_HEBREW_ONLY).
+	ErrSourceFiltered = wisdom.ErrSourceFiltered
+	// ErrUnknownQuote is returned when no loaded quote has the requested ID.
+	ErrUnknownQuote = wisdom.ErrUnknownQuote
+	// ErrInvalidRequest is returned when request parameters are out of range or inconsistent.
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrClosed is returned when a method is called after Close.
+	ErrClosed = errors.New("client closed")
+)
+
+// Error describes a failed Client operation.
+// Op is the method that failed (e.g. "quote", "consult") and Err is the
+// underlying cause, which usually wraps one of the sentinel errors above.
+type Error struct {
+	Op  string
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("devwisdom %s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying cause so errors.Is and errors.As work.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// wrapErr wraps err in an *Error for op. It returns nil for a nil error.
+func wrapErr(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Op: op, Err: err}
+}
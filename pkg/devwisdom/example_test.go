@@ -0,0 +1,35 @@
+package devwisdom_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/davidl71/devwisdom-go/pkg/devwisdom"
+)
+
+func ExampleClient_Consult() {
+	client, err := devwisdom.New(devwisdom.WithConsultationLog(".devwisdom"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	consultation, err := client.Consult(context.Background(), devwisdom.ConsultRequest{
+		Metric: "security",
+		Score:  42,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%s %s: %q\n", consultation.AdvisorIcon, consultation.Advisor, consultation.Quote)
+}
+
+func ExampleAeonLevel() {
+	fmt.Println(devwisdom.AeonLevel(25))
+	fmt.Println(devwisdom.AeonLevel(90))
+	// Output:
+	// chaos
+	// treasury
+}
@@ -0,0 +1,183 @@
+package devwisdom
+
+import (
+	"time"
+
+	"github.com/davidl71/devwisdom-go/internal/wisdom"
+)
+
+// Clock supplies the current time. It lets callers control timestamps and
+// date-based behavior (e.g. in tests).
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts an ordinary function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// systemClock is the default Clock backed by time.Now.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Logger receives non-fatal diagnostics, such as a consultation that could not
+// be written to the log. *log.Logger satisfies this interface.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// nopLogger discards all diagnostics.
+type nopLogger struct{}
+
+func (nopLogger) Printf(string, ...interface{}) {}
+
+// options holds Client configuration collected from Option values.
+type options struct {
+	configPaths   []string
+	projectRoot   string
+	clock         Clock
+	logger        Logger
+	consultLogDir string
+	cacheTTL      time.Duration
+	cacheMaxAge   time.Duration
+	cacheDisabled bool
+	httpTimeout   time.Duration
+	rotation      string
+	historyDir    string
+	historyUser   string
+	timezone      string
+	dayStartHour  int
+	daySet        bool
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+func defaultOptions() *options {
+	return &options{
+		clock:  systemClock{},
+		logger: nopLogger{},
+	}
+}
+
+// WithConfigPaths adds sources.json files to load, in order (later files override earlier ones).
+// Paths are loaded in addition to the default search locations (project .wisdom/, home, XDG config).
+func WithConfigPaths(paths ...string) Option {
+	return func(o *options) {
+		o.configPaths = append(o.configPaths, paths...)
+	}
+}
+
+// WithProjectRoot sets the project root used for project-specific sources (<root>/.wisdom/sources.json).
+// By default the root is detected from the working directory.
+func WithProjectRoot(root string) Option {
+	return func(o *options) {
+		o.projectRoot = root
+	}
+}
+
+// WithClock sets the clock used for consultation timestamps, briefing dates,
+// daily quote selection, and source cache expiry.
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		if clock != nil {
+			o.clock = clock
+		}
+	}
+}
+
+// WithLogger sets the destination for non-fatal diagnostics. By default they are discarded.
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithConsultationLog enables JSONL consultation logging in dir (e.g. ".devwisdom").
+// The log format and rotation are shared with the devwisdom MCP server.
+func WithConsultationLog(dir string) Option {
+	return func(o *options) {
+		o.consultLogDir = dir
+	}
+}
+
+// WithCacheTTL sets how long parsed source files stay cached.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.cacheTTL = ttl
+	}
+}
+
+// WithCacheMaxAge sets the maximum age of any cached source file.
+func WithCacheMaxAge(maxAge time.Duration) Option {
+	return func(o *options) {
+		o.cacheMaxAge = maxAge
+	}
+}
+
+// WithCacheDisabled disables source file caching.
+func WithCacheDisabled() Option {
+	return func(o *options) {
+		o.cacheDisabled = true
+	}
+}
+
+// WithHTTPTimeout sets the timeout for API-backed sources such as Sefaria.
+func WithHTTPTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.httpTimeout = timeout
+	}
+}
+
+// Quote rotation modes for WithQuoteRotation.
+const (
+	RotationDaily      = wisdom.RotationDaily      // Same date-seeded quote all day (default)
+	RotationCycle      = wisdom.RotationCycle      // New quote per request, no repeats until all are seen
+	RotationCycleDaily = wisdom.RotationCycleDaily // Like RotationCycle, advancing once per day
+)
+
+// WithQuoteRotation sets the quote rotation mode, overriding the
+// EXARP_WISDOM_ROTATION and config file settings. The cycling modes keep a
+// per-user history (see WithHistoryDir).
+func WithQuoteRotation(mode string) Option {
+	return func(o *options) {
+		o.rotation = mode
+	}
+}
+
+// WithHistoryDir sets the directory of the cycling rotation modes' history
+// (default ".devwisdom"). It is only written when such a mode is in effect.
+func WithHistoryDir(dir string) Option {
+	return func(o *options) {
+		o.historyDir = dir
+	}
+}
+
+// WithHistoryUser sets the user whose rotation history is used. By default
+// it is the current OS user.
+func WithHistoryUser(user string) Option {
+	return func(o *options) {
+		o.historyUser = user
+	}
+}
+
+// WithDayBoundary sets when a "day" starts for daily quote selection, briefing
+// dates, and consultation log rotation: at startHour (0-23) in the IANA time
+// zone timezone (e.g. "UTC"; "" for the local zone). It overrides the
+// EXARP_WISDOM_TIMEZONE and EXARP_WISDOM_DAY_START_HOUR settings.
+func WithDayBoundary(timezone string, startHour int) Option {
+	return func(o *options) {
+		o.timezone = timezone
+		o.dayStartHour = startHour
+		o.daySet = true
+	}
+}
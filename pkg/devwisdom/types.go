@@ -0,0 +1,233 @@
+package devwisdom
+
+import "github.com/davidl71/devwisdom-go/internal/wisdom"
+
+// Quote is a wisdom quote selected for a project health score.
+type Quote struct {
+	ID            string `json:"id,omitempty"` // Stable quote ID, usable with Client.QuoteByID
+	Text          string `json:"quote"`
+	Translation   string `json:"translation,omitempty"` // Translation of Text, when shown by the Hebrew settings
+	Source        string `json:"source"`
+	Encouragement string `json:"encouragement"`
+	WisdomSource  string `json:"wisdom_source,omitempty"`
+	WisdomIcon    string `json:"wisdom_icon,omitempty"`
+}
+
+// SourceInfo describes a loaded wisdom source.
+type SourceInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Icon        string `json:"icon"`
+	Description string `json:"description,omitempty"`
+}
+
+// Diagnostic is a problem found in a sources.json file by ValidateSourceFiles
+// or Client.ValidateSources.
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`   // 1-based; 0 if the problem concerns the whole file
+	Column   int    `json:"column,omitempty"` // 1-based, in characters
+	Severity string `json:"severity"`         // "error", "warning" or "info"
+	Code     string `json:"code"`             // e.g. "syntax", "unknown_field", "duplicate_quote"
+	Source   string `json:"source,omitempty"` // Source ID, if the problem concerns a source
+	Message  string `json:"message"`
+}
+
+// String formats the diagnostic as "file:line:column: severity: message (code)".
+func (d Diagnostic) String() string {
+	return diagnosticToInternal(d).String()
+}
+
+// Mode describes the consultation mode for a project health score
+// (chaos, building, maturing, mastery).
+type Mode struct {
+	Name        string `json:"name"`
+	Icon        string `json:"icon"`
+	Frequency   string `json:"frequency"`
+	Description string `json:"description"`
+}
+
+// ConsultRequest selects an advisor by metric, tool, or stage (checked in that order).
+// If none is set, the default advisor (pistis_sophia) is consulted.
+type ConsultRequest struct {
+	Metric  string
+	Tool    string
+	Stage   string
+	Score   float64 // Project health score, 0-100
+	Context string  // Free-form context recorded with the consultation
+}
+
+// Consultation is the result of consulting an advisor.
+// Its JSON form matches the entries written to the consultation log.
+type Consultation struct {
+	Timestamp        string  `json:"timestamp"`
+	ConsultationType string  `json:"consultation_type"`
+	Advisor          string  `json:"advisor"`
+	AdvisorIcon      string  `json:"advisor_icon"`
+	AdvisorName      string  `json:"advisor_name"`
+	Rationale        string  `json:"rationale"`
+	Metric           string  `json:"metric,omitempty"`
+	Tool             string  `json:"tool,omitempty"`
+	Stage            string  `json:"stage,omitempty"`
+	ScoreAtTime      float64 `json:"score_at_time"`
+	ConsultationMode string  `json:"consultation_mode"`
+	ModeIcon         string  `json:"mode_icon"`
+	ModeFrequency    string  `json:"mode_frequency"`
+	QuoteID          string  `json:"quote_id,omitempty"`
+	Quote            string  `json:"quote"`
+	QuoteTranslation string  `json:"quote_translation,omitempty"`
+	QuoteSource      string  `json:"quote_source"`
+	Encouragement    string  `json:"encouragement"`
+	Context          string  `json:"context,omitempty"`
+	SessionMode      string  `json:"session_mode,omitempty"`
+	ModeGuidance     string  `json:"mode_guidance,omitempty"`
+}
+
+// BriefingRequest holds the inputs for a daily briefing.
+type BriefingRequest struct {
+	Score        float64            // Overall project score, 0-100, used for the consultation mode
+	MetricScores map[string]float64 // Per-metric scores, 0-100; the weakest ones get advice
+	Limit        int                // Number of weakest metrics to include (default: 3)
+	Days         int                // Days of consultation log history compared for trends (default: 7)
+}
+
+// Trend compares a metric score with its most recent earlier consultation.
+type Trend struct {
+	PreviousScore float64 `json:"previous_score"`
+	Change        float64 `json:"change"`
+	Direction     string  `json:"direction"` // "improving", "declining" or "steady"
+	Since         string  `json:"since"`     // Timestamp of the previous consultation
+}
+
+// BriefingEntry is the advice for a single weak metric.
+type BriefingEntry struct {
+	Metric      string  `json:"metric"`
+	Score       float64 `json:"score"`
+	Advisor     string  `json:"advisor"`
+	AdvisorIcon string  `json:"advisor_icon"`
+	Rationale   string  `json:"rationale,omitempty"`
+	Quote       Quote   `json:"quote"`
+	Trend       *Trend  `json:"trend,omitempty"` // Nil without an earlier logged consultation for the metric
+}
+
+// Briefing is a daily advisor briefing.
+type Briefing struct {
+	Date    string          `json:"date"`
+	Score   float64         `json:"overall_score"`
+	Mode    Mode            `json:"consultation_mode"`
+	Entries []BriefingEntry `json:"advisory_quotes"`
+}
+
+// AeonLevel returns the aeon level (chaos, lower_aeons, middle_aeons,
+// upper_aeons, treasury) used to select quotes for score.
+func AeonLevel(score float64) string {
+	return wisdom.GetAeonLevel(score)
+}
+
+// ConsultationMode returns the consultation mode for score.
+func ConsultationMode(score float64) Mode {
+	return modeFromInternal(wisdom.GetConsultationMode(score))
+}
+
+func quoteFromInternal(q *wisdom.Quote) *Quote {
+	return &Quote{
+		ID:            q.ID,
+		Text:          q.Quote,
+		Translation:   q.Translation,
+		Source:        q.Source,
+		Encouragement: q.Encouragement,
+		WisdomSource:  q.WisdomSource,
+		WisdomIcon:    q.WisdomIcon,
+	}
+}
+
+func briefingEntryFromInternal(e wisdom.BriefingEntry) BriefingEntry {
+	entry := BriefingEntry{
+		Metric:      e.Metric,
+		Score:       e.Score,
+		Advisor:     e.Advisor,
+		AdvisorIcon: e.AdvisorIcon,
+		Rationale:   e.Rationale,
+		Quote: Quote{
+			ID:            e.QuoteID,
+			Text:          e.Quote,
+			Translation:   e.Translation,
+			Source:        e.Source,
+			Encouragement: e.Encouragement,
+			WisdomSource:  e.WisdomSource,
+			WisdomIcon:    e.WisdomIcon,
+		},
+	}
+	if e.Trend != nil {
+		entry.Trend = &Trend{
+			PreviousScore: e.Trend.PreviousScore,
+			Change:        e.Trend.Change,
+			Direction:     e.Trend.Direction,
+			Since:         e.Trend.Since,
+		}
+	}
+	return entry
+}
+
+func modeFromInternal(m wisdom.ConsultationModeConfig) Mode {
+	return Mode{
+		Name:        m.Name,
+		Icon:        m.Icon,
+		Frequency:   m.Frequency,
+		Description: m.Description,
+	}
+}
+
+func consultationFromInternal(c *wisdom.Consultation) *Consultation {
+	return &Consultation{
+		Timestamp:        c.Timestamp,
+		ConsultationType: c.ConsultationType,
+		Advisor:          c.Advisor,
+		AdvisorIcon:      c.AdvisorIcon,
+		AdvisorName:      c.AdvisorName,
+		Rationale:        c.Rationale,
+		Metric:           c.Metric,
+		Tool:             c.Tool,
+		Stage:            c.Stage,
+		ScoreAtTime:      c.ScoreAtTime,
+		ConsultationMode: c.ConsultationMode,
+		ModeIcon:         c.ModeIcon,
+		ModeFrequency:    c.ModeFrequency,
+		QuoteID:          c.QuoteID,
+		Quote:            c.Quote,
+		QuoteTranslation: c.QuoteTranslation,
+		QuoteSource:      c.QuoteSource,
+		Encouragement:    c.Encouragement,
+		Context:          c.Context,
+		SessionMode:      c.SessionMode,
+		ModeGuidance:     c.ModeGuidance,
+	}
+}
+
+func diagnosticsFromInternal(diagnostics []wisdom.Diagnostic) []Diagnostic {
+	result := make([]Diagnostic, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		result = append(result, Diagnostic{
+			File:     d.File,
+			Line:     d.Line,
+			Column:   d.Column,
+			Severity: string(d.Severity),
+			Code:     d.Code,
+			Source:   d.Source,
+			Message:  d.Message,
+		})
+	}
+	return result
+}
+
+func diagnosticToInternal(d Diagnostic) wisdom.Diagnostic {
+	return wisdom.Diagnostic{
+		File:     d.File,
+		Line:     d.Line,
+		Column:   d.Column,
+		Severity: wisdom.Severity(d.Severity),
+		Code:     d.Code,
+		Source:   d.Source,
+		Message:  d.Message,
+	}
+}